@@ -0,0 +1,166 @@
+// Package client is a typed Go SDK for the Bulls and Cows REST API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_MAX_RETRIES = 3
+	DEFAULT_MIN_BACKOFF = 100 * time.Millisecond
+	DEFAULT_MAX_BACKOFF = 2 * time.Second
+)
+
+type Client struct {
+	baseURL    string
+	language   string
+	http       *http.Client
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+type Option func(c *Client)
+
+// WithHTTPClient replaces the default http.Client. Keep its Timeout at zero when
+// using WatchMatch, the event stream stays open for the whole match.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithLanguage sets the Accept-Language of every request, which localizes the
+// detail of error responses.
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.language = language
+	}
+}
+
+// WithRetries configures how many times a request answered with a 5xx status is
+// retried and the bounds of the exponential backoff between attempts.
+func WithRetries(maxRetries int, minBackoff time.Duration, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.minBackoff = minBackoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// New returns a client for the API under baseURL, e.g. http://localhost:3000/api/v1.
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		http:       &http.Client{},
+		maxRetries: DEFAULT_MAX_RETRIES,
+		minBackoff: DEFAULT_MIN_BACKOFF,
+		maxBackoff: DEFAULT_MAX_BACKOFF,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body any, out any) error {
+	res, err := c.send(ctx, method, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// send performs the request, retrying with backoff while the API answers with a
+// 5xx status. Any other error status is returned as an *Error.
+func (c *Client) send(ctx context.Context, method string, path string, body any, accept string) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, path, payload, accept)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode < http.StatusBadRequest {
+			return res, nil
+		}
+
+		apiErr := decodeError(res)
+		res.Body.Close()
+
+		if res.StatusCode < http.StatusInternalServerError || attempt >= c.maxRetries {
+			return nil, apiErr
+		}
+
+		if err := sleep(ctx, max(c.backoff(attempt), apiErr.RetryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, payload []byte, accept string) (*http.Request, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", accept)
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	return req, nil
+}
+
+// backoff doubles minBackoff on every attempt up to maxBackoff and picks a random
+// delay between half and all of it, so clients that failed together do not retry
+// together.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.maxBackoff
+	if attempt < 32 && c.minBackoff<<attempt < c.maxBackoff {
+		delay = c.minBackoff << attempt
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return New(server.URL+"/api/v1", WithRetries(2, time.Millisecond, 5*time.Millisecond))
+}
+
+func writeProblem(w http.ResponseWriter, status int, code contracts.ErrorCode, detail string) {
+	w.Header().Set("Content-Type", contracts.PROBLEM_CONTENT_TYPE)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(contracts.ProblemResponse{
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Code:      code,
+		RequestId: "req-1",
+	})
+}
+
+func TestCreateRoom(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/matches/create" {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Accept-Language"); got != "es" {
+			t.Errorf("expected Accept-Language es, got %q", got)
+		}
+
+		command := contracts.CreateRoomCommand{}
+		if err := json.NewDecoder(r.Body).Decode(&command); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(contracts.CreateRoomResponse{
+			RoomId: "room1",
+			Mode:   command.Mode,
+			BestOf: command.BestOf,
+			Player: contracts.PlayerCredentialsResponse{Id: "p1", Username: command.Username},
+		})
+	})
+	WithLanguage("es")(c)
+
+	res, err := c.CreateRoom(context.Background(), contracts.CreateRoomCommand{Username: "alice", Mode: domain.MatchModeRace, BestOf: 3})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if res.RoomId != "room1" || res.Player.Username != "alice" || res.Mode != domain.MatchModeRace || res.BestOf != 3 {
+		t.Fatalf("unexpected response %+v", res)
+	}
+}
+
+func TestEndpointsUseTheirRoutes(t *testing.T) {
+	var got string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method + " " + r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{}"))
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		call func() error
+		want string
+	}{
+		{func() error { _, err := c.JoinRoom(ctx, "r1", "bob"); return err }, "PUT /api/v1/matches/join/r1"},
+		{func() error { _, err := c.SetCombination(ctx, "r1", "p1", 1234); return err }, "PUT /api/v1/matches/setCombination/r1"},
+		{func() error { _, err := c.StartGame(ctx, "r1"); return err }, "PUT /api/v1/matches/startGame/r1"},
+		{func() error { _, err := c.MakeGuess(ctx, "r1", "p1", 5678); return err }, "PUT /api/v1/matches/makeGuess/r1"},
+		{func() error { _, err := c.OfferRematch(ctx, "r1", "p1"); return err }, "PUT /api/v1/matches/rematch/offer/r1"},
+		{func() error { _, err := c.AcceptRematch(ctx, "r1", "p2"); return err }, "PUT /api/v1/matches/rematch/accept/r1"},
+		{func() error { _, err := c.DeclineRematch(ctx, "r1", "p2"); return err }, "PUT /api/v1/matches/rematch/decline/r1"},
+		{func() error { _, err := c.GetMatch(ctx, "r1"); return err }, "GET /api/v1/matches/r1"},
+		{func() error { _, err := c.SendChatMessage(ctx, "r1", "p1", "hi"); return err }, "POST /api/v1/matches/chat/r1"},
+		{func() error { _, err := c.GetChat(ctx, "r1"); return err }, "GET /api/v1/matches/chat/r1"},
+		{func() error { _, err := c.VerifyCommitment(ctx, "digest", "1234", "00ff"); return err }, "POST /api/v1/matches/verify"},
+	}
+
+	for _, test := range tests {
+		if err := test.call(); err != nil {
+			t.Errorf("%v: %v", test.want, err)
+		}
+		if got != test.want {
+			t.Errorf("expected %v, got %v", test.want, got)
+		}
+	}
+}
+
+func TestRetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			writeProblem(w, http.StatusServiceUnavailable, contracts.ErrorCodeInternal, "")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(contracts.StartMatchResponse{Mode: domain.MatchModeTurns, IsTurnOf: "p1"})
+	})
+
+	res, err := c.StartGame(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("StartGame: %v", err)
+	}
+	if res.IsTurnOf != "p1" {
+		t.Fatalf("unexpected response %+v", res)
+	}
+	if attempts.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %v", attempts.Load())
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		writeProblem(w, http.StatusInternalServerError, contracts.ErrorCodeInternal, "")
+	})
+
+	_, err := c.GetMatch(context.Background(), "r1")
+	if !errors.Is(err, ErrInternal) {
+		t.Fatalf("expected ErrInternal, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %v", attempts.Load())
+	}
+}
+
+func TestRetryStopsWhenContextIsCancelled(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, http.StatusBadGateway, contracts.ErrorCodeInternal, "")
+	})
+	WithRetries(5, time.Hour, time.Hour)(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetMatch(ctx, "r1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+}
+
+func TestClientErrorsAreTypedAndNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		writeProblem(w, http.StatusConflict, contracts.ErrorCodeNotYourTurn, "Wait for your opponent to play")
+	})
+
+	_, err := c.MakeGuess(context.Background(), "r1", "p1", 1234)
+	if !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("expected ErrNotYourTurn, got %v", err)
+	}
+	if errors.Is(err, ErrMatchFinished) {
+		t.Fatalf("did not expect ErrMatchFinished to match %v", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *Error, got %T", err)
+	}
+	if apiErr.Status != http.StatusConflict || apiErr.RequestId != "req-1" || apiErr.Detail != "Wait for your opponent to play" {
+		t.Fatalf("unexpected error %+v", apiErr)
+	}
+	if attempts.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %v", attempts.Load())
+	}
+}
+
+func TestErrorWithoutProblemBody(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("<html>slow down</html>"))
+	})
+
+	_, err := c.GetChat(context.Background(), "r1")
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if apiErr.Status != http.StatusTooManyRequests || apiErr.Title != "Too Many Requests" || apiErr.RetryAfter != 7*time.Second {
+		t.Fatalf("unexpected error %+v", apiErr)
+	}
+}
+
+func writeEvents(w http.ResponseWriter, events ...contracts.MatchEvent) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	fmt.Fprint(w, ": ping\n\n")
+	for _, event := range events {
+		data, _ := json.Marshal(event)
+		fmt.Fprintf(w, "event: %v\ndata: %s\n\n", event.Type, data)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/matches/watch/r1" || r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected request %v %v", r.URL.Path, r.Header.Get("Accept"))
+		}
+		writeEvents(w,
+			contracts.MatchEvent{Type: contracts.MatchEventSnapshot, Match: &contracts.MatchStateResponse{RoomId: "r1", Status: domain.MatchStateWaiting}},
+			contracts.MatchEvent{Type: contracts.MatchEventMatchClosed},
+		)
+	})
+
+	subscription, err := c.Subscribe(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer subscription.Close()
+
+	var received []contracts.MatchEvent
+	for event := range subscription.Events {
+		received = append(received, event)
+	}
+
+	if len(received) != 2 || received[0].Match == nil || received[0].Match.RoomId != "r1" || received[1].Type != contracts.MatchEventMatchClosed {
+		t.Fatalf("unexpected events %+v", received)
+	}
+	if err := subscription.Err(); err != nil {
+		t.Fatalf("unexpected stream error %v", err)
+	}
+}
+
+func TestSubscribeUnknownRoom(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, http.StatusNotFound, contracts.ErrorCodeMatchNotFound, "")
+	})
+
+	if _, err := c.Subscribe(context.Background(), "r1"); !errors.Is(err, ErrMatchNotFound) {
+		t.Fatalf("expected ErrMatchNotFound, got %v", err)
+	}
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeEvents(w, contracts.MatchEvent{Type: contracts.MatchEventSnapshot, Match: &contracts.MatchStateResponse{RoomId: "r1"}})
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	subscription, err := c.Subscribe(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	if event := <-subscription.Events; event.Type != contracts.MatchEventSnapshot {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	subscription.Close()
+	if _, open := <-subscription.Events; open {
+		t.Fatal("expected the events channel to be closed")
+	}
+	if err := subscription.Err(); err != nil {
+		t.Fatalf("unexpected stream error %v", err)
+	}
+}
+
+func TestWatchMatchStopsWhenHandlerReturnsFalse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeEvents(w,
+			contracts.MatchEvent{Type: contracts.MatchEventSnapshot},
+			contracts.MatchEvent{Type: contracts.MatchEventGuessMade},
+			contracts.MatchEvent{Type: contracts.MatchEventMatchFinished},
+		)
+	})
+
+	var types []contracts.MatchEventType
+	err := c.WatchMatch(context.Background(), "r1", func(event contracts.MatchEvent) bool {
+		types = append(types, event.Type)
+		return event.Type != contracts.MatchEventGuessMade
+	})
+	if err != nil {
+		t.Fatalf("WatchMatch: %v", err)
+	}
+	if len(types) != 2 || types[1] != contracts.MatchEventGuessMade {
+		t.Fatalf("unexpected events %v", types)
+	}
+}
+
+func TestCheckMatchCommitments(t *testing.T) {
+	digest := domain.CommitSecret("1234", "00ff")
+	match := &contracts.MatchStateResponse{
+		Commitments: map[string]contracts.CommitmentResponse{
+			"p1": {Commitment: digest, Secret: "1234", Salt: "00ff"},
+			"p2": {Commitment: digest, Secret: "4321", Salt: "00ff"},
+			"p3": {Commitment: digest},
+		},
+	}
+
+	results := CheckMatchCommitments(match, nil)
+	if len(results) != 2 || !results["p1"] || results["p2"] {
+		t.Fatalf("expected p1 to check out and p2 not, got %v", results)
+	}
+
+	results = CheckMatchCommitments(match, map[string]string{"p1": domain.CommitSecret("5678", "00ff")})
+	if results["p1"] {
+		t.Fatal("expected a commitment replaced after the start to fail")
+	}
+}
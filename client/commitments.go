@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+// VerifyCommitment asks the server to check a revealed secret, CheckCommitment does
+// the same without trusting it.
+func (c *Client) VerifyCommitment(ctx context.Context, commitment string, secret string, salt string) (*contracts.VerifyCommitmentResponse, error) {
+	res := &contracts.VerifyCommitmentResponse{}
+	command := contracts.VerifyCommitmentCommand{Commitment: commitment, Secret: secret, Salt: salt}
+	return res, c.do(ctx, http.MethodPost, "/matches/verify", command, res)
+}
+
+// CheckCommitment recomputes SHA-256(secret || salt) and compares it with the
+// commitment published when the match or the daily attempt started.
+func CheckCommitment(commitment string, secret string, salt string) bool {
+	return domain.VerifyCommitment(commitment, secret, salt)
+}
+
+// CheckMatchCommitments checks every secret revealed in a finished match, keyed by
+// the player who set it. Pass the commitments of StartMatchResponse as published to
+// also make sure the server did not replace them afterwards, or nil to skip it.
+func CheckMatchCommitments(match *contracts.MatchStateResponse, published map[string]string) map[string]bool {
+	results := make(map[string]bool, len(match.Commitments))
+	for playerId, commitment := range match.Commitments {
+		if commitment.Secret == "" {
+			continue
+		}
+		valid := CheckCommitment(commitment.Commitment, commitment.Secret, commitment.Salt)
+		if digest, ok := published[playerId]; ok && digest != commitment.Commitment {
+			valid = false
+		}
+		results[playerId] = valid
+	}
+	return results
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+)
+
+// Error is a problem response returned by the API. Use errors.Is with the ErrXxx
+// values below to check the error code, or errors.As to read the details.
+type Error struct {
+	contracts.ProblemResponse
+	// RetryAfter is set when the API asked to wait before trying again.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Detail != "":
+		return fmt.Sprintf("%v (%v)", e.Detail, e.Code)
+	case e.Title != "" && e.Code != "":
+		return fmt.Sprintf("%v (%v)", e.Title, e.Code)
+	case e.Title != "":
+		return fmt.Sprintf("%v (%v)", e.Title, e.Status)
+	}
+	return string(e.Code)
+}
+
+// Is reports whether target is an *Error with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+func codeError(code contracts.ErrorCode) *Error {
+	return &Error{ProblemResponse: contracts.ProblemResponse{Code: code}}
+}
+
+var (
+	ErrInternal            = codeError(contracts.ErrorCodeInternal)
+	ErrInvalidRequest      = codeError(contracts.ErrorCodeInvalidRequest)
+	ErrValidationFailed    = codeError(contracts.ErrorCodeValidationFailed)
+	ErrRateLimited         = codeError(contracts.ErrorCodeRateLimited)
+	ErrInvalidCombination  = codeError(contracts.ErrorCodeInvalidCombination)
+	ErrRepeatedDigits      = codeError(contracts.ErrorCodeRepeatedDigits)
+	ErrResourceNotFound    = codeError(contracts.ErrorCodeResourceNotFound)
+	ErrRoomFull            = codeError(contracts.ErrorCodeRoomFull)
+	ErrRoomNotReady        = codeError(contracts.ErrorCodeRoomNotReady)
+	ErrMatchNotFound       = codeError(contracts.ErrorCodeMatchNotFound)
+	ErrMatchExpired        = codeError(contracts.ErrorCodeMatchExpired)
+	ErrCombinationsPending = codeError(contracts.ErrorCodeCombinationsPending)
+	ErrMatchNotStarted     = codeError(contracts.ErrorCodeMatchNotStarted)
+	ErrMatchFinished       = codeError(contracts.ErrorCodeMatchFinished)
+	ErrNotYourTurn         = codeError(contracts.ErrorCodeNotYourTurn)
+	ErrAlreadySolved       = codeError(contracts.ErrorCodeAlreadySolved)
+	ErrSeriesOver          = codeError(contracts.ErrorCodeSeriesOver)
+	ErrMatchNotFinished    = codeError(contracts.ErrorCodeMatchNotFinished)
+	ErrRematchOffered      = codeError(contracts.ErrorCodeRematchAlreadyOffered)
+	ErrNoRematchOffer      = codeError(contracts.ErrorCodeNoRematchOffer)
+	ErrOwnRematchOffer     = codeError(contracts.ErrorCodeOwnRematchOffer)
+	ErrPlayerNotInRoom     = codeError(contracts.ErrorCodePlayerNotInRoom)
+)
+
+// decodeError reads the problem response, falling back to the status line when
+// the body is not a problem, e.g. when a proxy answered.
+func decodeError(res *http.Response) *Error {
+	apiErr := &Error{
+		ProblemResponse: contracts.ProblemResponse{Status: res.StatusCode, Title: http.StatusText(res.StatusCode)},
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if mediaType == contracts.PROBLEM_CONTENT_TYPE || mediaType == "application/json" {
+		json.NewDecoder(res.Body).Decode(&apiErr.ProblemResponse)
+	}
+	apiErr.Status = res.StatusCode
+
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return apiErr
+}
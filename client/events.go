@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+)
+
+// WatchMatch reads the server-sent events of a room and calls handle for each one
+// until handle returns false, the stream ends or ctx is cancelled. The first event
+// is a snapshot of the match and the last one, when the room goes away, is
+// match_expired or match_closed.
+func (c *Client) WatchMatch(ctx context.Context, roomId string, handle func(event contracts.MatchEvent) bool) error {
+	res, err := c.send(ctx, http.MethodGet, "/matches/watch/"+url.PathEscape(roomId), nil, "text/event-stream")
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := readEvents(res.Body, handle); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func readEvents(body io.Reader, handle func(event contracts.MatchEvent) bool) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			event := contracts.MatchEvent{}
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return fmt.Errorf("decoding match event: %w", err)
+			}
+			data.Reset()
+			if !handle(event) {
+				return nil
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Subscription delivers the events of a room on a channel. Events is closed when
+// the stream ends; Err then tells why.
+type Subscription struct {
+	Events <-chan contracts.MatchEvent
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+	err    error
+}
+
+// Subscribe opens the event stream of a room. Errors opening it, like an unknown
+// room, are returned right away; call Close to stop receiving.
+func (c *Client) Subscribe(ctx context.Context, roomId string) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	res, err := c.send(ctx, http.MethodGet, "/matches/watch/"+url.PathEscape(roomId), nil, "text/event-stream")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	events := make(chan contracts.MatchEvent)
+	s := &Subscription{
+		Events: events,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		defer close(events)
+		defer res.Body.Close()
+
+		err := readEvents(res.Body, func(event contracts.MatchEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			s.mu.Lock()
+			s.err = err
+			s.mu.Unlock()
+		}
+	}()
+
+	return s, nil
+}
+
+// Err returns the error that ended the stream, nil when it ended normally or was
+// closed.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close stops the subscription and waits for the stream to be released.
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+}
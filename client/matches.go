@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+)
+
+const (
+	RematchOffer   = "offer"
+	RematchAccept  = "accept"
+	RematchDecline = "decline"
+)
+
+func (c *Client) CreateRoom(ctx context.Context, command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error) {
+	res := &contracts.CreateRoomResponse{}
+	return res, c.do(ctx, http.MethodPost, "/matches/create", command, res)
+}
+
+func (c *Client) JoinRoom(ctx context.Context, roomId string, username string) (*contracts.JoinRoomResponse, error) {
+	res := &contracts.JoinRoomResponse{}
+	command := contracts.JoinRoomCommand{Username: username}
+	return res, c.do(ctx, http.MethodPut, "/matches/join/"+url.PathEscape(roomId), command, res)
+}
+
+func (c *Client) SetCombination(ctx context.Context, roomId string, playerId string, combination int) (*contracts.SuccessResponse, error) {
+	res := &contracts.SuccessResponse{}
+	command := contracts.SetCombinationCommand{PlayerId: playerId, Combination: combination}
+	return res, c.do(ctx, http.MethodPut, "/matches/setCombination/"+url.PathEscape(roomId), command, res)
+}
+
+func (c *Client) StartGame(ctx context.Context, roomId string) (*contracts.StartMatchResponse, error) {
+	res := &contracts.StartMatchResponse{}
+	return res, c.do(ctx, http.MethodPut, "/matches/startGame/"+url.PathEscape(roomId), nil, res)
+}
+
+func (c *Client) MakeGuess(ctx context.Context, roomId string, playerId string, guess int) (*contracts.MakeGuessResponse, error) {
+	res := &contracts.MakeGuessResponse{}
+	command := contracts.MakeGuessCommand{PlayerId: playerId, Guess: guess}
+	return res, c.do(ctx, http.MethodPut, "/matches/makeGuess/"+url.PathEscape(roomId), command, res)
+}
+
+func (c *Client) OfferRematch(ctx context.Context, roomId string, playerId string) (*contracts.SuccessResponse, error) {
+	return c.Rematch(ctx, RematchOffer, roomId, playerId)
+}
+
+func (c *Client) AcceptRematch(ctx context.Context, roomId string, playerId string) (*contracts.SuccessResponse, error) {
+	return c.Rematch(ctx, RematchAccept, roomId, playerId)
+}
+
+func (c *Client) DeclineRematch(ctx context.Context, roomId string, playerId string) (*contracts.SuccessResponse, error) {
+	return c.Rematch(ctx, RematchDecline, roomId, playerId)
+}
+
+// Rematch runs one of RematchOffer, RematchAccept or RematchDecline.
+func (c *Client) Rematch(ctx context.Context, action string, roomId string, playerId string) (*contracts.SuccessResponse, error) {
+	res := &contracts.SuccessResponse{}
+	command := contracts.RematchCommand{PlayerId: playerId}
+	return res, c.do(ctx, http.MethodPut, "/matches/rematch/"+url.PathEscape(action)+"/"+url.PathEscape(roomId), command, res)
+}
+
+func (c *Client) GetMatch(ctx context.Context, roomId string) (*contracts.MatchStateResponse, error) {
+	res := &contracts.MatchStateResponse{}
+	return res, c.do(ctx, http.MethodGet, "/matches/"+url.PathEscape(roomId), nil, res)
+}
+
+func (c *Client) SendChatMessage(ctx context.Context, roomId string, playerId string, message string) (*contracts.ChatMessageResponse, error) {
+	res := &contracts.ChatMessageResponse{}
+	command := contracts.ChatMessageCommand{PlayerId: playerId, Message: message}
+	return res, c.do(ctx, http.MethodPost, "/matches/chat/"+url.PathEscape(roomId), command, res)
+}
+
+func (c *Client) GetChat(ctx context.Context, roomId string) (*contracts.ChatResponse, error) {
+	res := &contracts.ChatResponse{}
+	return res, c.do(ctx, http.MethodGet, "/matches/chat/"+url.PathEscape(roomId), nil, res)
+}
@@ -0,0 +1,303 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/config"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/gorilla/mux"
+)
+
+const (
+	adminKey = contextKey("admin")
+
+	DEFAULT_ADMIN_LIST_LIMIT = 100
+)
+
+type AdminController struct {
+	*Controller
+	adminService contracts.IAdminService
+}
+
+func newAdminController(controller *Controller, adminService contracts.IAdminService) *AdminController {
+	return &AdminController{
+		Controller:   controller,
+		adminService: adminService,
+	}
+}
+
+func (ac *AdminController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/rooms", ac.listRoomsHandler).Methods("GET")
+	router.HandleFunc("/rooms/{roomId}", ac.inspectRoomHandler).Methods("GET")
+	router.HandleFunc("/rooms/{roomId}", ac.deleteRoomHandler).Methods("DELETE")
+	router.HandleFunc("/rooms/finish/{roomId}", ac.forceFinishHandler).Methods("PUT")
+	router.HandleFunc("/rooms/kick/{roomId}", ac.kickPlayerHandler).Methods("PUT")
+	router.HandleFunc("/rooms/ttl/{roomId}", ac.extendTTLHandler).Methods("PUT")
+	router.HandleFunc("/stats", ac.statsHandler).Methods("GET")
+	router.HandleFunc("/audit", ac.auditLogHandler).Methods("GET")
+}
+
+// adminAuthMiddleware accepts a bearer token from the configured list and stores the
+// name of its owner in the context, so every audit entry says who did what.
+func adminAuthMiddleware(tokens []config.AdminToken) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || token == "" {
+				unauthorized(w, r)
+				return
+			}
+
+			admin := ""
+			for _, candidate := range tokens {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(candidate.Token)) == 1 {
+					admin = candidate.Name
+				}
+			}
+
+			if admin == "" {
+				unauthorized(w, r)
+				return
+			}
+
+			addLogFields(r, "admin", admin)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), adminKey, admin)))
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+	localizer := localizerFromContext(r.Context())
+	WriteProblem(w, r, newProblem(r, http.StatusUnauthorized, contracts.ErrorCodeUnauthorized, localizer.Detail(contracts.ErrorCodeUnauthorized)))
+}
+
+func adminActor(r *http.Request) contracts.AdminActor {
+	admin, _ := r.Context().Value(adminKey).(string)
+	return contracts.AdminActor{
+		Name:      admin,
+		RequestId: requestIdFromContext(r.Context()),
+	}
+}
+
+func (ac *AdminController) listRoomsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	query := contracts.ListRoomsQuery{
+		Status: domain.MatchStatus(r.URL.Query().Get("status")),
+		Limit:  limit,
+	}
+
+	if err := Validate.Struct(query); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := ac.adminService.ListRooms(r.Context(), adminActor(r), query)
+
+	if err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) inspectRoomHandler(w http.ResponseWriter, r *http.Request) {
+	roomId := mux.Vars(r)["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := ac.adminService.InspectRoom(r.Context(), adminActor(r), roomId)
+
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) deleteRoomHandler(w http.ResponseWriter, r *http.Request) {
+	roomId := mux.Vars(r)["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := ac.adminService.DeleteRoom(r.Context(), adminActor(r), roomId)
+
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) forceFinishHandler(w http.ResponseWriter, r *http.Request) {
+	roomId := mux.Vars(r)["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	payload := &contracts.ForceFinishCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.RoomId = roomId
+
+	res, err := ac.adminService.ForceFinish(r.Context(), adminActor(r), *payload)
+
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) kickPlayerHandler(w http.ResponseWriter, r *http.Request) {
+	roomId := mux.Vars(r)["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	payload := &contracts.KickPlayerCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.RoomId = roomId
+
+	res, err := ac.adminService.KickPlayer(r.Context(), adminActor(r), *payload)
+
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) extendTTLHandler(w http.ResponseWriter, r *http.Request) {
+	roomId := mux.Vars(r)["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	payload := &contracts.ExtendTTLCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.RoomId = roomId
+
+	res, err := ac.adminService.ExtendTTL(r.Context(), adminActor(r), *payload)
+
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) statsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := ac.adminService.GetStats(r.Context(), adminActor(r))
+
+	if err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (ac *AdminController) auditLogHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Var(limit, "min=1,max=1000"); err != nil {
+		ac.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := ac.adminService.GetAuditLog(r.Context(), adminActor(r), limit)
+
+	if err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		ac.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func parseLimit(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return DEFAULT_ADMIN_LIST_LIMIT, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/config"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const TEST_ADMIN_TOKEN = "s3cret"
+
+type stubAdminService struct {
+	err error
+	// actor is the last admin the service was called by
+	actor contracts.AdminActor
+}
+
+func (s *stubAdminService) ListRooms(ctx context.Context, actor contracts.AdminActor, query contracts.ListRoomsQuery) (*contracts.AdminRoomsResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.AdminRoomsResponse](s.err)
+}
+
+func (s *stubAdminService) InspectRoom(ctx context.Context, actor contracts.AdminActor, roomId string) (*contracts.AdminRoomResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.AdminRoomResponse](s.err)
+}
+
+func (s *stubAdminService) ForceFinish(ctx context.Context, actor contracts.AdminActor, command contracts.ForceFinishCommand) (*contracts.SuccessResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.SuccessResponse](s.err)
+}
+
+func (s *stubAdminService) DeleteRoom(ctx context.Context, actor contracts.AdminActor, roomId string) (*contracts.SuccessResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.SuccessResponse](s.err)
+}
+
+func (s *stubAdminService) KickPlayer(ctx context.Context, actor contracts.AdminActor, command contracts.KickPlayerCommand) (*contracts.SuccessResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.SuccessResponse](s.err)
+}
+
+func (s *stubAdminService) ExtendTTL(ctx context.Context, actor contracts.AdminActor, command contracts.ExtendTTLCommand) (*contracts.AdminRoomSummaryResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.AdminRoomSummaryResponse](s.err)
+}
+
+func (s *stubAdminService) GetStats(ctx context.Context, actor contracts.AdminActor) (*contracts.AdminStatsResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.AdminStatsResponse](s.err)
+}
+
+func (s *stubAdminService) GetAuditLog(ctx context.Context, actor contracts.AdminActor, limit int) (*contracts.AuditLogResponse, error) {
+	s.actor = actor
+	return stubResponse[contracts.AuditLogResponse](s.err)
+}
+
+var adminEndpoints = []handlerEndpoint{
+	{"list", "GET", "/admin/rooms?status=Playing&limit=10", ``, http.StatusOK, false},
+	{"inspect", "GET", "/admin/rooms/abc1234", ``, http.StatusOK, true},
+	{"delete", "DELETE", "/admin/rooms/abc1234", ``, http.StatusOK, true},
+	{"finish", "PUT", "/admin/rooms/finish/abc1234", `{"winner":"p1"}`, http.StatusOK, true},
+	{"kick", "PUT", "/admin/rooms/kick/abc1234", `{"player_id":"p1"}`, http.StatusOK, true},
+	{"ttl", "PUT", "/admin/rooms/ttl/abc1234", `{"seconds":60}`, http.StatusOK, true},
+	{"stats", "GET", "/admin/stats", ``, http.StatusOK, false},
+	{"audit", "GET", "/admin/audit?limit=5", ``, http.StatusOK, false},
+}
+
+func newAdminTestRouter(adminService contracts.IAdminService) http.Handler {
+	router := mux.NewRouter()
+	adminRouter := router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(adminAuthMiddleware([]config.AdminToken{{Name: "ops", Token: TEST_ADMIN_TOKEN}}))
+	newAdminController(&Controller{logger: zap.NewNop().Sugar()}, adminService).RegisterRoutes(adminRouter)
+	return router
+}
+
+func TestAdminHandlersSuccess(t *testing.T) {
+	service := &stubAdminService{}
+	assertSuccess(t, newAdminTestRouter(service), adminEndpoints, "Authorization", "Bearer "+TEST_ADMIN_TOKEN)
+
+	if service.actor.Name != "ops" {
+		t.Fatalf("expected the token owner to be the actor, got %+v", service.actor)
+	}
+}
+
+func TestAdminHandlersErrorMapping(t *testing.T) {
+	assertErrorMappings(t, adminEndpoints, func(err error) http.Handler {
+		return newAdminTestRouter(&stubAdminService{err: err})
+	}, []errorMapping{
+		{services.ErrMatchNotFound, http.StatusNotFound, contracts.ErrorCodeMatchNotFound},
+		{services.ErrPlayerNotInRoom, http.StatusBadRequest, contracts.ErrorCodePlayerNotInRoom},
+		{services.ErrKickLastPlayer, http.StatusConflict, contracts.ErrorCodeKickLastPlayer},
+		{domain.ErrEmptyResult, http.StatusNotFound, contracts.ErrorCodeResourceNotFound},
+	}, "Authorization", "Bearer "+TEST_ADMIN_TOKEN)
+}
+
+func TestAdminHandlersUnauthorized(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"missing header", ""},
+		{"not a bearer token", "Basic " + TEST_ADMIN_TOKEN},
+		{"empty token", "Bearer "},
+		{"unknown token", "Bearer nope"},
+	}
+
+	router := newAdminTestRouter(&stubAdminService{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, problem := serveAPI(t, router, "GET", "/admin/stats", ``, "Authorization", tt.header)
+			if rec.Code != http.StatusUnauthorized || problem.Code != contracts.ErrorCodeUnauthorized {
+				t.Fatalf("expected 401 %v, got %v %v", contracts.ErrorCodeUnauthorized, rec.Code, problem.Code)
+			}
+			if rec.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
+
+func TestAdminHandlersBadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"unknown status", "GET", "/admin/rooms?status=Lost", ``},
+		{"limit too high", "GET", "/admin/rooms?limit=501", ``},
+		{"limit not a number", "GET", "/admin/audit?limit=ten", ``},
+		{"short room id", "GET", "/admin/rooms/abc", ``},
+		{"missing player", "PUT", "/admin/rooms/kick/abc1234", `{}`},
+		{"ttl too long", "PUT", "/admin/rooms/ttl/abc1234", `{"seconds":86401}`},
+	}
+
+	router := newAdminTestRouter(&stubAdminService{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, _ := serveAPI(t, router, tt.method, tt.path, tt.body, "Authorization", "Bearer "+TEST_ADMIN_TOKEN)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %v", rec.Code)
+			}
+		})
+	}
+}
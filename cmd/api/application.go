@@ -2,40 +2,64 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/config"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/i18n"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/metrics"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/ratelimit"
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/store"
-	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/tracing"
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
 var Validate *validator.Validate
 
 func init() {
 	Validate = validator.New(validator.WithRequiredStructEnabled())
-}
-
-type ApplicationConfig struct {
-	Addr            string
-	GracefulTimeout time.Duration
+	// report fields by their JSON name so problem responses match the request body
+	Validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	if err := i18n.RegisterValidatorTranslations(Validate); err != nil {
+		log.Fatal(err)
+	}
 }
 
 type Application struct {
-	config ApplicationConfig
-	logger *zap.SugaredLogger
+	config       *config.Config
+	logger       *zap.SugaredLogger
+	shuttingDown atomic.Bool
 }
 
 func NewApplication(
-	config ApplicationConfig,
+	config *config.Config,
 ) *Application {
 
 	logger := zap.Must(zap.NewProduction()).Sugar()
@@ -46,79 +70,288 @@ func NewApplication(
 	}
 }
 
+// NewEmbeddedApplication takes the logger from the caller, for clients that run the
+// API in process and own the terminal.
+func NewEmbeddedApplication(
+	config *config.Config,
+	logger *zap.SugaredLogger,
+) *Application {
+	return &Application{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Handler builds the HTTP API without listening or serving gRPC, so it can be
+// embedded. Open match streams end once done is closed.
+func (app *Application) Handler(done <-chan struct{}) http.Handler {
+	router, _ := app.createRouter(&Controller{
+		logger: app.logger,
+		done:   done,
+	})
+	return router
+}
+
 func (app *Application) Run() {
 
-	router := app.createRouter()
+	shutdownTracing, err := tracing.Setup(context.Background(), app.config.Tracing)
+	if err != nil {
+		app.logger.Error("error setting up tracing")
+		log.Fatal(err)
+	}
+
+	// closed on shutdown so open match streams end instead of holding the servers
+	done := make(chan struct{})
+	controller := &Controller{
+		logger: app.logger,
+		done:   done,
+	}
+
+	router, matchesService := app.createRouter(controller)
 
 	srv := &http.Server{
-		Addr:         app.config.Addr,
-		WriteTimeout: time.Second * 15,
-		ReadTimeout:  time.Second * 15,
-		IdleTimeout:  time.Second * 60,
-		Handler:      router,
+		Addr:              app.config.HTTP.Addr,
+		WriteTimeout:      app.config.HTTP.WriteTimeout,
+		ReadTimeout:       app.config.HTTP.ReadTimeout,
+		ReadHeaderTimeout: app.config.HTTP.ReadHeaderTimeout,
+		IdleTimeout:       app.config.HTTP.IdleTimeout,
+		Handler:           router,
 	}
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal(err)
 		}
 	}()
 
-	app.logger.Info("Listening on", app.config.Addr)
+	app.logger.Info("Listening on", app.config.HTTP.Addr)
+
+	var grpcServer *grpc.Server
+	var grpcHealth *health.Server
+
+	if app.config.GRPC.Enabled {
+		listener, err := net.Listen("tcp", app.config.GRPC.Addr)
+		if err != nil {
+			app.logger.Error("error listening for grpc")
+			log.Fatal(err)
+		}
+
+		grpcServer, grpcHealth = app.newGRPCServer(controller, matchesService)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatal(err)
+			}
+		}()
+
+		app.logger.Info("gRPC listening on ", app.config.GRPC.Addr)
+	}
+
 	ch := make(chan os.Signal, 1)
 
-	signal.Notify(ch, os.Interrupt)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
 
 	<-ch
 
-	ctx, cancel := context.WithTimeout(context.Background(), app.config.GracefulTimeout)
+	// fail readiness first so the load balancer stops routing traffic here
+	app.shuttingDown.Store(true)
+	if grpcHealth != nil {
+		grpcHealth.Shutdown()
+	}
+	app.logger.Info("draining connections for ", app.config.HTTP.DrainDelay)
+	time.Sleep(app.config.HTTP.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.config.HTTP.GracefulTimeout)
 	defer cancel()
 
+	close(done)
+
+	var wg sync.WaitGroup
+	if grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopGRPCServer(ctx, grpcServer)
+		}()
+	}
 	srv.Shutdown(ctx)
+	wg.Wait()
+
+	shutdownTracing(ctx)
 	os.Exit(0)
 }
 
-func (app *Application) createRouter() http.Handler {
+func (app *Application) createRouter(controller *Controller) (http.Handler, contracts.IMatchesService) {
 	router := mux.NewRouter()
+	router.NotFoundHandler = http.HandlerFunc(notFoundHandler)
+	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
+	router.Use(routeLogFieldsMiddleware)
+	router.Use(otelmux.Middleware(app.config.Tracing.ServiceName))
+	router.Use(metrics.RouteMiddleware)
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
 
 	subrouter := router.PathPrefix("/api/v1").Subrouter()
 
-	controller := &Controller{
-		logger: app.logger,
-	}
-
 	// storage registration
 	matchesRdb := app.createMatchesRdb()
-	storage := store.NewRedisStorage(matchesRdb)
+	clock := domain.SystemClock{}
+	storage := store.NewRedisStorage(matchesRdb, store.Config{
+		RoomTTL: store.RoomTTLPolicy{
+			Waiting:   app.config.Rooms.WaitingTTL,
+			Playing:   app.config.Rooms.PlayingTTL,
+			Finished:  app.config.Rooms.FinishedTTL,
+			Tombstone: app.config.Rooms.TombstoneTTL,
+		},
+		Clock: clock,
+	})
+
+	healthController := newHealthController(controller, &app.shuttingDown, app.config.HTTP.ReadinessTimeout, HealthCheck{
+		Name: "redis",
+		Check: func(ctx context.Context) error {
+			return matchesRdb.Ping(ctx).Err()
+		},
+	})
+	healthController.RegisterRoutes(router)
+
+	if app.config.RateLimit.Enabled {
+		subrouter.Use(app.rateLimitMiddleware(ratelimit.NewRedisLimiter(matchesRdb)))
+	}
+
+	metrics.RegisterActiveRooms(storage.MatchesRepository)
+	go app.sweepRooms(storage.MatchesRepository, controller.done)
 
 	// services registration
-	matchesService := services.NewMatchesService(storage)
+	gameConfig := services.GameConfig{
+		DefaultMode:       app.config.Game.DefaultMode,
+		DefaultBestOf:     app.config.Game.DefaultBestOf,
+		RematchTimeout:    app.config.Game.RematchTimeout,
+		RaceFinishTimeout: app.config.Game.RaceFinishTimeout,
+		OnFinish:          metrics.ObserveFinishedMatch,
+	}
+	random := domain.CryptoRandom{}
+	matchesService := tracing.NewMatchesService(metrics.NewMatchesService(services.NewMatchesService(storage, gameConfig, clock, random)))
+	tournamentsService := services.NewTournamentsService(storage, gameConfig, random)
+	dailyService := services.NewDailyService(storage, app.getDailySeed(), clock, random)
 
 	// controllers registration
-	matchesController := newMatchesController(controller, matchesService)
-	matchesController.RegisterRoutes(subrouter)
+	registerAPIRoutes(subrouter, controller, matchesService, tournamentsService, dailyService, app.config.Docs.SwaggerUI)
+
+	if len(app.config.Admin.Tokens) > 0 {
+		adminRouter := router.PathPrefix("/admin").Subrouter()
+		adminRouter.Use(adminAuthMiddleware(app.config.Admin.Tokens))
+
+		adminController := newAdminController(controller, services.NewAdminService(storage, gameConfig, clock))
+		adminController.RegisterRoutes(adminRouter)
+	} else {
+		app.logger.Info("no admin tokens configured, the admin API is disabled")
+	}
 
 	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{utils.GetEnvironment().GetEnv("ALLOWED_HOST", "")},
+		AllowedOrigins:   app.config.CORS.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "X-CSRF-Token", REQUEST_ID_HEADER},
+		ExposedHeaders:   []string{"Link", REQUEST_ID_HEADER, "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	})
 
-	return c.Handler(router)
+	return c.Handler(app.requestMiddleware(metrics.Middleware(router))), matchesService
+}
+
+// registerAPIRoutes mounts the public controllers under /api/v1. The OpenAPI test
+// walks the same routes, so anything registered here must be documented.
+func registerAPIRoutes(
+	router *mux.Router,
+	controller *Controller,
+	matchesService contracts.IMatchesService,
+	tournamentsService contracts.ITournamentsService,
+	dailyService contracts.IDailyService,
+	swaggerUI bool,
+) {
+	matchesController := newMatchesController(controller, matchesService)
+	matchesController.RegisterRoutes(router)
+
+	tournamentsController := newTournamentsController(controller, tournamentsService)
+	tournamentsController.RegisterRoutes(router)
+
+	dailyController := newDailyController(controller, dailyService)
+	dailyController.RegisterRoutes(router)
+
+	docsController := newDocsController(controller, swaggerUI)
+	docsController.RegisterRoutes(router)
 }
 
-func (app *Application) createMatchesRdb() *redis.Client {
-	matchesRdb, err := store.NewRedisClient(
-		utils.GetEnvironment().GetEnv("DB_MATCHES", ""),
-		utils.GetEnvironment().GetEnv("DB_MATCHES_PWD", ""),
-		int(utils.GetEnvironment().GetEnvAsInt("DB_MATCHES_DB", 0)),
-	)
+func (app *Application) createMatchesRdb() redis.UniversalClient {
+	matchesRdb, err := store.NewRedisClient(store.RedisOptions{
+		Mode:             app.config.Redis.Mode,
+		Addrs:            app.config.Redis.Addrs,
+		MasterName:       app.config.Redis.MasterName,
+		Username:         app.config.Redis.Username,
+		Password:         app.config.Redis.Password,
+		SentinelUsername: app.config.Redis.SentinelUsername,
+		SentinelPassword: app.config.Redis.SentinelPassword,
+		DB:               app.config.Redis.DB,
+		TLS: store.RedisTLSOptions{
+			Enabled:            app.config.Redis.TLS.Enabled,
+			ServerName:         app.config.Redis.TLS.ServerName,
+			CAFile:             app.config.Redis.TLS.CAFile,
+			CertFile:           app.config.Redis.TLS.CertFile,
+			KeyFile:            app.config.Redis.TLS.KeyFile,
+			InsecureSkipVerify: app.config.Redis.TLS.InsecureSkipVerify,
+		},
+		PoolSize:     app.config.Redis.PoolSize,
+		MinIdleConns: app.config.Redis.MinIdleConns,
+		DialTimeout:  app.config.Redis.DialTimeout,
+		ReadTimeout:  app.config.Redis.ReadTimeout,
+		WriteTimeout: app.config.Redis.WriteTimeout,
+	})
 	if err != nil {
 		app.logger.Error("error connecting to matchesdb")
 		log.Fatal(err)
 	}
+	matchesRdb.AddHook(metrics.RedisHook{})
+	// statements are left out of spans because they carry the secret combinations
+	if err := redisotel.InstrumentTracing(matchesRdb, redisotel.WithDBStatement(false)); err != nil {
+		app.logger.Error("error instrumenting matchesdb")
+		log.Fatal(err)
+	}
 	return matchesRdb
 }
+
+// sweepRooms runs until done is closed.
+func (app *Application) sweepRooms(repository contracts.IMatchesRepository, done <-chan struct{}) {
+	ticker := time.NewTicker(app.config.Rooms.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		expired, err := repository.SweepExpiredRooms(context.Background())
+		if err != nil {
+			app.logger.Errorw("error sweeping expired rooms", "error", err.Error())
+		}
+
+		for _, room := range expired {
+			app.logger.Infow("room expired", "room_id", room.RoomId, "last_status", room.LastStatus)
+			if room.LastStatus != domain.MatchStateFinished {
+				metrics.MatchesAbandoned.WithLabelValues(string(room.LastStatus)).Inc()
+			}
+		}
+	}
+}
+
+func (app *Application) getDailySeed() string {
+	if app.config.Daily.Seed != "" {
+		return app.config.Daily.Seed
+	}
+
+	app.logger.Warn("DAILY_SEED is not set, using a random seed for this instance")
+	seed, err := domain.GenerateMatchId(domain.CryptoRandom{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return seed
+}
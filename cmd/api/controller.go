@@ -3,37 +3,38 @@ package api
 import (
 	"net/http"
 
-	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
 	"go.uber.org/zap"
 )
 
 type Controller struct {
 	logger *zap.SugaredLogger
+	// done is closed on shutdown so long lived streams end before the deadline
+	done <-chan struct{}
 }
 
-func (app *Controller) WriteJSONError(w http.ResponseWriter, status int, message string) error {
-	type envelope struct {
-		Error string `json:"error"`
-	}
-	return utils.WriteJSON(w, status, &envelope{Error: message})
+func (app *Controller) Logger(r *http.Request) *zap.SugaredLogger {
+	return loggerFromContext(r.Context(), app.logger)
 }
 
-func (app *Controller) InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorw("internal server error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusInternalServerError, "The server encountered a problem")
+// ErrorResponse answers with the problem mapped to err in problemTypes.
+func (app *Controller) ErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	app.writeProblem(w, r, resolveProblem(r, err), err)
 }
 
-func (app *Controller) BadRequestError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("bad request error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusBadRequest, err.Error())
+func (app *Controller) InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
+	app.writeProblem(w, r, internalProblem(r), err)
 }
 
-func (app *Controller) NotFoundError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("not found error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusNotFound, "Resource Not Found")
+func (app *Controller) BadRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	app.writeProblem(w, r, badRequestProblem(r, err), err)
 }
 
-func (app *Controller) ConflictError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorw("conflict error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusConflict, err.Error())
+func (app *Controller) writeProblem(w http.ResponseWriter, r *http.Request, problem *contracts.ProblemResponse, err error) {
+	if problem.Status >= http.StatusInternalServerError {
+		app.Logger(r).Errorw("internal server error", "code", problem.Code, "error", err.Error())
+	} else {
+		app.Logger(r).Warnw("request error", "status", problem.Status, "code", problem.Code, "error", err.Error())
+	}
+	WriteProblem(w, r, problem)
 }
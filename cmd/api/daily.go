@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/gorilla/mux"
+)
+
+type DailyController struct {
+	*Controller
+	dailyService contracts.IDailyService
+}
+
+func newDailyController(controller *Controller, dailyService contracts.IDailyService) *DailyController {
+	return &DailyController{
+		Controller:   controller,
+		dailyService: dailyService,
+	}
+}
+
+func (dc *DailyController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/daily/start", dc.startAttemptHandler).Methods("POST")
+	router.HandleFunc("/daily/guess", dc.makeGuessHandler).Methods("PUT")
+	router.HandleFunc("/daily/attempt/{username}", dc.getAttemptHandler).Methods("GET")
+	router.HandleFunc("/daily/stats/{username}", dc.getStatsHandler).Methods("GET")
+}
+
+func (dc *DailyController) startAttemptHandler(w http.ResponseWriter, r *http.Request) {
+	payload := &contracts.StartDailyCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		dc.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		dc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := dc.dailyService.StartAttempt(r.Context(), *payload)
+
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		dc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (dc *DailyController) makeGuessHandler(w http.ResponseWriter, r *http.Request) {
+	payload := &contracts.DailyGuessCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		dc.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		dc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := dc.dailyService.MakeGuess(r.Context(), *payload)
+
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		dc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (dc *DailyController) getAttemptHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	query := contracts.DailyAttemptQuery{
+		Username: vars["username"],
+		Day:      r.URL.Query().Get("day"),
+	}
+
+	if err := Validate.Struct(query); err != nil {
+		dc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := dc.dailyService.GetAttempt(r.Context(), query)
+
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		dc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (dc *DailyController) getStatsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	res, err := dc.dailyService.GetStats(r.Context(), username)
+
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		dc.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type stubDailyService struct {
+	err error
+}
+
+func (s *stubDailyService) StartAttempt(ctx context.Context, command contracts.StartDailyCommand) (*contracts.DailyAttemptResponse, error) {
+	return stubResponse[contracts.DailyAttemptResponse](s.err)
+}
+
+func (s *stubDailyService) MakeGuess(ctx context.Context, command contracts.DailyGuessCommand) (*contracts.DailyAttemptResponse, error) {
+	return stubResponse[contracts.DailyAttemptResponse](s.err)
+}
+
+func (s *stubDailyService) GetAttempt(ctx context.Context, query contracts.DailyAttemptQuery) (*contracts.DailyAttemptResponse, error) {
+	return stubResponse[contracts.DailyAttemptResponse](s.err)
+}
+
+func (s *stubDailyService) GetStats(ctx context.Context, username string) (*contracts.DailyStatsResponse, error) {
+	return stubResponse[contracts.DailyStatsResponse](s.err)
+}
+
+var dailyEndpoints = []handlerEndpoint{
+	{"start", "POST", "/daily/start", `{"username":"alice"}`, http.StatusOK, true},
+	{"guess", "PUT", "/daily/guess", `{"username":"alice","token":"t0k3n","guess":1234}`, http.StatusOK, true},
+	{"attempt", "GET", "/daily/attempt/alice", ``, http.StatusOK, true},
+	{"past attempt", "GET", "/daily/attempt/alice?day=2024-03-09", ``, http.StatusOK, true},
+	{"stats", "GET", "/daily/stats/alice", ``, http.StatusOK, true},
+}
+
+func newDailyTestRouter(dailyService contracts.IDailyService) http.Handler {
+	router := mux.NewRouter()
+	newDailyController(&Controller{logger: zap.NewNop().Sugar()}, dailyService).RegisterRoutes(router)
+	return router
+}
+
+func TestDailyHandlersSuccess(t *testing.T) {
+	assertSuccess(t, newDailyTestRouter(&stubDailyService{}), dailyEndpoints)
+}
+
+func TestDailyHandlersErrorMapping(t *testing.T) {
+	assertErrorMappings(t, dailyEndpoints, func(err error) http.Handler {
+		return newDailyTestRouter(&stubDailyService{err: err})
+	}, []errorMapping{
+		{services.ErrDailyAlreadyPlayed, http.StatusConflict, contracts.ErrorCodeDailyAlreadyPlayed},
+		{services.ErrDailyNotStarted, http.StatusNotFound, contracts.ErrorCodeDailyNotStarted},
+		{services.ErrDailyAttemptIsOver, http.StatusConflict, contracts.ErrorCodeDailyAttemptOver},
+		{services.ErrDailyPlayerNotFound, http.StatusNotFound, contracts.ErrorCodeDailyPlayerNotFound},
+		{services.ErrDailyInvalidToken, http.StatusForbidden, contracts.ErrorCodeDailyInvalidToken},
+		{domain.ErrInvalidUniqueCombination, http.StatusBadRequest, contracts.ErrorCodeRepeatedDigits},
+	})
+}
+
+func TestDailyHandlersBadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"missing username", "POST", "/daily/start", `{}`},
+		{"long username", "POST", "/daily/start", `{"username":"abcdefghijklmnopqrstuvwxyz0123456"}`},
+		{"braces in username", "POST", "/daily/start", `{"username":"a{b}"}`},
+		{"guess without token", "PUT", "/daily/guess", `{"username":"alice","guess":1234}`},
+		{"malformed day", "GET", "/daily/attempt/alice?day=09-03-2024", ``},
+	}
+
+	router := newDailyTestRouter(&stubDailyService{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, problem := serveAPI(t, router, tt.method, tt.path, tt.body)
+			if rec.Code != http.StatusBadRequest || problem.Code != contracts.ErrorCodeValidationFailed {
+				t.Fatalf("expected 400 %v, got %v %v", contracts.ErrorCodeValidationFailed, rec.Code, problem.Code)
+			}
+		})
+	}
+}
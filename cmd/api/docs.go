@@ -0,0 +1,74 @@
+package api
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+const SWAGGER_UI_VERSION = "5.17.14"
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Bulls and Cows API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: {{.SpecURL}}, dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`))
+
+type DocsController struct {
+	*Controller
+	swaggerUI bool
+}
+
+func newDocsController(controller *Controller, swaggerUI bool) *DocsController {
+	return &DocsController{
+		Controller: controller,
+		swaggerUI:  swaggerUI,
+	}
+}
+
+func (dc *DocsController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/openapi.json", dc.openAPIHandler).Methods("GET")
+	if dc.swaggerUI {
+		router.HandleFunc("/docs", dc.swaggerUIHandler).Methods("GET")
+	}
+}
+
+func (dc *DocsController) openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(openAPISpec); err != nil {
+		dc.Logger(r).Warnw("error writing openapi spec", "error", err.Error())
+	}
+}
+
+func (dc *DocsController) swaggerUIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct {
+		Version string
+		SpecURL string
+	}{
+		Version: SWAGGER_UI_VERSION,
+		SpecURL: "openapi.json",
+	}
+	if err := swaggerUITemplate.Execute(w, data); err != nil {
+		dc.InternalServerError(w, r, err)
+	}
+}
@@ -1,34 +1,155 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
-	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	"github.com/go-playground/validator/v10"
 )
 
-func WriteJSONError(w http.ResponseWriter, status int, message string) error {
-	type envelope struct {
-		Error string `json:"error"`
+const PROBLEM_TYPE_PREFIX = "urn:bullandcows:problem:"
+
+type problemType struct {
+	err    error
+	status int
+	code   contracts.ErrorCode
+}
+
+// problemTypes is the single place where errors are mapped to responses. It is
+// walked in order with errors.Is, so the most specific errors must come first.
+var problemTypes = []problemType{
+	{domain.ErrInvalidUniqueCombination, http.StatusBadRequest, contracts.ErrorCodeRepeatedDigits},
+	{domain.ErrInvalidCombination, http.StatusBadRequest, contracts.ErrorCodeInvalidCombination},
+	{services.ErrInvalidCombination, http.StatusBadRequest, contracts.ErrorCodeInvalidCombination},
+
+	{services.ErrMatchExpired, http.StatusGone, contracts.ErrorCodeMatchExpired},
+	{services.ErrMatchNotFound, http.StatusNotFound, contracts.ErrorCodeMatchNotFound},
+	{services.ErrCanNotAddAnotherPlayer, http.StatusConflict, contracts.ErrorCodeRoomFull},
+	{services.ErrMatchNotFullRoom, http.StatusConflict, contracts.ErrorCodeRoomNotReady},
+	{services.ErrExpectingCombinations, http.StatusConflict, contracts.ErrorCodeCombinationsPending},
+	{services.ErrMatchNotStarted, http.StatusConflict, contracts.ErrorCodeMatchNotStarted},
+	{services.ErrMatchIsFinished, http.StatusConflict, contracts.ErrorCodeMatchFinished},
+	{services.ErrNotYourTurn, http.StatusConflict, contracts.ErrorCodeNotYourTurn},
+	{services.ErrAlreadySolved, http.StatusConflict, contracts.ErrorCodeAlreadySolved},
+	{services.ErrSeriesIsOver, http.StatusConflict, contracts.ErrorCodeSeriesOver},
+	{services.ErrMatchNotFinished, http.StatusConflict, contracts.ErrorCodeMatchNotFinished},
+	{services.ErrRematchAlreadyOffered, http.StatusConflict, contracts.ErrorCodeRematchAlreadyOffered},
+	{services.ErrNoRematchOffer, http.StatusConflict, contracts.ErrorCodeNoRematchOffer},
+	{services.ErrOwnRematchOffer, http.StatusConflict, contracts.ErrorCodeOwnRematchOffer},
+
+	{services.ErrTournamentNotFound, http.StatusNotFound, contracts.ErrorCodeTournamentNotFound},
+	{services.ErrRegistrationClosed, http.StatusConflict, contracts.ErrorCodeRegistrationClosed},
+	{services.ErrNotEnoughPlayers, http.StatusConflict, contracts.ErrorCodeNotEnoughPlayers},
+	{services.ErrTournamentAlreadyStarted, http.StatusConflict, contracts.ErrorCodeTournamentAlreadyStarted},
+
+	{services.ErrDailyAlreadyPlayed, http.StatusConflict, contracts.ErrorCodeDailyAlreadyPlayed},
+	{services.ErrDailyNotStarted, http.StatusNotFound, contracts.ErrorCodeDailyNotStarted},
+	{services.ErrDailyAttemptIsOver, http.StatusConflict, contracts.ErrorCodeDailyAttemptOver},
+	{services.ErrDailyPlayerNotFound, http.StatusNotFound, contracts.ErrorCodeDailyPlayerNotFound},
+	{services.ErrDailyInvalidToken, http.StatusForbidden, contracts.ErrorCodeDailyInvalidToken},
+
+	{services.ErrPlayerNotInRoom, http.StatusBadRequest, contracts.ErrorCodePlayerNotInRoom},
+	{services.ErrKickLastPlayer, http.StatusConflict, contracts.ErrorCodeKickLastPlayer},
+
+	{domain.ErrEmptyResult, http.StatusNotFound, contracts.ErrorCodeResourceNotFound},
+	{domain.ErrAlreadyExists, http.StatusConflict, contracts.ErrorCodeResourceAlreadyExists},
+}
+
+// newProblem localizes the title of code. The detail is expected to be localized by
+// the caller already.
+func newProblem(r *http.Request, status int, code contracts.ErrorCode, detail string) *contracts.ProblemResponse {
+	return &contracts.ProblemResponse{
+		Type:      PROBLEM_TYPE_PREFIX + string(code),
+		Title:     localizerFromContext(r.Context()).Title(code),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestId: requestIdFromContext(r.Context()),
+	}
+}
+
+// resolveProblem turns any error into a problem. Errors missing from problemTypes
+// are internal and their message is never exposed.
+func resolveProblem(r *http.Request, err error) *contracts.ProblemResponse {
+	if problem, ok := findProblemType(err); ok {
+		return newProblem(r, problem.status, problem.code, localizerFromContext(r.Context()).Detail(problem.code))
 	}
-	return utils.WriteJSON(w, status, &envelope{Error: message})
+	return internalProblem(r)
 }
 
-func (app *Application) InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorw("internal server error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusInternalServerError, "The server encountered a problem")
+func findProblemType(err error) (problemType, bool) {
+	for _, problem := range problemTypes {
+		if errors.Is(err, problem.err) {
+			return problem, true
+		}
+	}
+	return problemType{}, false
+}
+
+func internalProblem(r *http.Request) *contracts.ProblemResponse {
+	return newProblem(r, http.StatusInternalServerError, contracts.ErrorCodeInternal, localizerFromContext(r.Context()).Detail(contracts.ErrorCodeInternal))
+}
+
+// badRequestProblem describes malformed bodies and failed validations, listing the
+// offending fields when the validator reports them.
+func badRequestProblem(r *http.Request, err error) *contracts.ProblemResponse {
+	localizer := localizerFromContext(r.Context())
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		problem := newProblem(r, http.StatusBadRequest, contracts.ErrorCodeValidationFailed, localizer.Detail(contracts.ErrorCodeValidationFailed))
+		for _, fieldError := range validationErrors {
+			problem.InvalidParams = append(problem.InvalidParams, contracts.InvalidParam{
+				Name:   fieldName(fieldError),
+				Reason: fieldError.Translate(localizer.Translator),
+			})
+		}
+		return problem
+	}
+
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return newProblem(r, http.StatusBadRequest, contracts.ErrorCodeInvalidRequest, localizer.Message("invalid_request.missing_body"))
+	case errors.As(err, &typeError):
+		return newProblem(r, http.StatusBadRequest, contracts.ErrorCodeInvalidRequest, localizer.Message("invalid_request.wrong_type", typeError.Field))
+	case errors.As(err, &syntaxError), errors.Is(err, io.ErrUnexpectedEOF):
+		return newProblem(r, http.StatusBadRequest, contracts.ErrorCodeInvalidRequest, localizer.Message("invalid_request.malformed_json"))
+	}
+
+	return newProblem(r, http.StatusBadRequest, contracts.ErrorCodeInvalidRequest, localizer.Detail(contracts.ErrorCodeInvalidRequest))
+}
+
+func fieldName(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if _, field, found := strings.Cut(namespace, "."); found {
+		return field
+	}
+	return namespace
 }
 
-func (app *Application) BadRequestError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("bad request error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusBadRequest, err.Error())
+func WriteProblem(w http.ResponseWriter, r *http.Request, problem *contracts.ProblemResponse) error {
+	w.Header().Set("Content-Type", contracts.PROBLEM_CONTENT_TYPE)
+	w.Header().Set("Content-Language", localizerFromContext(r.Context()).Tag.String())
+	w.Header().Add("Vary", "Accept-Language")
+	w.WriteHeader(problem.Status)
+	return json.NewEncoder(w).Encode(problem)
 }
 
-func (app *Application) NotFoundError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("not found error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusNotFound, "Resource Not Found")
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	localizer := localizerFromContext(r.Context())
+	WriteProblem(w, r, newProblem(r, http.StatusNotFound, contracts.ErrorCodeRouteNotFound, localizer.Detail(contracts.ErrorCodeRouteNotFound, r.Method, r.URL.Path)))
 }
 
-func (app *Application) ConflictError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorw("conflict error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	WriteJSONError(w, http.StatusConflict, err.Error())
+func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	localizer := localizerFromContext(r.Context())
+	WriteProblem(w, r, newProblem(r, http.StatusMethodNotAllowed, contracts.ErrorCodeMethodNotAllowed, localizer.Detail(contracts.ErrorCodeMethodNotAllowed, r.Method, r.URL.Path)))
 }
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"go.uber.org/zap"
+)
+
+func TestValidationProblemIsTranslated(t *testing.T) {
+	app := &Application{logger: zap.NewNop().Sugar()}
+	router := app.requestMiddleware(newDailyTestRouter(&stubDailyService{}))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		title          string
+		detail         string
+		params         []contracts.InvalidParam
+	}{
+		{"english", "en-US", "Validation failed", "One or more fields are invalid", []contracts.InvalidParam{
+			{Name: "username", Reason: "username is a required field"},
+			{Name: "token", Reason: "token is a required field"},
+		}},
+		{"spanish", "es-MX", "Validación fallida", "Uno o más campos no son válidos", []contracts.InvalidParam{
+			{Name: "username", Reason: "username es un campo requerido"},
+			{Name: "token", Reason: "token es un campo requerido"},
+		}},
+		{"unknown language", "fr-FR", "Validation failed", "One or more fields are invalid", []contracts.InvalidParam{
+			{Name: "username", Reason: "username is a required field"},
+			{Name: "token", Reason: "token is a required field"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, problem := serveAPI(t, router, "PUT", "/daily/guess", `{"guess":1234}`, "Accept-Language", tt.acceptLanguage)
+			if rec.Code != http.StatusBadRequest || problem.Code != contracts.ErrorCodeValidationFailed {
+				t.Fatalf("expected 400 %v, got %v %v", contracts.ErrorCodeValidationFailed, rec.Code, problem.Code)
+			}
+			if problem.Title != tt.title || problem.Detail != tt.detail {
+				t.Fatalf("expected %q / %q, got %q / %q", tt.title, tt.detail, problem.Title, problem.Detail)
+			}
+			if len(problem.InvalidParams) != len(tt.params) {
+				t.Fatalf("expected %v, got %v", tt.params, problem.InvalidParams)
+			}
+			for i, param := range tt.params {
+				if problem.InvalidParams[i] != param {
+					t.Fatalf("expected %v, got %v", tt.params, problem.InvalidParams)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidRequestIsTranslated(t *testing.T) {
+	app := &Application{logger: zap.NewNop().Sugar()}
+	router := app.requestMiddleware(newDailyTestRouter(&stubDailyService{}))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		body           string
+		detail         string
+	}{
+		{"malformed json", "en", `{"username":`, "The request body is not valid JSON"},
+		{"malformed json in spanish", "es", `{"username":`, "El cuerpo de la solicitud no es un JSON válido"},
+		{"wrong type in spanish", "es-MX", `{"username":"alice","token":"t","guess":"1234"}`, "El campo guess tiene un tipo incorrecto"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, problem := serveAPI(t, router, "PUT", "/daily/guess", tt.body, "Accept-Language", tt.acceptLanguage)
+			if rec.Code != http.StatusBadRequest || problem.Code != contracts.ErrorCodeInvalidRequest || problem.Detail != tt.detail {
+				t.Fatalf("expected 400 %v %q, got %v %v %q", contracts.ErrorCodeInvalidRequest, tt.detail, rec.Code, problem.Code, problem.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/i18n"
+	bullandcowsv1 "github.com/alejandro-cardenas-g/bullAndCowsApp/proto/bullandcows/v1"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const GRPC_ERROR_DOMAIN = "bullandcows"
+
+// newGRPCServer registers the game services next to the standard health and
+// reflection services. Streams end once the controller's done channel is closed so
+// GracefulStop does not wait for watchers that could stay connected for hours.
+func (app *Application) newGRPCServer(controller *Controller, matchesService contracts.IMatchesService) (*grpc.Server, *health.Server) {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(app.grpcUnaryInterceptor),
+		grpc.ChainStreamInterceptor(app.grpcStreamInterceptor),
+	)
+
+	bullandcowsv1.RegisterMatchesServiceServer(server, newMatchesGRPCServer(controller, matchesService))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server, healthServer
+}
+
+// stopGRPCServer waits for in-flight calls until ctx is done and then closes the
+// remaining connections.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// grpcContext is the gRPC counterpart of requestMiddleware: request id, request
+// scoped logger and negotiated language.
+func (app *Application) grpcContext(ctx context.Context, method string) (context.Context, *requestLogger) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestId := firstMetadataValue(md, REQUEST_ID_HEADER)
+	if requestId == "" || len(requestId) > 128 {
+		requestId = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(REQUEST_ID_HEADER, requestId))
+
+	logger := &requestLogger{
+		logger: app.logger.With("request_id", requestId, "grpc_method", method),
+	}
+
+	ctx = context.WithValue(ctx, requestIdKey, requestId)
+	ctx = context.WithValue(ctx, requestLoggerKey, logger)
+	ctx = context.WithValue(ctx, localizerKey, i18n.Negotiate(firstMetadataValue(md, "Accept-Language")))
+	return ctx, logger
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (app *Application) grpcUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	start := time.Now()
+	ctx, logger := app.grpcContext(ctx, info.FullMethod)
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.get().Errorw("panic recovered", "panic", fmt.Sprint(rec), "stack", string(debug.Stack()))
+			err = grpcInternalError(ctx)
+		}
+		logGRPCAccess(logger, start, err)
+	}()
+
+	return handler(ctx, req)
+}
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (app *Application) grpcStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx, logger := app.grpcContext(stream.Context(), info.FullMethod)
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.get().Errorw("panic recovered", "panic", fmt.Sprint(rec), "stack", string(debug.Stack()))
+			err = grpcInternalError(ctx)
+		}
+		logGRPCAccess(logger, start, err)
+	}()
+
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+}
+
+func logGRPCAccess(logger *requestLogger, start time.Time, err error) {
+	logger.get().Infow("access",
+		"grpc_code", status.Code(err).String(),
+		"latency_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// grpcError maps err through problemTypes, so gRPC clients get the same codes as
+// REST clients in the ErrorInfo reason.
+func (app *Controller) grpcError(ctx context.Context, err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return grpcValidationError(ctx, validationErrors)
+	}
+
+	problem, ok := findProblemType(err)
+	if !ok {
+		loggerFromContext(ctx, app.logger).Errorw("internal server error", "error", err.Error())
+		return grpcInternalError(ctx)
+	}
+
+	loggerFromContext(ctx, app.logger).Warnw("request error", "code", problem.code, "error", err.Error())
+	return newGRPCStatus(grpcCode(problem.status), problem.code, localizerFromContext(ctx).Detail(problem.code))
+}
+
+func grpcInternalError(ctx context.Context) error {
+	return newGRPCStatus(codes.Internal, contracts.ErrorCodeInternal, localizerFromContext(ctx).Detail(contracts.ErrorCodeInternal))
+}
+
+func grpcValidationError(ctx context.Context, validationErrors validator.ValidationErrors) error {
+	localizer := localizerFromContext(ctx)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, fieldError := range validationErrors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldName(fieldError),
+			Description: fieldError.Translate(localizer.Translator),
+		})
+	}
+
+	return newGRPCStatus(codes.InvalidArgument, contracts.ErrorCodeValidationFailed, localizer.Detail(contracts.ErrorCodeValidationFailed), badRequest)
+}
+
+func newGRPCStatus(code codes.Code, errorCode contracts.ErrorCode, message string, details ...*errdetails.BadRequest) error {
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: string(errorCode), Domain: GRPC_ERROR_DOMAIN})
+	if err != nil {
+		return st.Err()
+	}
+	for _, detail := range details {
+		if next, err := withDetails.WithDetails(detail); err == nil {
+			withDetails = next
+		}
+	}
+	return withDetails.Err()
+}
+
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	}
+	return codes.Internal
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	bullandcowsv1 "github.com/alejandro-cardenas-g/bullAndCowsApp/proto/bullandcows/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type matchesGRPCServer struct {
+	bullandcowsv1.UnimplementedMatchesServiceServer
+	*Controller
+	matchesService contracts.IMatchesService
+}
+
+func newMatchesGRPCServer(controller *Controller, matchesService contracts.IMatchesService) *matchesGRPCServer {
+	return &matchesGRPCServer{
+		Controller:     controller,
+		matchesService: matchesService,
+	}
+}
+
+func (s *matchesGRPCServer) CreateRoom(ctx context.Context, req *bullandcowsv1.CreateRoomRequest) (*bullandcowsv1.CreateRoomResponse, error) {
+	command := contracts.CreateRoomCommand{
+		Username: req.GetUsername(),
+		Mode:     fromProtoMatchMode(req.GetMode()),
+		BestOf:   int(req.GetBestOf()),
+	}
+	if err := Validate.Struct(command); err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	res, err := s.matchesService.CreateRoom(ctx, command)
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	addContextLogFields(ctx, "room_id", res.RoomId, "player_handle", res.Player.Handle)
+
+	return &bullandcowsv1.CreateRoomResponse{
+		RoomId: res.RoomId,
+		Mode:   toProtoMatchMode(res.Mode),
+		BestOf: int32(res.BestOf),
+		Player: toProtoPlayerCredentials(res.Player),
+	}, nil
+}
+
+func (s *matchesGRPCServer) JoinRoom(ctx context.Context, req *bullandcowsv1.JoinRoomRequest) (*bullandcowsv1.JoinRoomResponse, error) {
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return nil, err
+	}
+
+	command := contracts.JoinRoomCommand{
+		Username: req.GetUsername(),
+		RoomId:   req.GetRoomId(),
+	}
+	if err := Validate.Struct(command); err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	res, err := s.matchesService.JoinRoom(ctx, command)
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	addContextLogFields(ctx, "player_handle", res.Player.Handle)
+
+	return &bullandcowsv1.JoinRoomResponse{
+		RoomId: res.RoomId,
+		Player: toProtoPlayerCredentials(res.Player),
+	}, nil
+}
+
+func (s *matchesGRPCServer) SetCombination(ctx context.Context, req *bullandcowsv1.SetCombinationRequest) (*bullandcowsv1.SetCombinationResponse, error) {
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return nil, err
+	}
+
+	command := contracts.SetCombinationCommand{
+		PlayerId:    req.GetPlayerId(),
+		Combination: int(req.GetCombination()),
+		RoomId:      req.GetRoomId(),
+	}
+	if err := Validate.Struct(command); err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	addContextLogFields(ctx, "player_handle", domain.PlayerHandle(command.PlayerId))
+
+	res, err := s.matchesService.SetCombination(ctx, command)
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	return &bullandcowsv1.SetCombinationResponse{Success: res.Success}, nil
+}
+
+func (s *matchesGRPCServer) StartGame(ctx context.Context, req *bullandcowsv1.StartGameRequest) (*bullandcowsv1.StartGameResponse, error) {
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return nil, err
+	}
+
+	res, err := s.matchesService.StartGame(ctx, req.GetRoomId())
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	return &bullandcowsv1.StartGameResponse{
+		Mode:     toProtoMatchMode(res.Mode),
+		IsTurnOf: res.IsTurnOf,
+	}, nil
+}
+
+func (s *matchesGRPCServer) MakeGuess(ctx context.Context, req *bullandcowsv1.MakeGuessRequest) (*bullandcowsv1.MakeGuessResponse, error) {
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return nil, err
+	}
+
+	command := contracts.MakeGuessCommand{
+		Guess:    int(req.GetGuess()),
+		PlayerId: req.GetPlayerId(),
+		RoomId:   req.GetRoomId(),
+	}
+
+	addContextLogFields(ctx, "player_handle", domain.PlayerHandle(command.PlayerId))
+
+	res, err := s.matchesService.MakeGuess(ctx, command)
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	return &bullandcowsv1.MakeGuessResponse{
+		IsWinner: res.IsWinner,
+		Winner:   res.Winner,
+		Guesses:  toProtoGuesses(res.Guesses),
+	}, nil
+}
+
+func (s *matchesGRPCServer) OfferRematch(ctx context.Context, req *bullandcowsv1.RematchRequest) (*bullandcowsv1.RematchResponse, error) {
+	return s.handleRematch(ctx, req, s.matchesService.OfferRematch)
+}
+
+func (s *matchesGRPCServer) AcceptRematch(ctx context.Context, req *bullandcowsv1.RematchRequest) (*bullandcowsv1.RematchResponse, error) {
+	return s.handleRematch(ctx, req, s.matchesService.AcceptRematch)
+}
+
+func (s *matchesGRPCServer) DeclineRematch(ctx context.Context, req *bullandcowsv1.RematchRequest) (*bullandcowsv1.RematchResponse, error) {
+	return s.handleRematch(ctx, req, s.matchesService.DeclineRematch)
+}
+
+func (s *matchesGRPCServer) handleRematch(
+	ctx context.Context,
+	req *bullandcowsv1.RematchRequest,
+	action func(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error),
+) (*bullandcowsv1.RematchResponse, error) {
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return nil, err
+	}
+
+	command := contracts.RematchCommand{
+		PlayerId: req.GetPlayerId(),
+		RoomId:   req.GetRoomId(),
+	}
+	if err := Validate.Struct(command); err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	addContextLogFields(ctx, "player_handle", domain.PlayerHandle(command.PlayerId))
+
+	res, err := action(ctx, command)
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	return &bullandcowsv1.RematchResponse{Success: res.Success}, nil
+}
+
+func (s *matchesGRPCServer) GetMatch(ctx context.Context, req *bullandcowsv1.GetMatchRequest) (*bullandcowsv1.MatchState, error) {
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return nil, err
+	}
+
+	res, err := s.matchesService.GetMatch(ctx, req.GetRoomId())
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+
+	return toProtoMatchState(res), nil
+}
+
+func (s *matchesGRPCServer) WatchMatch(req *bullandcowsv1.WatchMatchRequest, stream grpc.ServerStreamingServer[bullandcowsv1.MatchEvent]) error {
+	ctx := stream.Context()
+	if err := s.validateRoomId(ctx, req.GetRoomId()); err != nil {
+		return err
+	}
+
+	events, err := s.matchesService.WatchMatch(ctx, req.GetRoomId())
+	if err != nil {
+		return s.grpcError(ctx, err)
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			if err := stream.Send(toProtoMatchEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *matchesGRPCServer) validateRoomId(ctx context.Context, roomId string) error {
+	addContextLogFields(ctx, "room_id", roomId)
+	if err := validateRoomId(roomId); err != nil {
+		return s.grpcError(ctx, err)
+	}
+	return nil
+}
+
+func fromProtoMatchMode(mode bullandcowsv1.MatchMode) domain.MatchMode {
+	switch mode {
+	case bullandcowsv1.MatchMode_MATCH_MODE_UNSPECIFIED:
+		return ""
+	case bullandcowsv1.MatchMode_MATCH_MODE_TURNS:
+		return domain.MatchModeTurns
+	case bullandcowsv1.MatchMode_MATCH_MODE_RACE:
+		return domain.MatchModeRace
+	}
+	// unknown values are kept so validation rejects them
+	return domain.MatchMode(mode.String())
+}
+
+func toProtoMatchMode(mode domain.MatchMode) bullandcowsv1.MatchMode {
+	switch mode {
+	case domain.MatchModeTurns:
+		return bullandcowsv1.MatchMode_MATCH_MODE_TURNS
+	case domain.MatchModeRace:
+		return bullandcowsv1.MatchMode_MATCH_MODE_RACE
+	}
+	return bullandcowsv1.MatchMode_MATCH_MODE_UNSPECIFIED
+}
+
+func toProtoMatchStatus(status domain.MatchStatus) bullandcowsv1.MatchStatus {
+	switch status {
+	case domain.MatchStateWaiting:
+		return bullandcowsv1.MatchStatus_MATCH_STATUS_WAITING
+	case domain.MatchStateFullRoom:
+		return bullandcowsv1.MatchStatus_MATCH_STATUS_FULL_ROOM
+	case domain.MatchStatePlaying:
+		return bullandcowsv1.MatchStatus_MATCH_STATUS_PLAYING
+	case domain.MatchStateFinished:
+		return bullandcowsv1.MatchStatus_MATCH_STATUS_FINISHED
+	}
+	return bullandcowsv1.MatchStatus_MATCH_STATUS_UNSPECIFIED
+}
+
+func toProtoDigitResult(result domain.BullAndCowType) bullandcowsv1.DigitResult {
+	switch result {
+	case domain.Bull:
+		return bullandcowsv1.DigitResult_DIGIT_RESULT_BULL
+	case domain.Cow:
+		return bullandcowsv1.DigitResult_DIGIT_RESULT_COW
+	case domain.None:
+		return bullandcowsv1.DigitResult_DIGIT_RESULT_NONE
+	}
+	return bullandcowsv1.DigitResult_DIGIT_RESULT_UNSPECIFIED
+}
+
+var protoMatchEventTypes = map[contracts.MatchEventType]bullandcowsv1.MatchEventType{
+	contracts.MatchEventSnapshot:         bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_SNAPSHOT,
+	contracts.MatchEventPlayerJoined:     bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_PLAYER_JOINED,
+	contracts.MatchEventPlayerLeft:       bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_PLAYER_LEFT,
+	contracts.MatchEventMatchStarted:     bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_MATCH_STARTED,
+	contracts.MatchEventGuessMade:        bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_GUESS_MADE,
+	contracts.MatchEventMatchFinished:    bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_MATCH_FINISHED,
+	contracts.MatchEventRematchOffered:   bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_REMATCH_OFFERED,
+	contracts.MatchEventRematchCancelled: bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_REMATCH_CANCELLED,
+	contracts.MatchEventRematchAccepted:  bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_REMATCH_ACCEPTED,
+	contracts.MatchEventUpdated:          bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_UPDATED,
+	contracts.MatchEventMatchExpired:     bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_MATCH_EXPIRED,
+	contracts.MatchEventMatchClosed:      bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_MATCH_CLOSED,
+}
+
+func toProtoPlayer(player contracts.PlayerResponse) *bullandcowsv1.Player {
+	return &bullandcowsv1.Player{
+		Username: player.Username,
+		Handle:   player.Handle,
+	}
+}
+
+func toProtoPlayerCredentials(player contracts.PlayerCredentialsResponse) *bullandcowsv1.Player {
+	return &bullandcowsv1.Player{
+		Id:       player.Id,
+		Username: player.Username,
+		Handle:   player.Handle,
+	}
+}
+
+func toProtoGuesses(guesses domain.MatchGuesses) map[string]*bullandcowsv1.PlayerGuesses {
+	result := make(map[string]*bullandcowsv1.PlayerGuesses, len(guesses))
+	for handle, items := range guesses {
+		playerGuesses := &bullandcowsv1.PlayerGuesses{}
+		for _, item := range items {
+			guess := &bullandcowsv1.Guess{
+				IsWinnerCombination: item.IsWinnerCombination,
+				Round:               int32(item.Round),
+				PlayedAt:            timestamppb.New(item.PlayedAt),
+			}
+			for _, digit := range item.Guess {
+				guess.Digits = append(guess.Digits, &bullandcowsv1.Digit{
+					Value:  digit.Value,
+					Result: toProtoDigitResult(digit.Type),
+				})
+			}
+			playerGuesses.Guesses = append(playerGuesses.Guesses, guess)
+		}
+		result[handle] = playerGuesses
+	}
+	return result
+}
+
+func toProtoMatchState(match *contracts.MatchStateResponse) *bullandcowsv1.MatchState {
+	state := &bullandcowsv1.MatchState{
+		RoomId:   match.RoomId,
+		Mode:     toProtoMatchMode(match.Mode),
+		Status:   toProtoMatchStatus(match.Status),
+		IsTurnOf: match.IsTurnOf,
+		Winner:   match.Winner,
+		Guesses:  toProtoGuesses(match.Guesses),
+		Series: &bullandcowsv1.Series{
+			BestOf: int32(match.Series.BestOf),
+			Score:  make(map[string]int32, len(match.Series.Score)),
+			Winner: match.Series.Winner,
+		},
+	}
+
+	for _, player := range match.Players {
+		state.Players = append(state.Players, toProtoPlayer(player))
+	}
+
+	for handle, wins := range match.Series.Score {
+		state.Series.Score[handle] = int32(wins)
+	}
+
+	for _, round := range match.Series.Rounds {
+		state.Series.Rounds = append(state.Series.Rounds, &bullandcowsv1.RoundResult{
+			Round:     int32(round.Round),
+			Winner:    round.Winner,
+			Guesses:   int32(round.Guesses),
+			StartedBy: round.StartedBy,
+		})
+	}
+
+	if match.Rematch != nil {
+		state.RematchOffer = &bullandcowsv1.RematchOffer{
+			OfferedBy: match.Rematch.OfferedBy,
+			ExpiresAt: timestamppb.New(match.Rematch.ExpiresAt),
+		}
+	}
+
+	return state
+}
+
+func toProtoMatchEvent(event contracts.MatchEvent) *bullandcowsv1.MatchEvent {
+	protoEvent := &bullandcowsv1.MatchEvent{
+		Type: protoMatchEventTypes[event.Type],
+		At:   timestamppb.New(event.At),
+	}
+	if event.Match != nil {
+		protoEvent.Match = toProtoMatchState(event.Match)
+	}
+	return protoEvent
+}
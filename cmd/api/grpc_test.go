@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	bullandcowsv1 "github.com/alejandro-cardenas-g/bullAndCowsApp/proto/bullandcows/v1"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeMatchesService embeds the interface so tests only stub what they call.
+type fakeMatchesService struct {
+	contracts.IMatchesService
+	createRoom func(command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error)
+	getMatch   func(roomId string) (*contracts.MatchStateResponse, error)
+	watchMatch func(ctx context.Context, roomId string) (<-chan contracts.MatchEvent, error)
+}
+
+func (f *fakeMatchesService) CreateRoom(ctx context.Context, command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error) {
+	return f.createRoom(command)
+}
+
+func (f *fakeMatchesService) GetMatch(ctx context.Context, roomId string) (*contracts.MatchStateResponse, error) {
+	return f.getMatch(roomId)
+}
+
+func (f *fakeMatchesService) WatchMatch(ctx context.Context, roomId string) (<-chan contracts.MatchEvent, error) {
+	return f.watchMatch(ctx, roomId)
+}
+
+func newGRPCTestClient(t *testing.T, matchesService contracts.IMatchesService, done <-chan struct{}) bullandcowsv1.MatchesServiceClient {
+	t.Helper()
+
+	app := &Application{logger: zap.NewNop().Sugar()}
+	server, _ := app.newGRPCServer(&Controller{logger: app.logger, done: done}, matchesService)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dialing bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return bullandcowsv1.NewMatchesServiceClient(conn)
+}
+
+func errorInfoReason(t *testing.T, err error) string {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	t.Fatalf("expected an ErrorInfo detail in %v", err)
+	return ""
+}
+
+func TestGRPCCreateRoom(t *testing.T) {
+	var received contracts.CreateRoomCommand
+	client := newGRPCTestClient(t, &fakeMatchesService{
+		createRoom: func(command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error) {
+			received = command
+			return &contracts.CreateRoomResponse{
+				RoomId: "abc1234",
+				Mode:   command.Mode,
+				BestOf: command.BestOf,
+				Player: contracts.PlayerCredentialsResponse{Id: "p1", Username: command.Username},
+			}, nil
+		},
+	}, nil)
+
+	var header metadata.MD
+	res, err := client.CreateRoom(context.Background(), &bullandcowsv1.CreateRoomRequest{
+		Username: "alice",
+		Mode:     bullandcowsv1.MatchMode_MATCH_MODE_RACE,
+		BestOf:   3,
+	}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	if received.Mode != domain.MatchModeRace || received.BestOf != 3 || received.Username != "alice" {
+		t.Errorf("unexpected command %+v", received)
+	}
+	if res.RoomId != "abc1234" || res.Mode != bullandcowsv1.MatchMode_MATCH_MODE_RACE || res.Player.GetUsername() != "alice" {
+		t.Errorf("unexpected response %v", res)
+	}
+	if len(header.Get(REQUEST_ID_HEADER)) != 1 {
+		t.Errorf("expected a request id header, got %v", header)
+	}
+}
+
+func TestGRPCValidationError(t *testing.T) {
+	client := newGRPCTestClient(t, &fakeMatchesService{}, nil)
+
+	_, err := client.CreateRoom(context.Background(), &bullandcowsv1.CreateRoomRequest{BestOf: 2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if reason := errorInfoReason(t, err); reason != string(contracts.ErrorCodeValidationFailed) {
+		t.Errorf("expected reason %v, got %v", contracts.ErrorCodeValidationFailed, reason)
+	}
+
+	fields := map[string]bool{}
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields[violation.Field] = true
+			}
+		}
+	}
+	if !fields["username"] || !fields["best_of"] {
+		t.Errorf("expected username and best_of violations, got %v", fields)
+	}
+}
+
+func TestGRPCErrorMapping(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason contracts.ErrorCode
+	}{
+		{services.ErrMatchNotFound, codes.NotFound, contracts.ErrorCodeMatchNotFound},
+		{services.ErrMatchExpired, codes.NotFound, contracts.ErrorCodeMatchExpired},
+		{errors.New("redis is down"), codes.Internal, contracts.ErrorCodeInternal},
+	}
+
+	for _, test := range tests {
+		client := newGRPCTestClient(t, &fakeMatchesService{
+			getMatch: func(roomId string) (*contracts.MatchStateResponse, error) {
+				return nil, test.err
+			},
+		}, nil)
+
+		_, err := client.GetMatch(context.Background(), &bullandcowsv1.GetMatchRequest{RoomId: "abc1234"})
+		if status.Code(err) != test.code {
+			t.Errorf("%v: expected %v, got %v", test.err, test.code, err)
+			continue
+		}
+		if reason := errorInfoReason(t, err); reason != string(test.reason) {
+			t.Errorf("%v: expected reason %v, got %v", test.err, test.reason, reason)
+		}
+	}
+}
+
+func TestGRPCWatchMatch(t *testing.T) {
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := newGRPCTestClient(t, &fakeMatchesService{
+		watchMatch: func(ctx context.Context, roomId string) (<-chan contracts.MatchEvent, error) {
+			events := make(chan contracts.MatchEvent, 2)
+			events <- contracts.MatchEvent{
+				Type: contracts.MatchEventSnapshot,
+				At:   at,
+				Match: &contracts.MatchStateResponse{
+					RoomId: roomId,
+					Mode:   domain.MatchModeTurns,
+					Status: domain.MatchStatePlaying,
+					Guesses: domain.MatchGuesses{
+						"p1": {{
+							Guess: []domain.BullAndCowGuess{{Value: "1", Type: domain.Bull}, {Value: "2", Type: domain.Cow}},
+							Round: 1,
+						}},
+					},
+				},
+			}
+			events <- contracts.MatchEvent{Type: contracts.MatchEventMatchExpired, At: at}
+			close(events)
+			return events, nil
+		},
+	}, nil)
+
+	stream, err := client.WatchMatch(context.Background(), &bullandcowsv1.WatchMatchRequest{RoomId: "abc1234"})
+	if err != nil {
+		t.Fatalf("WatchMatch: %v", err)
+	}
+
+	snapshot, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("receiving snapshot: %v", err)
+	}
+	if snapshot.Type != bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_SNAPSHOT || !snapshot.At.AsTime().Equal(at) {
+		t.Errorf("unexpected snapshot %v", snapshot)
+	}
+	if snapshot.Match.GetStatus() != bullandcowsv1.MatchStatus_MATCH_STATUS_PLAYING {
+		t.Errorf("expected a playing match, got %v", snapshot.Match.GetStatus())
+	}
+	digits := snapshot.Match.GetGuesses()["p1"].GetGuesses()[0].GetDigits()
+	if len(digits) != 2 || digits[0].Result != bullandcowsv1.DigitResult_DIGIT_RESULT_BULL || digits[1].Result != bullandcowsv1.DigitResult_DIGIT_RESULT_COW {
+		t.Errorf("unexpected digits %v", digits)
+	}
+
+	expired, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("receiving expired event: %v", err)
+	}
+	if expired.Type != bullandcowsv1.MatchEventType_MATCH_EVENT_TYPE_MATCH_EXPIRED || expired.Match != nil {
+		t.Errorf("unexpected event %v", expired)
+	}
+
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("expected the stream to end, got %v", err)
+	}
+}
+
+func TestGRPCWatchMatchEndsOnShutdown(t *testing.T) {
+	done := make(chan struct{})
+	client := newGRPCTestClient(t, &fakeMatchesService{
+		watchMatch: func(ctx context.Context, roomId string) (<-chan contracts.MatchEvent, error) {
+			events := make(chan contracts.MatchEvent, 1)
+			events <- contracts.MatchEvent{Type: contracts.MatchEventSnapshot, At: time.Now()}
+			return events, nil
+		},
+	}, done)
+
+	stream, err := client.WatchMatch(context.Background(), &bullandcowsv1.WatchMatchRequest{RoomId: "abc1234"})
+	if err != nil {
+		t.Fatalf("WatchMatch: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("receiving snapshot: %v", err)
+	}
+
+	close(done)
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable after shutdown, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/gorilla/mux"
+)
+
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status       string                      `json:"status"`
+	ShuttingDown bool                        `json:"shutting_down"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+type HealthController struct {
+	*Controller
+	checks       []HealthCheck
+	timeout      time.Duration
+	shuttingDown *atomic.Bool
+}
+
+func newHealthController(controller *Controller, shuttingDown *atomic.Bool, timeout time.Duration, checks ...HealthCheck) *HealthController {
+	return &HealthController{
+		Controller:   controller,
+		checks:       checks,
+		timeout:      timeout,
+		shuttingDown: shuttingDown,
+	}
+}
+
+func (hc *HealthController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/healthz", hc.livenessHandler).Methods("GET")
+	router.HandleFunc("/readyz", hc.readinessHandler).Methods("GET")
+}
+
+func (hc *HealthController) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	type envelope struct {
+		Status string `json:"status"`
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, &envelope{Status: "ok"}); err != nil {
+		hc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (hc *HealthController) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), hc.timeout)
+	defer cancel()
+
+	res := &ReadinessResponse{
+		Status:       "ready",
+		ShuttingDown: hc.shuttingDown.Load(),
+		Dependencies: make(map[string]DependencyStatus, len(hc.checks)),
+	}
+
+	for _, check := range hc.checks {
+		start := time.Now()
+		err := check.Check(ctx)
+
+		dependency := DependencyStatus{
+			Status:    "up",
+			LatencyMs: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			dependency.Status = "down"
+			dependency.Error = err.Error()
+			res.Status = "not_ready"
+		}
+		res.Dependencies[check.Name] = dependency
+	}
+
+	status := http.StatusOK
+	if res.ShuttingDown {
+		res.Status = "not_ready"
+	}
+	if res.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+
+	if err := utils.WriteJSON(w, status, res); err != nil {
+		hc.InternalServerError(w, r, err)
+		return
+	}
+}
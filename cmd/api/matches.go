@@ -1,15 +1,22 @@
 package api
 
 import (
-	"errors"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
-	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
 	"github.com/gorilla/mux"
 )
 
+const MATCH_WATCH_HEARTBEAT = 20 * time.Second
+
 type MatchesController struct {
 	*Controller
 	matchesService contracts.IMatchesService
@@ -28,7 +35,14 @@ func (uc *MatchesController) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/matches/setCombination/{roomId}", uc.setCombinationHandler).Methods("PUT")
 	router.HandleFunc("/matches/startGame/{roomId}", uc.startGameHandler).Methods("PUT")
 	router.HandleFunc("/matches/makeGuess/{roomId}", uc.makeGuessHandler).Methods("PUT")
-	router.HandleFunc("/matches/restart/{roomId}", uc.restartGameHandler).Methods("PUT")
+	router.HandleFunc("/matches/rematch/offer/{roomId}", uc.offerRematchHandler).Methods("PUT")
+	router.HandleFunc("/matches/rematch/accept/{roomId}", uc.acceptRematchHandler).Methods("PUT")
+	router.HandleFunc("/matches/rematch/decline/{roomId}", uc.declineRematchHandler).Methods("PUT")
+	router.HandleFunc("/matches/{roomId}", uc.getMatchHandler).Methods("GET")
+	router.HandleFunc("/matches/watch/{roomId}", uc.watchMatchHandler).Methods("GET")
+	router.HandleFunc("/matches/chat/{roomId}", uc.sendChatMessageHandler).Methods("POST")
+	router.HandleFunc("/matches/chat/{roomId}", uc.getChatHandler).Methods("GET")
+	router.HandleFunc("/matches/verify", uc.verifyCommitmentHandler).Methods("POST")
 }
 
 func (uc *MatchesController) createMatchHandler(w http.ResponseWriter, r *http.Request) {
@@ -51,6 +65,8 @@ func (uc *MatchesController) createMatchHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	addLogFields(r, "room_id", res.RoomId, "player_handle", res.Player.Handle)
+
 	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
 		uc.InternalServerError(w, r, err)
 		return
@@ -82,23 +98,12 @@ func (uc *MatchesController) joinMatchHandler(w http.ResponseWriter, r *http.Req
 	res, err := uc.matchesService.JoinRoom(r.Context(), *payload)
 
 	if err != nil {
-		switch err {
-		case services.ErrMatchNotFound:
-			{
-				uc.NotFoundError(w, r, err)
-			}
-		case services.ErrCanNotAddAnotherPlayer:
-			{
-				uc.ConflictError(w, r, err)
-			}
-		default:
-			{
-				uc.InternalServerError(w, r, err)
-			}
-		}
+		uc.ErrorResponse(w, r, err)
 		return
 	}
 
+	addLogFields(r, "player_handle", res.Player.Handle)
+
 	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
 		uc.InternalServerError(w, r, err)
 		return
@@ -126,28 +131,12 @@ func (uc *MatchesController) setCombinationHandler(w http.ResponseWriter, r *htt
 	}
 
 	payload.RoomId = roomId
+	addLogFields(r, "player_handle", domain.PlayerHandle(payload.PlayerId))
 
 	res, err := uc.matchesService.SetCombination(r.Context(), *payload)
 
 	if err != nil {
-		switch {
-		case errors.Is(err, services.ErrMatchNotFullRoom):
-			{
-				uc.ConflictError(w, r, err)
-			}
-		case errors.Is(err, services.ErrInvalidCombination):
-			{
-				uc.BadRequestError(w, r, err)
-			}
-		case errors.Is(err, services.ErrMatchNotFound):
-			{
-				uc.NotFoundError(w, r, err)
-			}
-		default:
-			{
-				uc.InternalServerError(w, r, err)
-			}
-		}
+		uc.ErrorResponse(w, r, err)
 		return
 	}
 
@@ -169,24 +158,7 @@ func (uc *MatchesController) startGameHandler(w http.ResponseWriter, r *http.Req
 	result, err := uc.matchesService.StartGame(r.Context(), roomId)
 
 	if err != nil {
-		switch {
-		case errors.Is(err, services.ErrMatchNotFullRoom):
-			{
-				uc.ConflictError(w, r, err)
-			}
-		case errors.Is(err, services.ErrMatchNotFound):
-			{
-				uc.NotFoundError(w, r, err)
-			}
-		case errors.Is(err, services.ErrExpectingCombinations):
-			{
-				uc.ConflictError(w, r, err)
-			}
-		default:
-			{
-				uc.InternalServerError(w, r, err)
-			}
-		}
+		uc.ErrorResponse(w, r, err)
 		return
 	}
 
@@ -217,30 +189,64 @@ func (uc *MatchesController) makeGuessHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	payload.RoomId = roomId
+	addLogFields(r, "player_handle", domain.PlayerHandle(payload.PlayerId))
 
 	result, err := uc.matchesService.MakeGuess(r.Context(), *payload)
 
 	if err != nil {
+		uc.ErrorResponse(w, r, err)
+		return
+	}
 
-		switch err {
-		case services.ErrNotYourTurn, services.ErrMatchNotStarted:
-			{
-				uc.ConflictError(w, r, err)
-			}
-		case services.ErrMatchNotFound:
-			{
-				uc.NotFoundError(w, r, err)
-			}
-		default:
-			{
-				if errors.Is(err, services.ErrInvalidCombination) {
-					uc.BadRequestError(w, r, err)
-				} else {
-
-					uc.InternalServerError(w, r, err)
-				}
-			}
-		}
+	if err := utils.WriteJSON(w, http.StatusOK, result); err != nil {
+		uc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (uc *MatchesController) offerRematchHandler(w http.ResponseWriter, r *http.Request) {
+	uc.handleRematch(w, r, uc.matchesService.OfferRematch)
+}
+
+func (uc *MatchesController) acceptRematchHandler(w http.ResponseWriter, r *http.Request) {
+	uc.handleRematch(w, r, uc.matchesService.AcceptRematch)
+}
+
+func (uc *MatchesController) declineRematchHandler(w http.ResponseWriter, r *http.Request) {
+	uc.handleRematch(w, r, uc.matchesService.DeclineRematch)
+}
+
+func (uc *MatchesController) handleRematch(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error),
+) {
+	vars := mux.Vars(r)
+	roomId := vars["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload := &contracts.RematchCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.RoomId = roomId
+	addLogFields(r, "player_handle", domain.PlayerHandle(payload.PlayerId))
+
+	result, err := action(r.Context(), *payload)
+
+	if err != nil {
+		uc.ErrorResponse(w, r, err)
 		return
 	}
 
@@ -250,7 +256,7 @@ func (uc *MatchesController) makeGuessHandler(w http.ResponseWriter, r *http.Req
 	}
 }
 
-func (uc *MatchesController) restartGameHandler(w http.ResponseWriter, r *http.Request) {
+func (uc *MatchesController) getMatchHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	roomId := vars["roomId"]
 
@@ -259,19 +265,10 @@ func (uc *MatchesController) restartGameHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	result, err := uc.matchesService.RestartGame(r.Context(), roomId)
+	result, err := uc.matchesService.GetMatch(r.Context(), roomId)
 
 	if err != nil {
-		switch err {
-		case services.ErrMatchNotFound:
-			{
-				uc.NotFoundError(w, r, err)
-			}
-		default:
-			{
-				uc.InternalServerError(w, r, err)
-			}
-		}
+		uc.ErrorResponse(w, r, err)
 		return
 	}
 
@@ -281,8 +278,150 @@ func (uc *MatchesController) restartGameHandler(w http.ResponseWriter, r *http.R
 	}
 }
 
+func (uc *MatchesController) sendChatMessageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roomId := vars["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload := &contracts.ChatMessageCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.Message = strings.TrimSpace(payload.Message)
+	if err := Validate.Struct(payload); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.RoomId = roomId
+	addLogFields(r, "player_handle", domain.PlayerHandle(payload.PlayerId))
+
+	result, err := uc.matchesService.SendChatMessage(r.Context(), *payload)
+
+	if err != nil {
+		uc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, result); err != nil {
+		uc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (uc *MatchesController) getChatHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roomId := vars["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	result, err := uc.matchesService.GetChat(r.Context(), roomId)
+
+	if err != nil {
+		uc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, result); err != nil {
+		uc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (uc *MatchesController) verifyCommitmentHandler(w http.ResponseWriter, r *http.Request) {
+	payload := &contracts.VerifyCommitmentCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := uc.matchesService.VerifyCommitment(r.Context(), *payload)
+
+	if err != nil {
+		uc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		uc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// watchMatchHandler streams match events as server-sent events until the match is
+// gone, the client disconnects or the server shuts down.
+func (uc *MatchesController) watchMatchHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roomId := vars["roomId"]
+
+	if err := validateRoomId(roomId); err != nil {
+		uc.BadRequestError(w, r, err)
+		return
+	}
+
+	events, err := uc.matchesService.WatchMatch(r.Context(), roomId)
+
+	if err != nil {
+		uc.ErrorResponse(w, r, err)
+		return
+	}
+
+	// the stream outlives the server write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	heartbeat := time.NewTicker(MATCH_WATCH_HEARTBEAT)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-uc.done:
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				uc.Logger(r).Errorw("error encoding match event", "error", err.Error())
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: %v\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
 func validateRoomId(roomId string) error {
 	return Validate.Struct(struct {
-		RoomId string `validate:"required,len=7"`
+		RoomId string `json:"room_id" validate:"required,len=7"`
 	}{RoomId: roomId})
 }
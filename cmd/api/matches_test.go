@@ -0,0 +1,448 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/store"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gorilla/mux"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// stubMatchesService fails every call with err, or succeeds with an empty response
+// when err is nil.
+type stubMatchesService struct {
+	err error
+}
+
+func (s *stubMatchesService) CreateRoom(ctx context.Context, command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.CreateRoomResponse{RoomId: "abc1234", Player: contracts.PlayerCredentialsResponse{Id: "p1", Username: command.Username}}, nil
+}
+
+func (s *stubMatchesService) JoinRoom(ctx context.Context, command contracts.JoinRoomCommand) (*contracts.JoinRoomResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.JoinRoomResponse{RoomId: command.RoomId, Player: contracts.PlayerCredentialsResponse{Id: "p2", Username: command.Username}}, nil
+}
+
+func (s *stubMatchesService) SetCombination(ctx context.Context, command contracts.SetCombinationCommand) (*contracts.SuccessResponse, error) {
+	return s.success()
+}
+
+func (s *stubMatchesService) StartGame(ctx context.Context, roomId string) (*contracts.StartMatchResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.StartMatchResponse{Mode: domain.MatchModeTurns, IsTurnOf: "p1"}, nil
+}
+
+func (s *stubMatchesService) MakeGuess(ctx context.Context, command contracts.MakeGuessCommand) (*contracts.MakeGuessResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.MakeGuessResponse{Guesses: domain.MatchGuesses{}}, nil
+}
+
+func (s *stubMatchesService) OfferRematch(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error) {
+	return s.success()
+}
+
+func (s *stubMatchesService) AcceptRematch(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error) {
+	return s.success()
+}
+
+func (s *stubMatchesService) DeclineRematch(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error) {
+	return s.success()
+}
+
+func (s *stubMatchesService) GetMatch(ctx context.Context, roomId string) (*contracts.MatchStateResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.MatchStateResponse{RoomId: roomId}, nil
+}
+
+func (s *stubMatchesService) WatchMatch(ctx context.Context, roomId string) (<-chan contracts.MatchEvent, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	events := make(chan contracts.MatchEvent)
+	close(events)
+	return events, nil
+}
+
+func (s *stubMatchesService) SendChatMessage(ctx context.Context, command contracts.ChatMessageCommand) (*contracts.ChatMessageResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.ChatMessageResponse{Handle: domain.PlayerHandle(command.PlayerId), Message: command.Message}, nil
+}
+
+func (s *stubMatchesService) GetChat(ctx context.Context, roomId string) (*contracts.ChatResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.ChatResponse{Messages: []contracts.ChatMessageResponse{}}, nil
+}
+
+func (s *stubMatchesService) VerifyCommitment(ctx context.Context, command contracts.VerifyCommitmentCommand) (*contracts.VerifyCommitmentResponse, error) {
+	return stubResponse[contracts.VerifyCommitmentResponse](s.err)
+}
+
+func (s *stubMatchesService) success() (*contracts.SuccessResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &contracts.SuccessResponse{Success: true}, nil
+}
+
+// stubResponse fails with err, or succeeds with an empty T when err is nil.
+func stubResponse[T any](err error) (*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return new(T), nil
+}
+
+type handlerEndpoint struct {
+	name    string
+	method  string
+	path    string
+	body    string
+	success int
+	// mapsErrors is false for handlers that answer every service error with a 500
+	mapsErrors bool
+}
+
+type errorMapping struct {
+	err    error
+	status int
+	code   contracts.ErrorCode
+}
+
+// TEST_COMMITMENT is the commitment to 1234 salted with 00ff.
+const TEST_COMMITMENT = "5da33a23e1c6316c8d6beaf089cd0897d4256e30b94e124e82e6c03c55ee5045"
+
+var matchesEndpoints = []handlerEndpoint{
+	{"create", "POST", "/matches/create", `{"username":"alice"}`, http.StatusOK, false},
+	{"join", "PUT", "/matches/join/abc1234", `{"username":"bob"}`, http.StatusOK, true},
+	{"setCombination", "PUT", "/matches/setCombination/abc1234", `{"player_id":"p1","combination":1234}`, http.StatusAccepted, true},
+	{"startGame", "PUT", "/matches/startGame/abc1234", ``, http.StatusAccepted, true},
+	{"makeGuess", "PUT", "/matches/makeGuess/abc1234", `{"player_id":"p1","guess":1234}`, http.StatusOK, true},
+	{"offerRematch", "PUT", "/matches/rematch/offer/abc1234", `{"player_id":"p1"}`, http.StatusOK, true},
+	{"acceptRematch", "PUT", "/matches/rematch/accept/abc1234", `{"player_id":"p1"}`, http.StatusOK, true},
+	{"declineRematch", "PUT", "/matches/rematch/decline/abc1234", `{"player_id":"p1"}`, http.StatusOK, true},
+	{"getMatch", "GET", "/matches/abc1234", ``, http.StatusOK, true},
+	{"watch", "GET", "/matches/watch/abc1234", ``, http.StatusOK, true},
+	{"sendChat", "POST", "/matches/chat/abc1234", `{"player_id":"p1","message":"hi"}`, http.StatusOK, true},
+	{"getChat", "GET", "/matches/chat/abc1234", ``, http.StatusOK, true},
+	{"verify", "POST", "/matches/verify", `{"commitment":"` + TEST_COMMITMENT + `","secret":"1234","salt":"00ff"}`, http.StatusOK, true},
+}
+
+func newMatchesTestRouter(matchesService contracts.IMatchesService) http.Handler {
+	router := mux.NewRouter()
+	controller := newMatchesController(&Controller{logger: zap.NewNop().Sugar(), done: make(chan struct{})}, matchesService)
+	controller.RegisterRoutes(router)
+	return router
+}
+
+func TestAccessLogNamesPlayersByHandle(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	app := &Application{logger: zap.New(core).Sugar()}
+	router := app.requestMiddleware(newMatchesTestRouter(&stubMatchesService{}))
+
+	serveAPI(t, router, "PUT", "/matches/makeGuess/abc1234", `{"player_id":"secret-player-id","guess":1234}`)
+
+	entries := logs.FilterMessage("access").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected one access log entry, got %v", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["player_handle"] != domain.PlayerHandle("secret-player-id") {
+		t.Fatalf("expected the player handle in the access log, got %v", fields)
+	}
+	for key, value := range fields {
+		if value == "secret-player-id" {
+			t.Fatalf("expected the player id to stay out of the access log, found it under %v", key)
+		}
+	}
+}
+
+func serveAPI(t *testing.T, router http.Handler, method string, path string, body string, headers ...string) (*httptest.ResponseRecorder, *contracts.ProblemResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code < http.StatusBadRequest {
+		return rec, nil
+	}
+
+	if contentType := rec.Header().Get("Content-Type"); contentType != contracts.PROBLEM_CONTENT_TYPE {
+		t.Fatalf("expected a problem response, got %q", contentType)
+	}
+	problem := &contracts.ProblemResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(problem); err != nil {
+		t.Fatalf("decoding problem: %v", err)
+	}
+	if problem.Status != rec.Code {
+		t.Fatalf("problem status %v does not match the response status %v", problem.Status, rec.Code)
+	}
+	return rec, problem
+}
+
+// assertSuccess serves every endpoint once and checks its success status.
+func assertSuccess(t *testing.T, router http.Handler, endpoints []handlerEndpoint, headers ...string) {
+	t.Helper()
+
+	for _, endpoint := range endpoints {
+		t.Run(endpoint.name, func(t *testing.T) {
+			rec, _ := serveAPI(t, router, endpoint.method, endpoint.path, endpoint.body, headers...)
+			if rec.Code != endpoint.success {
+				t.Fatalf("expected %v, got %v: %s", endpoint.success, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+// assertErrorMappings serves every endpoint with a service failing with each error and
+// checks the problem it turns into. An unmapped error is always added and must come
+// back as a 500 that does not leak its message.
+func assertErrorMappings(t *testing.T, endpoints []handlerEndpoint, newRouter func(err error) http.Handler, mappings []errorMapping, headers ...string) {
+	t.Helper()
+
+	mappings = append(mappings, errorMapping{fmt.Errorf("storage unavailable"), http.StatusInternalServerError, contracts.ErrorCodeInternal})
+
+	for _, endpoint := range endpoints {
+		for _, tt := range mappings {
+			t.Run(endpoint.name+"/"+string(tt.code), func(t *testing.T) {
+				status, code := tt.status, tt.code
+				if !endpoint.mapsErrors {
+					status, code = http.StatusInternalServerError, contracts.ErrorCodeInternal
+				}
+
+				rec, problem := serveAPI(t, newRouter(tt.err), endpoint.method, endpoint.path, endpoint.body, headers...)
+				if rec.Code != status || problem.Code != code {
+					t.Fatalf("expected %v %v, got %v %v", status, code, rec.Code, problem.Code)
+				}
+				if code == contracts.ErrorCodeInternal && strings.Contains(problem.Detail, tt.err.Error()) {
+					t.Fatalf("internal errors must not leak, got %q", problem.Detail)
+				}
+			})
+		}
+	}
+}
+
+func TestMatchesHandlersSuccess(t *testing.T) {
+	assertSuccess(t, newMatchesTestRouter(&stubMatchesService{}), matchesEndpoints)
+}
+
+func TestMatchesHandlersErrorMapping(t *testing.T) {
+	assertErrorMappings(t, matchesEndpoints, func(err error) http.Handler {
+		return newMatchesTestRouter(&stubMatchesService{err: err})
+	}, []errorMapping{
+		{fmt.Errorf("%w: %w", services.ErrInvalidCombination, domain.ErrInvalidUniqueCombination), http.StatusBadRequest, contracts.ErrorCodeRepeatedDigits},
+		{fmt.Errorf("%w: %w", services.ErrInvalidCombination, domain.ErrInvalidCombination), http.StatusBadRequest, contracts.ErrorCodeInvalidCombination},
+		{services.ErrInvalidCombination, http.StatusBadRequest, contracts.ErrorCodeInvalidCombination},
+		{services.ErrMatchExpired, http.StatusGone, contracts.ErrorCodeMatchExpired},
+		{services.ErrMatchNotFound, http.StatusNotFound, contracts.ErrorCodeMatchNotFound},
+		{services.ErrCanNotAddAnotherPlayer, http.StatusConflict, contracts.ErrorCodeRoomFull},
+		{services.ErrMatchNotFullRoom, http.StatusConflict, contracts.ErrorCodeRoomNotReady},
+		{services.ErrExpectingCombinations, http.StatusConflict, contracts.ErrorCodeCombinationsPending},
+		{services.ErrMatchNotStarted, http.StatusConflict, contracts.ErrorCodeMatchNotStarted},
+		{services.ErrMatchIsFinished, http.StatusConflict, contracts.ErrorCodeMatchFinished},
+		{services.ErrNotYourTurn, http.StatusConflict, contracts.ErrorCodeNotYourTurn},
+		{services.ErrAlreadySolved, http.StatusConflict, contracts.ErrorCodeAlreadySolved},
+		{services.ErrSeriesIsOver, http.StatusConflict, contracts.ErrorCodeSeriesOver},
+		{services.ErrMatchNotFinished, http.StatusConflict, contracts.ErrorCodeMatchNotFinished},
+		{services.ErrRematchAlreadyOffered, http.StatusConflict, contracts.ErrorCodeRematchAlreadyOffered},
+		{services.ErrNoRematchOffer, http.StatusConflict, contracts.ErrorCodeNoRematchOffer},
+		{services.ErrOwnRematchOffer, http.StatusConflict, contracts.ErrorCodeOwnRematchOffer},
+		{services.ErrPlayerNotInRoom, http.StatusBadRequest, contracts.ErrorCodePlayerNotInRoom},
+		{domain.ErrEmptyResult, http.StatusNotFound, contracts.ErrorCodeResourceNotFound},
+	})
+}
+
+func TestMatchesHandlersBadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   contracts.ErrorCode
+	}{
+		{"short room id", "GET", "/matches/abc", ``, contracts.ErrorCodeValidationFailed},
+		{"long room id", "PUT", "/matches/startGame/abc12345", ``, contracts.ErrorCodeValidationFailed},
+		{"missing body", "PUT", "/matches/join/abc1234", ``, contracts.ErrorCodeInvalidRequest},
+		{"malformed body", "POST", "/matches/create", `{"username":`, contracts.ErrorCodeInvalidRequest},
+		{"wrong type", "PUT", "/matches/makeGuess/abc1234", `{"player_id":"p1","guess":"1234"}`, contracts.ErrorCodeInvalidRequest},
+		{"missing username", "POST", "/matches/create", `{}`, contracts.ErrorCodeValidationFailed},
+		{"unknown mode", "POST", "/matches/create", `{"username":"alice","mode":"Blitz"}`, contracts.ErrorCodeValidationFailed},
+		{"even best of", "POST", "/matches/create", `{"username":"alice","best_of":2}`, contracts.ErrorCodeValidationFailed},
+		{"missing combination", "PUT", "/matches/setCombination/abc1234", `{"player_id":"p1"}`, contracts.ErrorCodeValidationFailed},
+		{"missing player", "PUT", "/matches/rematch/offer/abc1234", `{}`, contracts.ErrorCodeValidationFailed},
+		{"blank message", "POST", "/matches/chat/abc1234", `{"player_id":"p1","message":"   "}`, contracts.ErrorCodeValidationFailed},
+		{"long message", "POST", "/matches/chat/abc1234", `{"player_id":"p1","message":"` + strings.Repeat("a", 281) + `"}`, contracts.ErrorCodeValidationFailed},
+		{"short commitment", "POST", "/matches/verify", `{"commitment":"abc","secret":"1234","salt":"00ff"}`, contracts.ErrorCodeValidationFailed},
+		{"salt is not hex", "POST", "/matches/verify", `{"commitment":"` + TEST_COMMITMENT + `","secret":"1234","salt":"salt"}`, contracts.ErrorCodeValidationFailed},
+	}
+
+	router := newMatchesTestRouter(&stubMatchesService{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, problem := serveAPI(t, router, tt.method, tt.path, tt.body)
+			if rec.Code != http.StatusBadRequest || problem.Code != tt.code {
+				t.Fatalf("expected 400 %v, got %v %v", tt.code, rec.Code, problem.Code)
+			}
+		})
+	}
+}
+
+func TestProblemTypesResolve(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+
+	for _, problem := range problemTypes {
+		t.Run(string(problem.code), func(t *testing.T) {
+			resolved := resolveProblem(req, fmt.Errorf("wrapped: %w", problem.err))
+			if resolved.Status != problem.status || resolved.Code != problem.code {
+				t.Fatalf("expected %v %v, got %v %v", problem.status, problem.code, resolved.Status, resolved.Code)
+			}
+			if resolved.Type != PROBLEM_TYPE_PREFIX+string(problem.code) || resolved.Title == "" {
+				t.Fatalf("expected a typed and titled problem, got %+v", resolved)
+			}
+		})
+	}
+}
+
+// TestMatchStateHidesPlayerIds plays a room on the real service, the player ids
+// authenticate every action and must not show up in anything another player or a
+// spectator can read.
+func TestMatchStateHidesPlayerIds(t *testing.T) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	storage := store.NewRedisStorage(rdb, store.Config{
+		RoomTTL: store.RoomTTLPolicy{Waiting: time.Hour, Playing: time.Hour, Finished: time.Hour, Tombstone: time.Hour},
+	})
+	service := services.NewMatchesService(storage, services.GameConfig{
+		DefaultMode:       domain.MatchModeTurns,
+		DefaultBestOf:     1,
+		RematchTimeout:    time.Minute,
+		RaceFinishTimeout: time.Minute,
+	}, domain.SystemClock{}, domain.CryptoRandom{})
+	router := newMatchesTestRouter(service)
+	ctx := context.Background()
+
+	room, err := service.CreateRoom(ctx, contracts.CreateRoomCommand{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined, err := service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: room.RoomId, Username: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playerIds := []string{room.Player.Id, joined.Player.Id}
+
+	for playerId, combination := range map[string]int{room.Player.Id: 1234, joined.Player.Id: 5678} {
+		if _, err := service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: playerId, Combination: combination}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := service.SendChatMessage(ctx, contracts.ChatMessageCommand{RoomId: room.RoomId, PlayerId: room.Player.Id, Message: "good luck"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assertNoPlayerIds := func(t *testing.T, body string) {
+		t.Helper()
+		for _, playerId := range playerIds {
+			if strings.Contains(body, playerId) {
+				t.Fatalf("response exposes player id %v: %s", playerId, body)
+			}
+		}
+		if !strings.Contains(body, room.Player.Handle) {
+			t.Fatalf("expected the players to be named by handle, got %s", body)
+		}
+	}
+
+	rec, _ := serveAPI(t, router, http.MethodPut, "/matches/startGame/"+room.RoomId, "")
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 200, got %v: %s", rec.Code, rec.Body)
+	}
+	assertNoPlayerIds(t, rec.Body.String())
+
+	var started contracts.StartMatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &started); err != nil {
+		t.Fatal(err)
+	}
+	starter := room.Player.Id
+	if started.IsTurnOf != room.Player.Handle {
+		starter = joined.Player.Id
+	}
+
+	body := fmt.Sprintf(`{"player_id": %q, "guess": 9012}`, starter)
+	rec, _ = serveAPI(t, router, http.MethodPut, "/matches/makeGuess/"+room.RoomId, body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %v: %s", rec.Code, rec.Body)
+	}
+	for _, playerId := range playerIds {
+		if strings.Contains(rec.Body.String(), playerId) {
+			t.Fatalf("guess response exposes player id %v: %s", playerId, rec.Body)
+		}
+	}
+
+	for _, path := range []string{"/matches/" + room.RoomId, "/matches/chat/" + room.RoomId} {
+		t.Run(path, func(t *testing.T) {
+			rec, _ := serveAPI(t, router, http.MethodGet, path, "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %v: %s", rec.Code, rec.Body)
+			}
+			assertNoPlayerIds(t, rec.Body.String())
+		})
+	}
+
+	t.Run("watch", func(t *testing.T) {
+		api := httptest.NewServer(router)
+		defer api.Close()
+
+		res, err := http.Get(api.URL + "/matches/watch/" + room.RoomId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		// the snapshot is the first event of the stream
+		reader := bufio.NewReader(res.Body)
+		snapshot := ""
+		for !strings.HasPrefix(snapshot, "data: ") {
+			if snapshot, err = reader.ReadString('\n'); err != nil {
+				t.Fatal(err)
+			}
+		}
+		assertNoPlayerIds(t, snapshot)
+	})
+}
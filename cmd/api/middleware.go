@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/i18n"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const REQUEST_ID_HEADER = "X-Request-ID"
+
+type contextKey string
+
+const (
+	requestIdKey     = contextKey("request_id")
+	requestLoggerKey = contextKey("request_logger")
+	localizerKey     = contextKey("localizer")
+)
+
+// requestLogger lets handlers further down the chain attach fields (room, player)
+// that end up in the access log line written once the request is done.
+type requestLogger struct {
+	mu     sync.Mutex
+	logger *zap.SugaredLogger
+}
+
+func (l *requestLogger) with(args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger = l.logger.With(args...)
+}
+
+func (l *requestLogger) get() *zap.SugaredLogger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.logger
+}
+
+func requestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey).(string)
+	return requestId
+}
+
+func localizerFromContext(ctx context.Context) *i18n.Localizer {
+	if localizer, ok := ctx.Value(localizerKey).(*i18n.Localizer); ok {
+		return localizer
+	}
+	return i18n.Default()
+}
+
+func loggerFromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(requestLoggerKey).(*requestLogger); ok {
+		return logger.get()
+	}
+	return fallback
+}
+
+func addLogFields(r *http.Request, args ...interface{}) {
+	addContextLogFields(r.Context(), args...)
+}
+
+func addContextLogFields(ctx context.Context, args ...interface{}) {
+	if logger, ok := ctx.Value(requestLoggerKey).(*requestLogger); ok {
+		logger.with(args...)
+	}
+}
+
+// requestMiddleware wraps the whole router so unmatched routes and panics are logged
+// too. It propagates or generates the request id, stores a request-scoped logger and
+// the negotiated language in the context, recovers from panics and writes one access
+// line per request.
+func (app *Application) requestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestId := r.Header.Get(REQUEST_ID_HEADER)
+		if requestId == "" || len(requestId) > 128 {
+			requestId = uuid.NewString()
+		}
+		w.Header().Set(REQUEST_ID_HEADER, requestId)
+
+		logger := &requestLogger{
+			logger: app.logger.With("request_id", requestId, "method", r.Method, "path", r.URL.Path),
+		}
+
+		ctx := context.WithValue(r.Context(), requestIdKey, requestId)
+		ctx = context.WithValue(ctx, requestLoggerKey, logger)
+		ctx = context.WithValue(ctx, localizerKey, i18n.Negotiate(r.Header.Get("Accept-Language")))
+		r = r.WithContext(ctx)
+
+		recorder := utils.NewResponseRecorder(w)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.get().Errorw("panic recovered", "panic", fmt.Sprint(rec), "stack", string(debug.Stack()))
+				if !recorder.WroteHeader {
+					WriteProblem(recorder, r, internalProblem(r))
+				}
+			}
+
+			logger.get().Infow("access",
+				"status", recorder.Status,
+				"bytes", recorder.Bytes,
+				"latency_ms", time.Since(start).Milliseconds(),
+				"remote_addr", r.RemoteAddr,
+				"user_agent", r.UserAgent(),
+			)
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+// routeLogFieldsMiddleware runs inside the router, where the matched route and its
+// variables are known.
+func routeLogFieldsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				addLogFields(r, "route", template)
+			}
+		}
+
+		vars := mux.Vars(r)
+		if roomId, ok := vars["roomId"]; ok {
+			addLogFields(r, "room_id", roomId)
+		}
+		if tournamentId, ok := vars["tournamentId"]; ok {
+			addLogFields(r, "tournament_id", tournamentId)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type openAPIDocument struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+func loadOpenAPIDocument(t *testing.T) *openAPIDocument {
+	t.Helper()
+	document := &openAPIDocument{}
+	if err := json.Unmarshal(openAPISpec, document); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if len(document.Servers) != 1 {
+		t.Fatalf("expected a single server in openapi.json, got %v", len(document.Servers))
+	}
+	return document
+}
+
+// apiOperations returns every "METHOD path" registered by registerAPIRoutes, with
+// paths relative to the server url the way the spec lists them.
+func apiOperations(t *testing.T, base string) map[string]bool {
+	t.Helper()
+	router := mux.NewRouter()
+	subrouter := router.PathPrefix(base).Subrouter()
+	registerAPIRoutes(subrouter, &Controller{logger: zap.NewNop().Sugar()}, nil, nil, nil, true)
+
+	operations := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			// the subrouter itself has no methods
+			return nil
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			operations[method+" "+strings.TrimPrefix(template, base)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error walking routes: %v", err)
+	}
+	if len(operations) == 0 {
+		t.Fatal("no routes were registered")
+	}
+	return operations
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	document := loadOpenAPIDocument(t)
+
+	for operation := range apiOperations(t, document.Servers[0].URL) {
+		method, path, _ := strings.Cut(operation, " ")
+		if _, ok := document.Paths[path][strings.ToLower(method)]; !ok {
+			t.Errorf("%v is registered but missing from openapi.json", operation)
+		}
+	}
+}
+
+func TestOpenAPIHasNoStaleOperations(t *testing.T) {
+	document := loadOpenAPIDocument(t)
+	operations := apiOperations(t, document.Servers[0].URL)
+
+	for path, methods := range document.Paths {
+		for method := range methods {
+			if method == "parameters" {
+				continue
+			}
+			operation := strings.ToUpper(method) + " " + path
+			if !operations[operation] {
+				t.Errorf("%v is documented in openapi.json but not registered", operation)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/ratelimit"
+	"github.com/gorilla/mux"
+)
+
+// rateLimitMiddleware runs inside the router so rules can be matched against the
+// route template. Every matching rule is checked; the most restrictive result is the
+// one reported in the headers. Redis failures let the request through.
+func (app *Application) rateLimitMiddleware(limiter ratelimit.Limiter) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			template, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var reported *ratelimit.Result
+
+			for _, rule := range app.config.RateLimit.Rules {
+				if !rule.Matches(r.Method, template) {
+					continue
+				}
+
+				identity := rule.IdentityOf(r, app.config.RateLimit.ProxyHops())
+				if identity == "" {
+					continue
+				}
+
+				result, err := limiter.Allow(r.Context(), rule.Key(identity), rule.Limit, rule.Window)
+				if err != nil {
+					loggerFromContext(r.Context(), app.logger).Errorw("error checking rate limit", "rule", rule.Name, "error", err.Error())
+					continue
+				}
+
+				if reported == nil || isMoreRestrictive(result, reported) {
+					reported = result
+				}
+
+				if !result.Allowed {
+					addLogFields(r, "rate_limit_rule", rule.Name)
+					break
+				}
+			}
+
+			if reported == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(reported.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(reported.Remaining))
+			w.Header().Set("RateLimit-Reset", fmt.Sprint(ceilSeconds(reported.ResetAfter)))
+
+			if !reported.Allowed {
+				w.Header().Set("Retry-After", fmt.Sprint(ceilSeconds(reported.RetryAfter)))
+				detail := localizerFromContext(r.Context()).Detail(contracts.ErrorCodeRateLimited, ceilSeconds(reported.RetryAfter))
+				WriteProblem(w, r, newProblem(r, http.StatusTooManyRequests, contracts.ErrorCodeRateLimited, detail))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isMoreRestrictive(a, b *ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	return a.Remaining < b.Remaining
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
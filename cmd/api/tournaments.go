@@ -0,0 +1,161 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/gorilla/mux"
+)
+
+type TournamentsController struct {
+	*Controller
+	tournamentsService contracts.ITournamentsService
+}
+
+func newTournamentsController(controller *Controller, tournamentsService contracts.ITournamentsService) *TournamentsController {
+	return &TournamentsController{
+		Controller:         controller,
+		tournamentsService: tournamentsService,
+	}
+}
+
+func (tc *TournamentsController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/tournaments/create", tc.createTournamentHandler).Methods("POST")
+	router.HandleFunc("/tournaments/register/{tournamentId}", tc.registerPlayerHandler).Methods("PUT")
+	router.HandleFunc("/tournaments/start/{tournamentId}", tc.startTournamentHandler).Methods("PUT")
+	router.HandleFunc("/tournaments/bracket/{tournamentId}", tc.getBracketHandler).Methods("GET")
+	router.HandleFunc("/tournaments/standings/{tournamentId}", tc.getStandingsHandler).Methods("GET")
+}
+
+func (tc *TournamentsController) createTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	payload := &contracts.CreateTournamentCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := tc.tournamentsService.CreateTournament(r.Context(), *payload)
+
+	if err != nil {
+		tc.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		tc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (tc *TournamentsController) registerPlayerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentId := vars["tournamentId"]
+
+	if err := validateTournamentId(tournamentId); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload := &contracts.RegisterTournamentPlayerCommand{}
+	if err := utils.ParseJSON(r, payload); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	payload.TournamentId = tournamentId
+
+	res, err := tc.tournamentsService.RegisterPlayer(r.Context(), *payload)
+
+	if err != nil {
+		tc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		tc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (tc *TournamentsController) startTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentId := vars["tournamentId"]
+
+	if err := validateTournamentId(tournamentId); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := tc.tournamentsService.StartTournament(r.Context(), tournamentId)
+
+	if err != nil {
+		tc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusAccepted, res); err != nil {
+		tc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (tc *TournamentsController) getBracketHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentId := vars["tournamentId"]
+
+	if err := validateTournamentId(tournamentId); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := tc.tournamentsService.GetBracket(r.Context(), tournamentId)
+
+	if err != nil {
+		tc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		tc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (tc *TournamentsController) getStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentId := vars["tournamentId"]
+
+	if err := validateTournamentId(tournamentId); err != nil {
+		tc.BadRequestError(w, r, err)
+		return
+	}
+
+	res, err := tc.tournamentsService.GetStandings(r.Context(), tournamentId)
+
+	if err != nil {
+		tc.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, res); err != nil {
+		tc.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func validateTournamentId(tournamentId string) error {
+	return Validate.Struct(struct {
+		TournamentId string `validate:"required,len=7"`
+	}{TournamentId: tournamentId})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/services"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type stubTournamentsService struct {
+	err error
+}
+
+func (s *stubTournamentsService) CreateTournament(ctx context.Context, command contracts.CreateTournamentCommand) (*contracts.CreateTournamentResponse, error) {
+	return stubResponse[contracts.CreateTournamentResponse](s.err)
+}
+
+func (s *stubTournamentsService) RegisterPlayer(ctx context.Context, command contracts.RegisterTournamentPlayerCommand) (*contracts.RegisterTournamentPlayerResponse, error) {
+	return stubResponse[contracts.RegisterTournamentPlayerResponse](s.err)
+}
+
+func (s *stubTournamentsService) StartTournament(ctx context.Context, tournamentId string) (*contracts.TournamentBracketResponse, error) {
+	return stubResponse[contracts.TournamentBracketResponse](s.err)
+}
+
+func (s *stubTournamentsService) GetBracket(ctx context.Context, tournamentId string) (*contracts.TournamentBracketResponse, error) {
+	return stubResponse[contracts.TournamentBracketResponse](s.err)
+}
+
+func (s *stubTournamentsService) GetStandings(ctx context.Context, tournamentId string) (*contracts.TournamentStandingsResponse, error) {
+	return stubResponse[contracts.TournamentStandingsResponse](s.err)
+}
+
+var tournamentsEndpoints = []handlerEndpoint{
+	{"create", "POST", "/tournaments/create", `{"name":"cup","format":"SingleElimination"}`, http.StatusOK, false},
+	{"register", "PUT", "/tournaments/register/abc1234", `{"username":"alice"}`, http.StatusOK, true},
+	{"start", "PUT", "/tournaments/start/abc1234", ``, http.StatusAccepted, true},
+	{"bracket", "GET", "/tournaments/bracket/abc1234", ``, http.StatusOK, true},
+	{"standings", "GET", "/tournaments/standings/abc1234", ``, http.StatusOK, true},
+}
+
+func newTournamentsTestRouter(tournamentsService contracts.ITournamentsService) http.Handler {
+	router := mux.NewRouter()
+	newTournamentsController(&Controller{logger: zap.NewNop().Sugar()}, tournamentsService).RegisterRoutes(router)
+	return router
+}
+
+func TestTournamentsHandlersSuccess(t *testing.T) {
+	assertSuccess(t, newTournamentsTestRouter(&stubTournamentsService{}), tournamentsEndpoints)
+}
+
+func TestTournamentsHandlersErrorMapping(t *testing.T) {
+	assertErrorMappings(t, tournamentsEndpoints, func(err error) http.Handler {
+		return newTournamentsTestRouter(&stubTournamentsService{err: err})
+	}, []errorMapping{
+		{services.ErrTournamentNotFound, http.StatusNotFound, contracts.ErrorCodeTournamentNotFound},
+		{services.ErrRegistrationClosed, http.StatusConflict, contracts.ErrorCodeRegistrationClosed},
+		{services.ErrNotEnoughPlayers, http.StatusConflict, contracts.ErrorCodeNotEnoughPlayers},
+		{services.ErrTournamentAlreadyStarted, http.StatusConflict, contracts.ErrorCodeTournamentAlreadyStarted},
+	})
+}
+
+func TestTournamentsHandlersBadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"short tournament id", "GET", "/tournaments/bracket/abc", ``},
+		{"missing name", "POST", "/tournaments/create", `{"format":"Swiss"}`},
+		{"unknown format", "POST", "/tournaments/create", `{"name":"cup","format":"Ladder"}`},
+		{"too many rounds", "POST", "/tournaments/create", `{"name":"cup","format":"Swiss","rounds":21}`},
+		{"missing username", "PUT", "/tournaments/register/abc1234", `{}`},
+	}
+
+	router := newTournamentsTestRouter(&stubTournamentsService{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, problem := serveAPI(t, router, tt.method, tt.path, tt.body)
+			if rec.Code != http.StatusBadRequest || problem.Code != contracts.ErrorCodeValidationFailed {
+				t.Fatalf("expected 400 %v, got %v %v", contracts.ErrorCodeValidationFailed, rec.Code, problem.Code)
+			}
+		})
+	}
+}
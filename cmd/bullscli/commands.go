@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+// parseFlags parses args and fails with errUsage when a required flag is empty.
+func parseFlags(flags *flag.FlagSet, args []string, required map[string]*string) error {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	for name, value := range required {
+		if *value == "" {
+			return fmt.Errorf("%w: -%v is required", errUsage, name)
+		}
+	}
+	return nil
+}
+
+func parseCombination(value string) (int, error) {
+	combination, err := strconv.Atoi(value)
+	if err != nil || combination < 0 {
+		return 0, fmt.Errorf("%q is not a combination, use four different digits like 1234", value)
+	}
+	return combination, nil
+}
+
+func createCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	username := flags.String("user", "", "your username")
+	mode := flags.String("mode", "", "Turns or Race, the server default when empty")
+	bestOf := flags.Int("best-of", 0, "rounds in the series, the server default when 0")
+	if err := parseFlags(flags, args, map[string]*string{"user": username}); err != nil {
+		return err
+	}
+
+	res, err := c.api.CreateRoom(ctx, contracts.CreateRoomCommand{
+		Username: *username,
+		Mode:     domain.MatchMode(*mode),
+		BestOf:   *bestOf,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	c.print.line("room    %v", c.print.paint(ansiBold, res.RoomId))
+	c.print.line("player  %v", res.Player.Id)
+	c.print.line("mode    %v, best of %v", res.Mode, res.BestOf)
+	return nil
+}
+
+func joinCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("join", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	username := flags.String("user", "", "your username")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId, "user": username}); err != nil {
+		return err
+	}
+
+	res, err := c.api.JoinRoom(ctx, *roomId, *username)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	c.print.line("room    %v", c.print.paint(ansiBold, res.RoomId))
+	c.print.line("player  %v", res.Player.Id)
+	return nil
+}
+
+func secretCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("secret", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	playerId := flags.String("player", "", "your player id")
+	value := flags.String("combination", "", "your secret, four different digits")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId, "player": playerId, "combination": value}); err != nil {
+		return err
+	}
+
+	combination, err := parseCombination(*value)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.api.SetCombination(ctx, *roomId, *playerId, combination)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	c.print.line("secret set")
+	return nil
+}
+
+func startCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("start", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId}); err != nil {
+		return err
+	}
+
+	res, err := c.api.StartGame(ctx, *roomId)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	if res.Mode == domain.MatchModeTurns {
+		c.print.line("match started, %v plays first", res.IsTurnOf)
+	} else {
+		c.print.line("race started")
+	}
+
+	// keep them to check the secrets revealed at the end with verify
+	handles := make([]string, 0, len(res.Commitments))
+	for handle := range res.Commitments {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	for _, handle := range handles {
+		c.print.line("%v %v  %v", c.print.paint(ansiDim, "commitment"), handle, res.Commitments[handle])
+	}
+	return nil
+}
+
+func guessCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("guess", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	playerId := flags.String("player", "", "your player id")
+	value := flags.String("guess", "", "four different digits")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId, "player": playerId, "guess": value}); err != nil {
+		return err
+	}
+
+	guess, err := parseCombination(*value)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.api.MakeGuess(ctx, *roomId, *playerId, guess)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	c.printGuessResult(res, *playerId)
+	return nil
+}
+
+func (c *cli) printGuessResult(res *contracts.MakeGuessResponse, playerId string) {
+	if item, ok := lastGuess(res.Guesses, domain.PlayerHandle(playerId)); ok {
+		c.print.line("%v", c.print.guess(item))
+	}
+	switch {
+	case res.IsWinner:
+		c.print.line(c.print.paint(ansiBold+ansiGreen, "you cracked it!"))
+	case res.Winner != "":
+		c.print.line(c.print.paint(ansiRed, "your opponent won this round"))
+	}
+}
+
+func stateCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("state", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId}); err != nil {
+		return err
+	}
+
+	res, err := c.api.GetMatch(ctx, *roomId)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	c.print.match(res)
+	return nil
+}
+
+func watchCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId}); err != nil {
+		return err
+	}
+
+	return c.api.WatchMatch(ctx, *roomId, func(event contracts.MatchEvent) bool {
+		if c.print.json {
+			c.print.writeJSON(event)
+		} else {
+			c.print.event(event)
+			c.print.line("")
+		}
+		return true
+	})
+}
+
+func chatCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("chat", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	playerId := flags.String("player", "", "your player id, required to send")
+	message := flags.String("message", "", "message to send; the history is printed when empty")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId}); err != nil {
+		return err
+	}
+
+	if *message != "" {
+		if *playerId == "" {
+			return fmt.Errorf("%w: -player is required to send a message", errUsage)
+		}
+		res, err := c.api.SendChatMessage(ctx, *roomId, *playerId, *message)
+		if err != nil {
+			return err
+		}
+		if c.print.json {
+			c.print.writeJSON(res)
+		}
+		return nil
+	}
+
+	res, err := c.api.GetChat(ctx, *roomId)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	for _, message := range res.Messages {
+		c.print.line("%v %v %v", c.print.paint(ansiDim, message.SentAt.Local().Format("15:04")), c.print.paint(ansiBold, message.Username+":"), message.Message)
+	}
+	return nil
+}
+
+// verifyCommand checks revealed secrets on this machine, it never asks the server
+// whether its own commitments hold.
+func verifyCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	roomId := flags.String("room", "", "finished room whose revealed secrets are checked")
+	commitment := flags.String("commitment", "", "commitment published at the start")
+	secret := flags.String("secret", "", "revealed secret")
+	salt := flags.String("salt", "", "revealed salt")
+	if err := parseFlags(flags, args, nil); err != nil {
+		return err
+	}
+
+	if *roomId == "" {
+		if *commitment == "" || *secret == "" || *salt == "" {
+			return fmt.Errorf("%w: use -room or all of -commitment, -secret and -salt", errUsage)
+		}
+		valid := client.CheckCommitment(*commitment, *secret, *salt)
+		if c.print.json {
+			c.print.writeJSON(contracts.VerifyCommitmentResponse{Valid: valid})
+		} else {
+			c.print.line("%v  %v", *secret, c.print.verified(valid))
+		}
+		if !valid {
+			return errCommitmentMismatch
+		}
+		return nil
+	}
+
+	match, err := c.api.GetMatch(ctx, *roomId)
+	if err != nil {
+		return err
+	}
+
+	results := client.CheckMatchCommitments(match, nil)
+	if len(results) == 0 {
+		return fmt.Errorf("room %v has no revealed secrets yet, they are revealed once the match is finished", *roomId)
+	}
+
+	if c.print.json {
+		c.print.writeJSON(results)
+	}
+
+	mismatch := false
+	for _, player := range match.Players {
+		valid, revealed := results[player.Handle]
+		if !revealed {
+			continue
+		}
+		mismatch = mismatch || !valid
+		if !c.print.json {
+			c.print.line("%v  %v  %v", c.print.paint(ansiBold, player.Username), match.Commitments[player.Handle].Secret, c.print.verified(valid))
+		}
+	}
+	if mismatch {
+		return errCommitmentMismatch
+	}
+	return nil
+}
+
+func rematchCommand(ctx context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing offer, accept or decline", errUsage)
+	}
+	action := args[0]
+	if action != "offer" && action != "accept" && action != "decline" {
+		return fmt.Errorf("%w: unknown rematch action %q", errUsage, action)
+	}
+
+	flags := flag.NewFlagSet("rematch", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	playerId := flags.String("player", "", "your player id")
+	if err := parseFlags(flags, args[1:], map[string]*string{"room": roomId, "player": playerId}); err != nil {
+		return err
+	}
+
+	res, err := c.api.Rematch(ctx, action, *roomId, *playerId)
+	if err != nil {
+		return err
+	}
+
+	if c.print.json {
+		c.print.writeJSON(res)
+		return nil
+	}
+	c.print.line("rematch %v: done", action)
+	return nil
+}
@@ -0,0 +1,122 @@
+// Command bullscli plays and scripts Bulls and Cows matches against the REST API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+)
+
+const DEFAULT_API_URL = "http://localhost:3000/api/v1"
+
+var (
+	errUsage              = errors.New("invalid arguments")
+	errCommitmentMismatch = errors.New("a revealed secret does not match its commitment")
+)
+
+type cli struct {
+	api      *client.Client
+	print    *printer
+	language string
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"create":  {"create -user NAME [-mode Turns|Race] [-best-of 1|3|5|7]", createCommand},
+	"join":    {"join -room ID -user NAME", joinCommand},
+	"secret":  {"secret -room ID -player ID -combination 1234", secretCommand},
+	"start":   {"start -room ID", startCommand},
+	"guess":   {"guess -room ID -player ID -guess 1234", guessCommand},
+	"play":    {"play -room ID -player ID", playCommand},
+	"state":   {"state -room ID", stateCommand},
+	"watch":   {"watch -room ID", watchCommand},
+	"rematch": {"rematch offer|accept|decline -room ID -player ID", rematchCommand},
+	"chat":    {"chat -room ID [-player ID -message TEXT]", chatCommand},
+	"tui":     {"tui [-offline]", tuiCommand},
+	"verify":  {"verify -room ID | -commitment HASH -secret 1234 -salt HEX", verifyCommand},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	global := flag.NewFlagSet("bullscli", flag.ContinueOnError)
+	apiURL := global.String("api", envOr("BULLS_API_URL", DEFAULT_API_URL), "base URL of the API")
+	jsonOutput := global.Bool("json", false, "print one JSON document per result, for scripts")
+	noColor := global.Bool("no-color", false, "disable colored output")
+	language := global.String("lang", os.Getenv("BULLS_LANG"), "preferred language for error messages, e.g. es")
+	global.Usage = func() { usage(global) }
+
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if global.NArg() == 0 {
+		usage(global)
+		return 2
+	}
+
+	name := global.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage(global)
+		return 2
+	}
+
+	c := &cli{
+		api:      client.New(*apiURL, client.WithLanguage(*language)),
+		print:    newPrinter(*jsonOutput, *noColor),
+		language: *language,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := cmd.run(ctx, c, global.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			fmt.Fprintf(os.Stderr, "usage: bullscli [flags] %v\n", cmd.usage)
+			return 2
+		}
+		c.print.error(err)
+		return 1
+	}
+	return 0
+}
+
+func usage(global *flag.FlagSet) {
+	fmt.Fprintln(os.Stderr, "usage: bullscli [flags] <command> [command flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %v\n", commands[name].usage)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nflags:")
+	global.PrintDefaults()
+}
+
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+var errMatchClosed = errors.New("the match is no longer available")
+
+// playCommand is the interactive loop: it waits for the match to start and, in
+// Turns mode, for the player's turn, then reads guesses from stdin.
+func playCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("play", flag.ContinueOnError)
+	roomId := flags.String("room", "", "room id")
+	playerId := flags.String("player", "", "your player id")
+	if err := parseFlags(flags, args, map[string]*string{"room": roomId, "player": playerId}); err != nil {
+		return err
+	}
+
+	match, err := c.api.GetMatch(ctx, *roomId)
+	if err != nil {
+		return err
+	}
+	if !c.print.json {
+		c.print.match(match)
+		c.print.line("type a guess, \"board\" to show the match or \"quit\" to leave")
+	}
+
+	input := bufio.NewScanner(os.Stdin)
+	for {
+		if match.Status == domain.MatchStateFinished {
+			return nil
+		}
+
+		if match.Status != domain.MatchStatePlaying || !canGuess(match, *playerId) {
+			if !c.print.json {
+				c.print.line(c.print.paint(ansiDim, waitingMessage(match)))
+			}
+			match, err = c.waitFor(ctx, *roomId, func(match *contracts.MatchStateResponse) bool {
+				return match.Status == domain.MatchStateFinished ||
+					(match.Status == domain.MatchStatePlaying && canGuess(match, *playerId))
+			})
+			if err != nil {
+				return err
+			}
+			if !c.print.json && match.Status == domain.MatchStateFinished {
+				c.print.match(match)
+			}
+			continue
+		}
+
+		if !c.print.json {
+			fmt.Fprint(c.print.out, c.print.paint(ansiBold, "guess> "))
+		}
+		if !input.Scan() {
+			return input.Err()
+		}
+
+		line := strings.TrimSpace(input.Text())
+		switch line {
+		case "":
+			continue
+		case "quit", "q", "exit":
+			return nil
+		case "board", "b":
+			if match, err = c.api.GetMatch(ctx, *roomId); err != nil {
+				return err
+			}
+			c.print.match(match)
+			continue
+		}
+
+		guess, err := parseCombination(line)
+		if err != nil {
+			c.print.error(err)
+			continue
+		}
+
+		res, err := c.api.MakeGuess(ctx, *roomId, *playerId, guess)
+		if err != nil {
+			var apiErr *client.Error
+			if !errors.As(err, &apiErr) {
+				return err
+			}
+			c.print.error(err)
+		} else if c.print.json {
+			c.print.writeJSON(res)
+		} else {
+			c.printGuessResult(res, *playerId)
+		}
+
+		if match, err = c.api.GetMatch(ctx, *roomId); err != nil {
+			return err
+		}
+	}
+}
+
+// canGuess reports whether the player may guess now; in Race mode a player that
+// already solved the combination waits for the opponent.
+func canGuess(match *contracts.MatchStateResponse, playerId string) bool {
+	handle := domain.PlayerHandle(playerId)
+	if match.Mode == domain.MatchModeTurns {
+		return match.IsTurnOf == handle
+	}
+	item, ok := lastGuess(match.Guesses, handle)
+	return !ok || !item.IsWinnerCombination
+}
+
+func waitingMessage(match *contracts.MatchStateResponse) string {
+	switch match.Status {
+	case domain.MatchStateWaiting:
+		return "waiting for an opponent to join..."
+	case domain.MatchStateFullRoom:
+		return "waiting for both secrets and the start of the match..."
+	}
+	return "waiting for your opponent..."
+}
+
+func (c *cli) waitFor(ctx context.Context, roomId string, done func(match *contracts.MatchStateResponse) bool) (*contracts.MatchStateResponse, error) {
+	var result *contracts.MatchStateResponse
+	err := c.api.WatchMatch(ctx, roomId, func(event contracts.MatchEvent) bool {
+		if event.Match == nil {
+			return false
+		}
+		if event.Type == contracts.MatchEventGuessMade && !c.print.json {
+			c.print.line(c.print.paint(ansiDim, "your opponent made a guess"))
+		}
+		if done(event.Match) {
+			result = event.Match
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errMatchClosed
+	}
+	return result, nil
+}
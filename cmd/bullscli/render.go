@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+)
+
+// printer writes either human readable, optionally colored, text or one JSON
+// document per result for scripts.
+type printer struct {
+	out   io.Writer
+	err   io.Writer
+	json  bool
+	color bool
+}
+
+func newPrinter(jsonOutput bool, noColor bool) *printer {
+	return &printer{
+		out:   os.Stdout,
+		err:   os.Stderr,
+		json:  jsonOutput,
+		color: !jsonOutput && !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout),
+	}
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (p *printer) paint(color string, text string) string {
+	if !p.color {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+func (p *printer) writeJSON(v any) {
+	json.NewEncoder(p.out).Encode(v)
+}
+
+func (p *printer) line(format string, args ...any) {
+	fmt.Fprintf(p.out, format+"\n", args...)
+}
+
+func (p *printer) error(err error) {
+	if p.json {
+		problem := contracts.ProblemResponse{Title: err.Error()}
+		var apiErr *client.Error
+		if errors.As(err, &apiErr) {
+			problem = apiErr.ProblemResponse
+		}
+		json.NewEncoder(p.err).Encode(problem)
+		return
+	}
+	fmt.Fprintln(p.err, p.paint(ansiRed, "error: "+err.Error()))
+}
+
+// guess renders each digit green for a bull, yellow for a cow and dim otherwise,
+// followed by the totals.
+func (p *printer) guess(item domain.GuessesHistoryItem) string {
+	var digits strings.Builder
+	bulls, cows := 0, 0
+	for _, digit := range item.Guess {
+		switch digit.Type {
+		case domain.Bull:
+			bulls++
+			digits.WriteString(p.paint(ansiBold+ansiGreen, digit.Value))
+		case domain.Cow:
+			cows++
+			digits.WriteString(p.paint(ansiYellow, digit.Value))
+		default:
+			digits.WriteString(p.paint(ansiDim, digit.Value))
+		}
+	}
+	return fmt.Sprintf("%v  %v %v", digits.String(), p.paint(ansiGreen, fmt.Sprintf("%dB", bulls)), p.paint(ansiYellow, fmt.Sprintf("%dC", cows)))
+}
+
+func (p *printer) match(match *contracts.MatchStateResponse) {
+	p.line("%v %v  mode %v  status %v", p.paint(ansiBold, "room"), match.RoomId, match.Mode, match.Status)
+	if match.Series.BestOf > 1 {
+		p.line("series best of %v, round %v", match.Series.BestOf, len(match.Series.Rounds)+1)
+	}
+
+	names := playerNames(match.Players)
+	for _, player := range match.Players {
+		marker := " "
+		if match.Status == domain.MatchStatePlaying && match.Mode == domain.MatchModeTurns && match.IsTurnOf == player.Handle {
+			marker = p.paint(ansiGreen, ">")
+		}
+		score := ""
+		if match.Series.BestOf > 1 {
+			score = fmt.Sprintf("  wins %v", match.Series.Score[player.Handle])
+		}
+		p.line("%v %v %v%v", marker, p.paint(ansiBold, player.Username), p.paint(ansiDim, player.Handle), score)
+		for index, item := range match.Guesses[player.Handle] {
+			p.line("    %2d. %v", index+1, p.guess(item))
+		}
+	}
+
+	if match.Winner != "" {
+		p.line("%v %v", p.paint(ansiBold+ansiGreen, "winner"), names[match.Winner])
+	}
+	if match.Series.BestOf > 1 && match.Series.Winner != "" {
+		p.line("%v %v", p.paint(ansiBold+ansiGreen, "series winner"), names[match.Series.Winner])
+	}
+	if match.Rematch != nil {
+		p.line("rematch offered by %v until %v", names[match.Rematch.OfferedBy], match.Rematch.ExpiresAt.Local().Format("15:04:05"))
+	}
+}
+
+func (p *printer) event(event contracts.MatchEvent) {
+	p.line("%v %v", p.paint(ansiDim, event.At.Local().Format("15:04:05")), p.paint(ansiBold, string(event.Type)))
+	if event.Match != nil {
+		p.match(event.Match)
+	}
+}
+
+func (p *printer) verified(valid bool) string {
+	if valid {
+		return p.paint(ansiGreen, "matches its commitment")
+	}
+	return p.paint(ansiBold+ansiRed, "DOES NOT match its commitment")
+}
+
+func playerNames(players []contracts.PlayerResponse) map[string]string {
+	names := make(map[string]string, len(players))
+	for _, player := range players {
+		names[player.Handle] = player.Username
+	}
+	return names
+}
+
+func lastGuess(guesses domain.MatchGuesses, handle string) (domain.GuessesHistoryItem, bool) {
+	items := guesses[handle]
+	if len(items) == 0 {
+		return domain.GuessesHistoryItem{}, false
+	}
+	return items[len(items)-1], true
+}
@@ -0,0 +1,688 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/embedded"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+const TUI_CHAT_POLL_INTERVAL = 2 * time.Second
+
+type tuiScreen int
+
+const (
+	screenLobby tuiScreen = iota
+	screenSecret
+	screenBoard
+)
+
+// tuiSeat is a player sitting at this terminal; hot-seat matches have two.
+type tuiSeat struct {
+	username  string
+	playerId  string
+	secretSet bool
+}
+
+// lobbyField is either a text input or, when choices is set, a selector moved
+// with the arrow keys.
+type lobbyField struct {
+	label   string
+	input   textinput.Model
+	choices []string
+	choice  int
+}
+
+func (f *lobbyField) value() string {
+	if f.choices != nil {
+		return f.choices[f.choice]
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+type (
+	tickMsg        time.Time
+	chatPollMsg    struct{}
+	eventMsg       contracts.MatchEvent
+	streamEndedMsg struct{ err error }
+	chatMsg        []contracts.ChatMessageResponse
+	secretSetMsg   struct{ seat int }
+	guessMsg       struct{}
+	rematchMsg     struct{ action string }
+	errMsg         struct{ err error }
+	roomReadyMsg   struct {
+		roomId string
+		seats  []*tuiSeat
+	}
+)
+
+type tuiModel struct {
+	ctx     context.Context
+	api     *client.Client
+	hotSeat bool
+	screen  tuiScreen
+
+	action   *lobbyField
+	username *lobbyField
+	opponent *lobbyField
+	room     *lobbyField
+	mode     *lobbyField
+	bestOf   *lobbyField
+	focus    int
+
+	roomId string
+	seats  []*tuiSeat
+	active int
+	match  *contracts.MatchStateResponse
+	events chan tea.Msg
+	closed bool
+
+	input       textinput.Model
+	chatInput   textinput.Model
+	chatFocused bool
+	chat        []contracts.ChatMessageResponse
+
+	startedAt     time.Time
+	finishedAt    time.Time
+	turnOf        string
+	turnStartedAt time.Time
+	now           time.Time
+
+	notice string
+	err    string
+	busy   bool
+}
+
+// tuiCommand runs the full-screen client. With -offline the API runs in process
+// and both players share the keyboard.
+func tuiCommand(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("tui", flag.ContinueOnError)
+	offline := flags.Bool("offline", false, "play hot-seat against an embedded in-memory server")
+	if err := parseFlags(flags, args, nil); err != nil {
+		return err
+	}
+
+	if c.print.json {
+		return errors.New("the tui does not support -json output")
+	}
+	if !c.print.color {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	api := c.api
+	if *offline {
+		server, err := embedded.Start()
+		if err != nil {
+			return err
+		}
+		defer server.Close()
+		api = client.New(server.URL, client.WithLanguage(c.language))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	model := newTUIModel(ctx, api, *offline)
+	_, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func newTUIModel(ctx context.Context, api *client.Client, hotSeat bool) *tuiModel {
+	m := &tuiModel{
+		ctx:       ctx,
+		api:       api,
+		hotSeat:   hotSeat,
+		action:    &lobbyField{label: "Action", choices: []string{"Create", "Join"}},
+		username:  newTextField("Username", 20),
+		opponent:  newTextField("Player 2", 20),
+		room:      newTextField("Room", 16),
+		mode:      &lobbyField{label: "Mode", choices: []string{string(domain.MatchModeTurns), string(domain.MatchModeRace)}},
+		bestOf:    &lobbyField{label: "Best of", choices: []string{"1", "3", "5", "7"}},
+		input:     textinput.New(),
+		chatInput: textinput.New(),
+		now:       time.Now(),
+	}
+	if hotSeat {
+		m.username.label = "Player 1"
+	}
+	m.input.Prompt = ""
+	m.input.CharLimit = 4
+	m.input.Width = 6
+	m.chatInput.Prompt = ""
+	m.chatInput.CharLimit = 280
+	m.chatInput.Placeholder = "say something"
+	m.focusLobby()
+	return m
+}
+
+func newTextField(label string, limit int) *lobbyField {
+	input := textinput.New()
+	input.Prompt = ""
+	input.CharLimit = limit
+	input.Width = limit
+	return &lobbyField{label: label, input: input}
+}
+
+// lobbyFields returns the fields that apply to the current selection, in order.
+func (m *tuiModel) lobbyFields() []*lobbyField {
+	if m.hotSeat {
+		return []*lobbyField{m.username, m.opponent, m.mode, m.bestOf}
+	}
+	if m.action.value() == "Join" {
+		return []*lobbyField{m.action, m.username, m.room}
+	}
+	return []*lobbyField{m.action, m.username, m.mode, m.bestOf}
+}
+
+func (m *tuiModel) focusLobby() {
+	for index, field := range m.lobbyFields() {
+		if field.choices != nil {
+			continue
+		}
+		if index == m.focus {
+			field.input.Focus()
+		} else {
+			field.input.Blur()
+		}
+	}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, tick())
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func pollChat() tea.Cmd {
+	return tea.Tick(TUI_CHAT_POLL_INTERVAL, func(time.Time) tea.Msg { return chatPollMsg{} })
+}
+
+func waitForEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-events }
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEsc:
+			if m.screen == screenLobby || m.closed {
+				return m, tea.Quit
+			}
+		}
+		switch m.screen {
+		case screenLobby:
+			return m, m.updateLobby(msg)
+		default:
+			return m, m.updateRoom(msg)
+		}
+
+	case tickMsg:
+		m.now = time.Time(msg)
+		return m, tick()
+
+	case roomReadyMsg:
+		m.busy = false
+		m.roomId = msg.roomId
+		m.seats = msg.seats
+		m.err = ""
+		m.enterSecret()
+		return m, tea.Batch(m.watch(), m.fetchChat(), pollChat())
+
+	case eventMsg:
+		m.applyEvent(contracts.MatchEvent(msg))
+		return m, tea.Batch(waitForEvent(m.events), m.autoStart())
+
+	case streamEndedMsg:
+		m.closed = true
+		m.notice = "the match is no longer available, press esc to leave"
+		if msg.err != nil {
+			m.err = msg.err.Error()
+		}
+		return m, nil
+
+	case chatPollMsg:
+		if m.closed {
+			return m, nil
+		}
+		return m, tea.Batch(m.fetchChat(), pollChat())
+
+	case chatMsg:
+		m.chat = msg
+		return m, nil
+
+	case secretSetMsg:
+		m.busy = false
+		m.seats[msg.seat].secretSet = true
+		m.enterSecret()
+		return m, m.autoStart()
+
+	case guessMsg:
+		m.busy = false
+		return m, nil
+
+	case rematchMsg:
+		m.busy = false
+		m.notice = "rematch " + msg.action + "ed"
+		return m, nil
+
+	case errMsg:
+		m.busy = false
+		m.err = msg.err.Error()
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m *tuiModel) updateLobby(msg tea.KeyMsg) tea.Cmd {
+	fields := m.lobbyFields()
+	field := fields[m.focus]
+
+	switch msg.Type {
+	case tea.KeyTab, tea.KeyDown:
+		m.focus = (m.focus + 1) % len(fields)
+		m.focusLobby()
+		return nil
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.focus = (m.focus + len(fields) - 1) % len(fields)
+		m.focusLobby()
+		return nil
+	case tea.KeyLeft, tea.KeyRight:
+		if field.choices != nil {
+			step := 1
+			if msg.Type == tea.KeyLeft {
+				step = len(field.choices) - 1
+			}
+			field.choice = (field.choice + step) % len(field.choices)
+			return nil
+		}
+	case tea.KeyEnter:
+		if m.busy {
+			return nil
+		}
+		return m.submitLobby()
+	}
+
+	if field.choices != nil {
+		return nil
+	}
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	return cmd
+}
+
+func (m *tuiModel) submitLobby() tea.Cmd {
+	for _, field := range m.lobbyFields() {
+		if field.value() == "" {
+			m.err = strings.ToLower(field.label) + " is required"
+			return nil
+		}
+	}
+
+	m.err = ""
+	m.busy = true
+	bestOf, _ := strconv.Atoi(m.bestOf.value())
+	create := contracts.CreateRoomCommand{
+		Username: m.username.value(),
+		Mode:     domain.MatchMode(m.mode.value()),
+		BestOf:   bestOf,
+	}
+
+	if m.hotSeat {
+		opponent := m.opponent.value()
+		return func() tea.Msg {
+			room, err := m.api.CreateRoom(m.ctx, create)
+			if err != nil {
+				return errMsg{err}
+			}
+			joined, err := m.api.JoinRoom(m.ctx, room.RoomId, opponent)
+			if err != nil {
+				return errMsg{err}
+			}
+			return roomReadyMsg{roomId: room.RoomId, seats: []*tuiSeat{
+				{username: room.Player.Username, playerId: room.Player.Id},
+				{username: joined.Player.Username, playerId: joined.Player.Id},
+			}}
+		}
+	}
+
+	if m.action.value() == "Join" {
+		roomId, username := m.room.value(), m.username.value()
+		return func() tea.Msg {
+			joined, err := m.api.JoinRoom(m.ctx, roomId, username)
+			if err != nil {
+				return errMsg{err}
+			}
+			return roomReadyMsg{roomId: joined.RoomId, seats: []*tuiSeat{
+				{username: joined.Player.Username, playerId: joined.Player.Id},
+			}}
+		}
+	}
+
+	return func() tea.Msg {
+		room, err := m.api.CreateRoom(m.ctx, create)
+		if err != nil {
+			return errMsg{err}
+		}
+		return roomReadyMsg{roomId: room.RoomId, seats: []*tuiSeat{
+			{username: room.Player.Username, playerId: room.Player.Id},
+		}}
+	}
+}
+
+// watch streams the room events into the program through m.events; every
+// eventMsg re-arms waitForEvent so they are delivered one at a time.
+func (m *tuiModel) watch() tea.Cmd {
+	events := make(chan tea.Msg)
+	m.events = events
+	roomId := m.roomId
+
+	go func() {
+		err := m.api.WatchMatch(m.ctx, roomId, func(event contracts.MatchEvent) bool {
+			select {
+			case events <- eventMsg(event):
+				return true
+			case <-m.ctx.Done():
+				return false
+			}
+		})
+		select {
+		case events <- streamEndedMsg{err}:
+		case <-m.ctx.Done():
+		}
+	}()
+
+	return waitForEvent(events)
+}
+
+func (m *tuiModel) fetchChat() tea.Cmd {
+	roomId := m.roomId
+	return func() tea.Msg {
+		res, err := m.api.GetChat(m.ctx, roomId)
+		if err != nil {
+			return errMsg{err}
+		}
+		return chatMsg(res.Messages)
+	}
+}
+
+func (m *tuiModel) applyEvent(event contracts.MatchEvent) {
+	if event.Match == nil {
+		return
+	}
+
+	previous := m.match
+	m.match = event.Match
+
+	switch event.Match.Status {
+	case domain.MatchStatePlaying:
+		if previous == nil || previous.Status != domain.MatchStatePlaying {
+			m.startedAt = event.At
+			m.finishedAt = time.Time{}
+			m.notice = ""
+			m.err = ""
+		}
+		if m.turnOf != event.Match.IsTurnOf || m.turnStartedAt.IsZero() {
+			m.turnOf = event.Match.IsTurnOf
+			m.turnStartedAt = event.At
+		}
+		if m.screen != screenBoard {
+			m.enterBoard()
+		}
+		if m.hotSeat && event.Match.Mode == domain.MatchModeTurns {
+			m.active = m.seatOf(event.Match.IsTurnOf)
+		}
+	case domain.MatchStateFinished:
+		if m.finishedAt.IsZero() {
+			m.finishedAt = event.At
+		}
+		if m.screen != screenBoard {
+			m.enterBoard()
+		}
+	case domain.MatchStateFullRoom:
+		// a rematch puts the room back in FullRoom with the secrets cleared
+		if previous != nil && previous.Status == domain.MatchStateFinished {
+			for _, seat := range m.seats {
+				seat.secretSet = false
+			}
+			m.notice = "rematch accepted, choose a new secret"
+			m.enterSecret()
+		}
+	}
+
+	switch event.Type {
+	case contracts.MatchEventRematchOffered:
+		if event.Match.Rematch != nil && m.seatOf(event.Match.Rematch.OfferedBy) < 0 {
+			m.notice = "your opponent offers a rematch, press ctrl+r to accept"
+		}
+	case contracts.MatchEventRematchCancelled:
+		m.notice = "the rematch offer was withdrawn"
+	}
+}
+
+// seatOf finds the seat of a player named by its handle, as the match state does.
+func (m *tuiModel) seatOf(handle string) int {
+	for index, seat := range m.seats {
+		if domain.PlayerHandle(seat.playerId) == handle {
+			return index
+		}
+	}
+	return -1
+}
+
+// enterSecret asks the next local player without a secret for one.
+func (m *tuiModel) enterSecret() {
+	m.screen = screenSecret
+	m.chatFocused = false
+	m.chatInput.Blur()
+	m.input.Reset()
+	m.input.EchoMode = textinput.EchoPassword
+	m.input.Placeholder = ""
+	m.input.Focus()
+	for index, seat := range m.seats {
+		if !seat.secretSet {
+			m.active = index
+			return
+		}
+	}
+	m.input.Blur()
+}
+
+func (m *tuiModel) enterBoard() {
+	m.screen = screenBoard
+	m.input.Reset()
+	m.input.EchoMode = textinput.EchoNormal
+	m.input.Placeholder = "guess"
+	if !m.chatFocused {
+		m.input.Focus()
+	}
+	if m.active >= len(m.seats) || m.active < 0 {
+		m.active = 0
+	}
+}
+
+func (m *tuiModel) secretsReady() bool {
+	for _, seat := range m.seats {
+		if !seat.secretSet {
+			return false
+		}
+	}
+	return len(m.seats) > 0
+}
+
+// autoStart starts the match once every local secret is set; the call is
+// expected to fail while the opponent has not joined or chosen a secret yet.
+func (m *tuiModel) autoStart() tea.Cmd {
+	if m.screen != screenSecret || !m.secretsReady() || m.match == nil || m.match.Status != domain.MatchStateFullRoom {
+		return nil
+	}
+	roomId := m.roomId
+	return func() tea.Msg {
+		_, err := m.api.StartGame(m.ctx, roomId)
+		var apiErr *client.Error
+		if err != nil && !errors.As(err, &apiErr) {
+			return errMsg{err}
+		}
+		return nil
+	}
+}
+
+func (m *tuiModel) updateRoom(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyTab:
+		m.chatFocused = !m.chatFocused
+		if m.chatFocused {
+			m.input.Blur()
+			return m.chatInput.Focus()
+		}
+		m.chatInput.Blur()
+		return m.input.Focus()
+	case tea.KeyCtrlT:
+		if m.hotSeat && m.match != nil && m.match.Mode == domain.MatchModeRace && m.screen == screenBoard {
+			m.active = (m.active + 1) % len(m.seats)
+		}
+		return nil
+	case tea.KeyCtrlR:
+		return m.rematch()
+	case tea.KeyEnter:
+		if m.chatFocused {
+			return m.sendChat()
+		}
+		if m.busy || m.closed {
+			return nil
+		}
+		if m.screen == screenSecret {
+			return m.submitSecret()
+		}
+		return m.submitGuess()
+	}
+
+	var cmd tea.Cmd
+	if m.chatFocused {
+		m.chatInput, cmd = m.chatInput.Update(msg)
+	} else {
+		m.input, cmd = m.input.Update(msg)
+	}
+	return cmd
+}
+
+func (m *tuiModel) submitSecret() tea.Cmd {
+	if m.secretsReady() {
+		return nil
+	}
+	combination, err := parseCombination(m.input.Value())
+	if err != nil {
+		m.err = err.Error()
+		return nil
+	}
+
+	m.err = ""
+	m.busy = true
+	m.input.Reset()
+	seat, roomId := m.active, m.roomId
+	playerId := m.seats[seat].playerId
+	return func() tea.Msg {
+		if _, err := m.api.SetCombination(m.ctx, roomId, playerId, combination); err != nil {
+			return errMsg{err}
+		}
+		return secretSetMsg{seat}
+	}
+}
+
+func (m *tuiModel) submitGuess() tea.Cmd {
+	if m.match == nil || m.match.Status != domain.MatchStatePlaying {
+		return nil
+	}
+	guess, err := parseCombination(m.input.Value())
+	if err != nil {
+		m.err = err.Error()
+		return nil
+	}
+
+	m.err = ""
+	m.busy = true
+	m.input.Reset()
+	roomId, playerId := m.roomId, m.seats[m.active].playerId
+	return func() tea.Msg {
+		if _, err := m.api.MakeGuess(m.ctx, roomId, playerId, guess); err != nil {
+			return errMsg{err}
+		}
+		return guessMsg{}
+	}
+}
+
+func (m *tuiModel) sendChat() tea.Cmd {
+	message := strings.TrimSpace(m.chatInput.Value())
+	if message == "" || m.closed {
+		return nil
+	}
+
+	m.chatInput.Reset()
+	roomId, playerId := m.roomId, m.seats[m.active].playerId
+	return func() tea.Msg {
+		if _, err := m.api.SendChatMessage(m.ctx, roomId, playerId, message); err != nil {
+			return errMsg{err}
+		}
+		res, err := m.api.GetChat(m.ctx, roomId)
+		if err != nil {
+			return errMsg{err}
+		}
+		return chatMsg(res.Messages)
+	}
+}
+
+// rematch offers a rematch or accepts the opponent's offer; at a hot-seat both
+// happen at once.
+func (m *tuiModel) rematch() tea.Cmd {
+	if m.busy || m.closed || m.match == nil || m.match.Status != domain.MatchStateFinished {
+		return nil
+	}
+
+	roomId := m.roomId
+	offer := m.match.Rematch
+	if m.hotSeat {
+		first, second := m.seats[0].playerId, m.seats[1].playerId
+		m.busy = true
+		return func() tea.Msg {
+			if _, err := m.api.Rematch(m.ctx, client.RematchOffer, roomId, first); err != nil {
+				return errMsg{err}
+			}
+			if _, err := m.api.Rematch(m.ctx, client.RematchAccept, roomId, second); err != nil {
+				return errMsg{err}
+			}
+			return rematchMsg{"accept"}
+		}
+	}
+
+	action := "offer"
+	if offer != nil && offer.OfferedBy != domain.PlayerHandle(m.seats[0].playerId) {
+		action = "accept"
+	}
+	playerId := m.seats[0].playerId
+	m.busy = true
+	return func() tea.Msg {
+		if _, err := m.api.Rematch(m.ctx, action, roomId, playerId); err != nil {
+			return errMsg{err}
+		}
+		return rematchMsg{action}
+	}
+}
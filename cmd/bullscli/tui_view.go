@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	TUI_COLUMN_WIDTH = 26
+	TUI_CHAT_WIDTH   = 44
+	TUI_CHAT_LINES   = 14
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	labelStyle   = lipgloss.NewStyle().Width(10)
+	focusStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	dimStyle     = lipgloss.NewStyle().Faint(true)
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	noticeStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	bullStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	cowStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	winnerStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	panelStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	activeBorder = lipgloss.NewStyle().BorderForeground(lipgloss.Color("10"))
+)
+
+func (m *tuiModel) View() string {
+	var body string
+	switch m.screen {
+	case screenLobby:
+		body = m.lobbyView()
+	case screenSecret:
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.secretView(), " ", m.chatView())
+	default:
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.boardView(), " ", m.chatView())
+	}
+
+	var footer []string
+	if m.notice != "" {
+		footer = append(footer, noticeStyle.Render(m.notice))
+	}
+	if m.err != "" {
+		footer = append(footer, errorStyle.Render(m.err))
+	}
+	footer = append(footer, dimStyle.Render(m.helpLine()))
+
+	return lipgloss.JoinVertical(lipgloss.Left, m.header(), "", body, "", strings.Join(footer, "\n"))
+}
+
+func (m *tuiModel) header() string {
+	title := titleStyle.Render("Bulls & Cows")
+	if m.hotSeat {
+		title += dimStyle.Render("  offline hot-seat")
+	}
+	if m.roomId != "" {
+		title += "  room " + lipgloss.NewStyle().Bold(true).Render(m.roomId)
+	}
+	if m.match != nil {
+		title += dimStyle.Render(fmt.Sprintf("  %v · %v", m.match.Mode, m.match.Status))
+		if m.match.Series.BestOf > 1 {
+			title += dimStyle.Render(fmt.Sprintf(" · best of %v, round %v", m.match.Series.BestOf, len(m.match.Series.Rounds)+1))
+		}
+	}
+	return title
+}
+
+func (m *tuiModel) helpLine() string {
+	switch m.screen {
+	case screenLobby:
+		return "tab/↑↓ move · ←→ change · enter play · esc quit"
+	case screenSecret:
+		return "enter set secret · tab chat · ctrl+c quit"
+	}
+	help := "enter guess · tab chat"
+	if m.match != nil && m.match.Status == domain.MatchStateFinished {
+		help = "tab chat"
+	}
+	if m.hotSeat && m.match != nil && m.match.Mode == domain.MatchModeRace {
+		help += " · ctrl+t switch player"
+	}
+	if m.match != nil && m.match.Status == domain.MatchStateFinished {
+		help += " · ctrl+r rematch"
+	}
+	return help + " · ctrl+c quit"
+}
+
+func (m *tuiModel) lobbyView() string {
+	var b strings.Builder
+	if m.hotSeat {
+		b.WriteString("Two players share this keyboard against an in-memory server.\n\n")
+	} else {
+		b.WriteString("Create a room and share its id, or join a friend's room.\n\n")
+	}
+
+	for index, field := range m.lobbyFields() {
+		label := labelStyle.Render(field.label)
+		if index == m.focus {
+			label = focusStyle.Inherit(labelStyle).Render(field.label)
+		}
+
+		value := field.input.View()
+		if field.choices != nil {
+			options := make([]string, len(field.choices))
+			for i, choice := range field.choices {
+				if i == field.choice {
+					options[i] = focusStyle.Render("[" + choice + "]")
+				} else {
+					options[i] = dimStyle.Render(" " + choice + " ")
+				}
+			}
+			value = strings.Join(options, " ")
+		}
+		fmt.Fprintf(&b, "%v %v\n", label, value)
+	}
+
+	if m.busy {
+		b.WriteString("\n" + dimStyle.Render("connecting..."))
+	}
+	return panelStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+func (m *tuiModel) secretView() string {
+	var b strings.Builder
+	for _, seat := range m.seats {
+		state := dimStyle.Render("choosing...")
+		if seat.secretSet {
+			state = bullStyle.Render("ready")
+		}
+		fmt.Fprintf(&b, "%v %v\n", labelStyle.Render(seat.username), state)
+	}
+	if m.match != nil && len(m.match.Players) < 2 {
+		b.WriteString(dimStyle.Render("waiting for an opponent to join...") + "\n")
+	}
+	b.WriteString("\n")
+
+	if m.secretsReady() {
+		b.WriteString(dimStyle.Render("waiting for the match to start..."))
+	} else {
+		seat := m.seats[m.active]
+		if m.hotSeat {
+			fmt.Fprintf(&b, "%v, pick a secret while your opponent looks away.\n", seat.username)
+		} else {
+			b.WriteString("Pick your secret: four different digits.\n")
+		}
+		b.WriteString("secret> " + m.input.View())
+	}
+	return panelStyle.Width(2*TUI_COLUMN_WIDTH + 4).Render(b.String())
+}
+
+func (m *tuiModel) boardView() string {
+	match := m.match
+	columns := make([]string, 0, len(match.Players))
+	for _, player := range match.Players {
+		columns = append(columns, m.playerColumn(player))
+	}
+	board := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+
+	var status []string
+	status = append(status, "match "+m.elapsed(m.startedAt))
+	if match.Mode == domain.MatchModeTurns && match.Status == domain.MatchStatePlaying {
+		status = append(status, "turn "+m.elapsed(m.turnStartedAt))
+	}
+
+	var prompt string
+	names := playerNames(match.Players)
+	switch {
+	case match.Status == domain.MatchStateFinished:
+		prompt = winnerStyle.Render(names[match.Winner] + " cracked it!")
+		if match.Series.BestOf > 1 && match.Series.Winner != "" {
+			prompt += "\n" + winnerStyle.Render(names[match.Series.Winner]+" wins the series")
+		}
+		if match.Rematch != nil {
+			prompt += "\n" + dimStyle.Render("rematch offered by "+names[match.Rematch.OfferedBy])
+		}
+	case m.closed:
+		prompt = dimStyle.Render("the match is closed")
+	case canGuess(match, m.seats[m.active].playerId):
+		who := ""
+		if m.hotSeat {
+			who = m.seats[m.active].username + " "
+		}
+		prompt = who + "guess> " + m.input.View()
+	default:
+		prompt = dimStyle.Render(waitingMessage(match))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, board, dimStyle.Render(strings.Join(status, " · ")), "", prompt)
+}
+
+func (m *tuiModel) playerColumn(player contracts.PlayerResponse) string {
+	match := m.match
+	name := player.Username
+	if m.seatOf(player.Handle) >= 0 && !m.hotSeat {
+		name += " (you)"
+	}
+
+	marker := "  "
+	if match.Status == domain.MatchStatePlaying && match.Mode == domain.MatchModeTurns && match.IsTurnOf == player.Handle {
+		marker = bullStyle.Render("▶ ")
+	}
+	lines := []string{marker + lipgloss.NewStyle().Bold(true).Render(name)}
+	if match.Series.BestOf > 1 {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  wins %v", match.Series.Score[player.Handle])))
+	}
+
+	guesses := match.Guesses[player.Handle]
+	if len(guesses) == 0 {
+		lines = append(lines, dimStyle.Render("  no guesses yet"))
+	}
+	for index, item := range guesses {
+		lines = append(lines, fmt.Sprintf("%3d. %v", index+1, renderGuess(item)))
+	}
+
+	style := panelStyle.Width(TUI_COLUMN_WIDTH)
+	if match.Winner == player.Handle || (match.Status == domain.MatchStatePlaying && match.IsTurnOf == player.Handle && match.Mode == domain.MatchModeTurns) {
+		style = style.Inherit(activeBorder)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// renderGuess highlights bulls in green and cows in yellow.
+func renderGuess(item domain.GuessesHistoryItem) string {
+	var digits strings.Builder
+	bulls, cows := 0, 0
+	for _, digit := range item.Guess {
+		switch digit.Type {
+		case domain.Bull:
+			bulls++
+			digits.WriteString(bullStyle.Render(digit.Value))
+		case domain.Cow:
+			cows++
+			digits.WriteString(cowStyle.Render(digit.Value))
+		default:
+			digits.WriteString(dimStyle.Render(digit.Value))
+		}
+	}
+	return fmt.Sprintf("%v  %v %v", digits.String(), bullStyle.Render(fmt.Sprintf("%dB", bulls)), cowStyle.Render(fmt.Sprintf("%dC", cows)))
+}
+
+func (m *tuiModel) elapsed(since time.Time) string {
+	if since.IsZero() {
+		return "0:00"
+	}
+	until := m.now
+	if !m.finishedAt.IsZero() {
+		until = m.finishedAt
+	}
+	d := until.Sub(since)
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+func (m *tuiModel) chatView() string {
+	lines := make([]string, 0, TUI_CHAT_LINES)
+	start := max(len(m.chat)-TUI_CHAT_LINES, 0)
+	for _, message := range m.chat[start:] {
+		lines = append(lines, fmt.Sprintf("%v %v %v", dimStyle.Render(message.SentAt.Local().Format("15:04")), lipgloss.NewStyle().Bold(true).Render(message.Username+":"), message.Message))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, dimStyle.Render("no messages yet"))
+	}
+
+	sender := ""
+	if m.hotSeat && len(m.seats) > 0 {
+		sender = m.seats[m.active].username + " "
+	}
+	prompt := dimStyle.Render(sender + "say> ")
+	if m.chatFocused {
+		prompt = focusStyle.Render(sender + "say> ")
+	}
+
+	title := titleStyle.Render("Chat")
+	return panelStyle.Width(TUI_CHAT_WIDTH).Render(title + "\n" + strings.Join(lines, "\n") + "\n\n" + prompt + m.chatInput.View())
+}
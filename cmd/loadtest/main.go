@@ -0,0 +1,149 @@
+// Command loadtest plays many concurrent matches end to end against the REST API,
+// or an in-process server, and reports latency percentiles, error rates and
+// throughput per endpoint.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/embedded"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	apiURL := flags.String("api", "", "base URL of the API, e.g. http://localhost:3000/api/v1; an in-process server with an in-memory store when empty")
+	matches := flags.Int("matches", 100, "matches to play")
+	concurrency := flags.Int("concurrency", 0, "matches played at the same time, all of them when 0")
+	mode := flags.String("mode", string(domain.MatchModeTurns), "Turns or Race")
+	think := flags.Duration("think", 0, "pause before every guess")
+	timeout := flags.Duration("timeout", 5*time.Minute, "stop after this long")
+	jsonOutput := flags.Bool("json", false, "print the report as JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *matches <= 0 || *concurrency < 0 {
+		fmt.Fprintln(os.Stderr, "-matches must be positive and -concurrency can not be negative")
+		return 2
+	}
+	if *mode != string(domain.MatchModeTurns) && *mode != string(domain.MatchModeRace) {
+		fmt.Fprintf(os.Stderr, "unknown mode %q, use Turns or Race\n", *mode)
+		return 2
+	}
+	if *concurrency == 0 || *concurrency > *matches {
+		*concurrency = *matches
+	}
+
+	target := *apiURL
+	if target == "" {
+		server, err := embedded.Start()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "starting the in-process server:", err)
+			return 1
+		}
+		defer server.Close()
+		target = server.URL
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	// one idle connection per worker and no retries, so latencies and errors are
+	// the server's own
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = *concurrency
+	sim := &simulator{
+		api:    client.New(target, client.WithHTTPClient(&http.Client{Transport: transport}), client.WithRetries(0, 0, 0)),
+		record: newRecorder(),
+		mode:   domain.MatchMode(*mode),
+		think:  *think,
+	}
+
+	result := &report{
+		Target:      target,
+		Mode:        *mode,
+		Matches:     *matches,
+		Concurrency: *concurrency,
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	jobs := make(chan int)
+	start := time.Now()
+
+	for worker := 0; worker < *concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				guesses, err := sim.play(ctx, index)
+
+				mu.Lock()
+				result.Guesses += guesses
+				if err != nil {
+					result.Failed++
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					result.Completed++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for index := 0; index < *matches && ctx.Err() == nil; index++ {
+		select {
+		case jobs <- index:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	endpoints, requests := sim.record.endpointReports()
+	result.Endpoints = endpoints
+	result.DurationSeconds = elapsed.Seconds()
+	result.RequestsPerSecond = float64(requests) / elapsed.Seconds()
+	result.MatchesPerSecond = float64(result.Completed) / elapsed.Seconds()
+
+	if *jsonOutput {
+		json.NewEncoder(os.Stdout).Encode(result)
+	} else {
+		result.write(os.Stdout)
+	}
+
+	if ctx.Err() != nil && result.Completed+result.Failed < *matches {
+		fmt.Fprintf(os.Stderr, "stopped after %v of %v matches: %v\n", result.Completed+result.Failed, *matches, context.Cause(ctx))
+		return 1
+	}
+	if firstErr != nil {
+		if !errors.Is(firstErr, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "first failure:", firstErr)
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+// MAX_GUESSES_PER_PLAYER stops a match that does not converge, the solver needs
+// at most ten guesses.
+const MAX_GUESSES_PER_PLAYER = 20
+
+type simulator struct {
+	api    *client.Client
+	record *recorder
+	mode   domain.MatchMode
+	think  time.Duration
+}
+
+type simPlayer struct {
+	id     string
+	handle string
+	solver *solver
+}
+
+// call runs one request and records its latency under endpoint.
+func call[T any](s *simulator, endpoint string, request func() (T, error)) (T, error) {
+	start := time.Now()
+	res, err := request()
+	s.record.observe(endpoint, start, err)
+	if err != nil {
+		return res, fmt.Errorf("%v: %w", endpoint, err)
+	}
+	return res, nil
+}
+
+// play runs a whole match, from creating the room to a winner, and returns how
+// many guesses the server recorded.
+func (s *simulator) play(ctx context.Context, index int) (int, error) {
+	rnd := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), uint64(index)))
+
+	room, err := call(s, "create", func() (*contracts.CreateRoomResponse, error) {
+		return s.api.CreateRoom(ctx, contracts.CreateRoomCommand{Username: fmt.Sprintf("host-%d", index), Mode: s.mode})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	joined, err := call(s, "join", func() (*contracts.JoinRoomResponse, error) {
+		return s.api.JoinRoom(ctx, room.RoomId, fmt.Sprintf("guest-%d", index))
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// keyed by handle like the match state; each player gets its own source, Race
+	// mode guesses concurrently
+	players := map[string]*simPlayer{
+		room.Player.Handle:   {id: room.Player.Id, handle: room.Player.Handle, solver: newSolver(rand.New(rand.NewPCG(rnd.Uint64(), rnd.Uint64())))},
+		joined.Player.Handle: {id: joined.Player.Id, handle: joined.Player.Handle, solver: newSolver(rand.New(rand.NewPCG(rnd.Uint64(), rnd.Uint64())))},
+	}
+	for _, player := range players {
+		secret, _ := strconv.Atoi(randomSecret(rnd))
+		if _, err := call(s, "setCombination", func() (*contracts.SuccessResponse, error) {
+			return s.api.SetCombination(ctx, room.RoomId, player.id, secret)
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	started, err := call(s, "startGame", func() (*contracts.StartMatchResponse, error) {
+		return s.api.StartGame(ctx, room.RoomId)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if s.mode == domain.MatchModeRace {
+		err = s.playRace(ctx, room.RoomId, players)
+	} else {
+		err = s.playTurns(ctx, room.RoomId, players, started.IsTurnOf)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	match, err := call(s, "getMatch", func() (*contracts.MatchStateResponse, error) {
+		return s.api.GetMatch(ctx, room.RoomId)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	guesses := 0
+	for _, items := range match.Guesses {
+		guesses += len(items)
+	}
+	if match.Status != domain.MatchStateFinished || match.Winner == "" {
+		return guesses, fmt.Errorf("room %v ended as %v without a winner", room.RoomId, match.Status)
+	}
+	return guesses, nil
+}
+
+func (s *simulator) playTurns(ctx context.Context, roomId string, players map[string]*simPlayer, turnOf string) error {
+	opponents := map[string]string{}
+	for handle := range players {
+		for other := range players {
+			if other != handle {
+				opponents[handle] = other
+			}
+		}
+	}
+
+	for i := 0; i < 2*MAX_GUESSES_PER_PLAYER; i++ {
+		winner, err := s.guess(ctx, roomId, players[turnOf])
+		if err != nil {
+			return err
+		}
+		if winner {
+			return nil
+		}
+		turnOf = opponents[turnOf]
+	}
+	return fmt.Errorf("room %v did not finish after %v guesses", roomId, 2*MAX_GUESSES_PER_PLAYER)
+}
+
+// playRace lets both players guess concurrently. A player stops once solved; the
+// match ends when the server resolves the winner on a later guess.
+func (s *simulator) playRace(ctx context.Context, roomId string, players map[string]*simPlayer) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, player := range players {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < MAX_GUESSES_PER_PLAYER; i++ {
+				done, err := s.guess(ctx, roomId, player)
+
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil && ctx.Err() == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+				}
+
+				if done || err != nil {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// guess plays the solver's next guess and reports whether the player is done:
+// the match has a winner or, in Race mode, the player cracked the secret.
+func (s *simulator) guess(ctx context.Context, roomId string, player *simPlayer) (bool, error) {
+	if s.think > 0 {
+		if err := sleep(ctx, s.think); err != nil {
+			return false, err
+		}
+	}
+
+	guess := player.solver.next()
+	value, _ := strconv.Atoi(guess)
+
+	start := time.Now()
+	res, err := s.api.MakeGuess(ctx, roomId, player.id, value)
+	if s.mode == domain.MatchModeRace && errors.Is(err, client.ErrMatchNotStarted) {
+		// the opponent won between two of our guesses, not a failure of the server
+		return true, nil
+	}
+	s.record.observe("makeGuess", start, err)
+	if err != nil {
+		return false, fmt.Errorf("makeGuess: %w", err)
+	}
+
+	items := res.Guesses[player.handle]
+	if len(items) == 0 {
+		return false, fmt.Errorf("room %v did not return the guess of %v", roomId, player.handle)
+	}
+	last := items[len(items)-1]
+	bulls, cows := countResult(last)
+	player.solver.learn(guess, bulls, cows)
+
+	return res.Winner != "" || last.IsWinnerCombination, nil
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
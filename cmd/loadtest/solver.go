@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+// allCombinations holds every valid combination: four different digits that do
+// not start with zero, since combinations travel as integers.
+var allCombinations = func() []string {
+	combinations := []string{}
+	for value := 1000; value <= 9999; value++ {
+		combination := strconv.Itoa(value)
+		if domain.ValidateCombination(combination) == nil {
+			combinations = append(combinations, combination)
+		}
+	}
+	return combinations
+}()
+
+// solver keeps the combinations consistent with every answer so far and guesses
+// one of them at random, which cracks a secret in about six guesses.
+type solver struct {
+	candidates []string
+	rand       *rand.Rand
+}
+
+func newSolver(rnd *rand.Rand) *solver {
+	return &solver{
+		candidates: append([]string(nil), allCombinations...),
+		rand:       rnd,
+	}
+}
+
+func (s *solver) next() string {
+	return s.candidates[s.rand.IntN(len(s.candidates))]
+}
+
+// learn drops the candidates that would not have produced the same answer to guess.
+func (s *solver) learn(guess string, bulls int, cows int) {
+	remaining := s.candidates[:0]
+	for _, candidate := range s.candidates {
+		if b, c := score(guess, candidate); b == bulls && c == cows {
+			remaining = append(remaining, candidate)
+		}
+	}
+	s.candidates = remaining
+}
+
+func score(guess string, secret string) (bulls int, cows int) {
+	for i := 0; i < len(guess); i++ {
+		for j := 0; j < len(secret); j++ {
+			if guess[i] != secret[j] {
+				continue
+			}
+			if i == j {
+				bulls++
+			} else {
+				cows++
+			}
+		}
+	}
+	return bulls, cows
+}
+
+func randomSecret(rnd *rand.Rand) string {
+	return allCombinations[rnd.IntN(len(allCombinations))]
+}
+
+func countResult(item domain.GuessesHistoryItem) (bulls int, cows int) {
+	for _, digit := range item.Guess {
+		switch digit.Type {
+		case domain.Bull:
+			bulls++
+		case domain.Cow:
+			cows++
+		}
+	}
+	return bulls, cows
+}
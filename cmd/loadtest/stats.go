@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/client"
+)
+
+type endpointStats struct {
+	latencies []time.Duration
+	errors    int
+	codes     map[string]int
+}
+
+// recorder collects the latency and outcome of every request by endpoint.
+type recorder struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointStats
+}
+
+func newRecorder() *recorder {
+	return &recorder{endpoints: map[string]*endpointStats{}}
+}
+
+func (r *recorder) observe(endpoint string, start time.Time, err error) {
+	elapsed := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.endpoints[endpoint]
+	if !ok {
+		stats = &endpointStats{codes: map[string]int{}}
+		r.endpoints[endpoint] = stats
+	}
+	stats.latencies = append(stats.latencies, elapsed)
+	if err != nil {
+		stats.errors++
+		stats.codes[errorCode(err)]++
+	}
+}
+
+func errorCode(err error) string {
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) {
+		if apiErr.Code != "" {
+			return string(apiErr.Code)
+		}
+		return fmt.Sprint(apiErr.Status)
+	}
+	return "transport"
+}
+
+type endpointReport struct {
+	Endpoint   string         `json:"endpoint"`
+	Requests   int            `json:"requests"`
+	Errors     int            `json:"errors"`
+	ErrorRate  float64        `json:"error_rate"`
+	P50        float64        `json:"p50_ms"`
+	P90        float64        `json:"p90_ms"`
+	P99        float64        `json:"p99_ms"`
+	Max        float64        `json:"max_ms"`
+	ErrorCodes map[string]int `json:"error_codes,omitempty"`
+}
+
+type report struct {
+	Target            string           `json:"target"`
+	Mode              string           `json:"mode"`
+	Matches           int              `json:"matches"`
+	Concurrency       int              `json:"concurrency"`
+	Completed         int              `json:"completed"`
+	Failed            int              `json:"failed"`
+	Guesses           int              `json:"guesses"`
+	DurationSeconds   float64          `json:"duration_seconds"`
+	RequestsPerSecond float64          `json:"requests_per_second"`
+	MatchesPerSecond  float64          `json:"matches_per_second"`
+	Endpoints         []endpointReport `json:"endpoints"`
+}
+
+func (r *recorder) endpointReports() ([]endpointReport, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reports := make([]endpointReport, 0, len(r.endpoints))
+	total := 0
+	for endpoint, stats := range r.endpoints {
+		latencies := slices.Clone(stats.latencies)
+		slices.Sort(latencies)
+		total += len(latencies)
+
+		report := endpointReport{
+			Endpoint:  endpoint,
+			Requests:  len(latencies),
+			Errors:    stats.errors,
+			ErrorRate: float64(stats.errors) / float64(len(latencies)),
+			P50:       milliseconds(percentile(latencies, 50)),
+			P90:       milliseconds(percentile(latencies, 90)),
+			P99:       milliseconds(percentile(latencies, 99)),
+			Max:       milliseconds(latencies[len(latencies)-1]),
+		}
+		if stats.errors > 0 {
+			report.ErrorCodes = stats.codes
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Endpoint < reports[j].Endpoint })
+	return reports, total
+}
+
+// percentile uses the nearest-rank method on sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank-1, 0)]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func (r *report) write(w io.Writer) {
+	fmt.Fprintf(w, "target      %v\n", r.Target)
+	fmt.Fprintf(w, "matches     %v %v, %v at a time: %v completed, %v failed\n", r.Matches, r.Mode, r.Concurrency, r.Completed, r.Failed)
+	fmt.Fprintf(w, "duration    %.2fs\n", r.DurationSeconds)
+	fmt.Fprintf(w, "throughput  %.1f req/s, %.2f matches/s, %v guesses\n\n", r.RequestsPerSecond, r.MatchesPerSecond, r.Guesses)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "endpoint\trequests\terrors\terror rate\tp50 ms\tp90 ms\tp99 ms\tmax ms\t")
+	for _, endpoint := range r.Endpoints {
+		fmt.Fprintf(table, "%v\t%v\t%v\t%.2f%%\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+			endpoint.Endpoint, endpoint.Requests, endpoint.Errors, endpoint.ErrorRate*100,
+			endpoint.P50, endpoint.P90, endpoint.P99, endpoint.Max)
+	}
+	table.Flush()
+
+	header := false
+	for _, endpoint := range r.Endpoints {
+		codes := slices.Sorted(maps.Keys(endpoint.ErrorCodes))
+		for _, code := range codes {
+			if !header {
+				fmt.Fprintln(w, "\nerrors")
+				header = true
+			}
+			fmt.Fprintf(w, "  %v %v: %v\n", endpoint.Endpoint, code, endpoint.ErrorCodes[code])
+		}
+	}
+}
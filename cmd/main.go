@@ -1,16 +1,21 @@
 package main
 
 import (
-	"time"
+	"flag"
+	"log"
+	"os"
 
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/cmd/api"
-	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/config"
 )
 
 func main() {
-	cfg := api.ApplicationConfig{
-		Addr:            utils.GetEnvironment().GetEnv("API_ADDR", ":3000"),
-		GracefulTimeout: time.Second * 15,
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
 
 	server := api.NewApplication(cfg)
@@ -0,0 +1,69 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+type AdminActor struct {
+	Name      string
+	RequestId string
+}
+
+type ListRoomsQuery struct {
+	Status domain.MatchStatus `validate:"omitempty,oneof=Waiting FullRoom Playing Finished"`
+	Limit  int                `validate:"min=1,max=500"`
+}
+
+type AdminRoomSummaryResponse struct {
+	RoomId     string             `json:"room_id"`
+	Status     domain.MatchStatus `json:"status"`
+	TTLSeconds int64              `json:"ttl_seconds"`
+}
+
+type AdminRoomsResponse struct {
+	Rooms []AdminRoomSummaryResponse `json:"rooms"`
+}
+
+// AdminRoomResponse keys everything by handle like the public state. PlayerIds maps
+// the handles to the player ids ForceFinish and KickPlayer take.
+type AdminRoomResponse struct {
+	MatchStateResponse
+	PlayerIds    map[string]string `json:"player_ids"`
+	Combinations map[string]string `json:"combinations"`
+	TTLSeconds   int64             `json:"ttl_seconds"`
+}
+
+type ForceFinishCommand struct {
+	Winner string `json:"winner"`
+	RoomId string
+}
+
+type KickPlayerCommand struct {
+	PlayerId string `json:"player_id" validate:"required"`
+	RoomId   string
+}
+
+type ExtendTTLCommand struct {
+	Seconds int `json:"seconds" validate:"required,min=1,max=86400"`
+	RoomId  string
+}
+
+type AdminStatsResponse struct {
+	Total    int                        `json:"total"`
+	ByStatus map[domain.MatchStatus]int `json:"by_status"`
+}
+
+type AuditEntry struct {
+	At        time.Time `json:"at"`
+	Admin     string    `json:"admin"`
+	Action    string    `json:"action"`
+	RoomId    string    `json:"room_id,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	RequestId string    `json:"request_id,omitempty"`
+}
+
+type AuditLogResponse struct {
+	Entries []AuditEntry `json:"entries"`
+}
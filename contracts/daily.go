@@ -0,0 +1,44 @@
+package contracts
+
+import "github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+
+type StartDailyCommand struct {
+	Username string `json:"username" validate:"required,max=32,excludesall={}"`
+	Token    string `json:"token"`
+}
+
+type DailyGuessCommand struct {
+	Username string `json:"username" validate:"required,max=32,excludesall={}"`
+	Token    string `json:"token" validate:"required"`
+	Guess    int    `json:"guess"`
+}
+
+// DailyAttemptQuery reads the attempt the player is on, or the one of Day when set.
+type DailyAttemptQuery struct {
+	Username string `validate:"required,max=32,excludesall={}"`
+	Day      string `validate:"omitempty,datetime=2006-01-02"`
+}
+
+type DailyAttemptResponse struct {
+	Day        string                      `json:"day"`
+	Username   string                      `json:"username"`
+	Guesses    []domain.GuessesHistoryItem `json:"guesses"`
+	Solved     bool                        `json:"solved"`
+	Finished   bool                        `json:"finished"`
+	Remaining  int                         `json:"remaining"`
+	MaxGuesses int                         `json:"max_guesses"`
+	Share      string                      `json:"share,omitempty"`
+	Commitment string                      `json:"commitment"`
+	Secret     string                      `json:"secret,omitempty"`
+	Salt       string                      `json:"salt,omitempty"`
+	Token      string                      `json:"token,omitempty"`
+}
+
+type DailyStatsResponse struct {
+	Username      string      `json:"username"`
+	Played        int         `json:"played"`
+	Wins          int         `json:"wins"`
+	CurrentStreak int         `json:"current_streak"`
+	MaxStreak     int         `json:"max_streak"`
+	Distribution  map[int]int `json:"distribution"`
+}
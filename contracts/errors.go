@@ -0,0 +1,64 @@
+package contracts
+
+// ErrorCode is the stable, machine readable identifier of an error. Clients should
+// branch on it instead of the human readable title or detail.
+type ErrorCode string
+
+const (
+	ErrorCodeInternal         ErrorCode = "internal_error"
+	ErrorCodeInvalidRequest   ErrorCode = "invalid_request"
+	ErrorCodeValidationFailed ErrorCode = "validation_failed"
+	ErrorCodeRouteNotFound    ErrorCode = "route_not_found"
+	ErrorCodeMethodNotAllowed ErrorCode = "method_not_allowed"
+	ErrorCodeUnauthorized     ErrorCode = "unauthorized"
+	ErrorCodeRateLimited      ErrorCode = "rate_limited"
+
+	ErrorCodeInvalidCombination       ErrorCode = "invalid_combination"
+	ErrorCodeRepeatedDigits           ErrorCode = "repeated_digits"
+	ErrorCodeResourceNotFound         ErrorCode = "resource_not_found"
+	ErrorCodeResourceAlreadyExists    ErrorCode = "resource_already_exists"
+	ErrorCodeRoomFull                 ErrorCode = "room_full"
+	ErrorCodeRoomNotReady             ErrorCode = "room_not_ready"
+	ErrorCodeMatchNotFound            ErrorCode = "match_not_found"
+	ErrorCodeMatchExpired             ErrorCode = "match_expired"
+	ErrorCodeCombinationsPending      ErrorCode = "combinations_pending"
+	ErrorCodeMatchNotStarted          ErrorCode = "match_not_started"
+	ErrorCodeMatchFinished            ErrorCode = "match_finished"
+	ErrorCodeNotYourTurn              ErrorCode = "not_your_turn"
+	ErrorCodeAlreadySolved            ErrorCode = "already_solved"
+	ErrorCodeSeriesOver               ErrorCode = "series_over"
+	ErrorCodeMatchNotFinished         ErrorCode = "match_not_finished"
+	ErrorCodeRematchAlreadyOffered    ErrorCode = "rematch_already_offered"
+	ErrorCodeNoRematchOffer           ErrorCode = "no_rematch_offer"
+	ErrorCodeOwnRematchOffer          ErrorCode = "own_rematch_offer"
+	ErrorCodeTournamentNotFound       ErrorCode = "tournament_not_found"
+	ErrorCodeRegistrationClosed       ErrorCode = "registration_closed"
+	ErrorCodeNotEnoughPlayers         ErrorCode = "not_enough_players"
+	ErrorCodeTournamentAlreadyStarted ErrorCode = "tournament_already_started"
+	ErrorCodeDailyAlreadyPlayed       ErrorCode = "daily_already_played"
+	ErrorCodeDailyNotStarted          ErrorCode = "daily_not_started"
+	ErrorCodeDailyAttemptOver         ErrorCode = "daily_attempt_over"
+	ErrorCodeDailyPlayerNotFound      ErrorCode = "daily_player_not_found"
+	ErrorCodeDailyInvalidToken        ErrorCode = "daily_invalid_token"
+	ErrorCodePlayerNotInRoom          ErrorCode = "player_not_in_room"
+	ErrorCodeKickLastPlayer           ErrorCode = "kick_last_player"
+)
+
+const PROBLEM_CONTENT_TYPE = "application/problem+json"
+
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ProblemResponse follows RFC 7807, extended with the error code and the request id.
+type ProblemResponse struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	Code          ErrorCode      `json:"code"`
+	RequestId     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
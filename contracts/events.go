@@ -0,0 +1,38 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+type RoomExpiredEvent struct {
+	RoomId     string             `json:"room_id"`
+	LastStatus domain.MatchStatus `json:"last_status"`
+	ExpiredAt  time.Time          `json:"expired_at"`
+}
+
+type MatchEventType string
+
+const (
+	MatchEventSnapshot         = MatchEventType("snapshot")
+	MatchEventPlayerJoined     = MatchEventType("player_joined")
+	MatchEventPlayerLeft       = MatchEventType("player_left")
+	MatchEventMatchStarted     = MatchEventType("match_started")
+	MatchEventGuessMade        = MatchEventType("guess_made")
+	MatchEventMatchFinished    = MatchEventType("match_finished")
+	MatchEventRematchOffered   = MatchEventType("rematch_offered")
+	MatchEventRematchCancelled = MatchEventType("rematch_cancelled")
+	MatchEventRematchAccepted  = MatchEventType("rematch_accepted")
+	MatchEventUpdated          = MatchEventType("updated")
+	MatchEventMatchExpired     = MatchEventType("match_expired")
+	MatchEventMatchClosed      = MatchEventType("match_closed")
+)
+
+// MatchEvent carries the public state of the match right after the change. The last
+// event of a stream is match_expired or match_closed and has no state.
+type MatchEvent struct {
+	Type  MatchEventType      `json:"type"`
+	Match *MatchStateResponse `json:"match,omitempty"`
+	At    time.Time           `json:"at"`
+}
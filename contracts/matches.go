@@ -1,13 +1,21 @@
 package contracts
 
-import "github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+import (
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
 
 type CreateRoomCommand struct {
-	Username string `json:"username" validate:"required"`
+	Username string           `json:"username" validate:"required"`
+	Mode     domain.MatchMode `json:"mode" validate:"omitempty,oneof=Turns Race"`
+	BestOf   int              `json:"best_of" validate:"omitempty,oneof=1 3 5 7"`
 }
 type CreateRoomResponse struct {
-	RoomId string         `json:"room_id"`
-	Player PlayerResponse `json:"player"`
+	RoomId string                    `json:"room_id"`
+	Mode   domain.MatchMode          `json:"mode"`
+	BestOf int                       `json:"best_of"`
+	Player PlayerCredentialsResponse `json:"player"`
 }
 
 type JoinRoomCommand struct {
@@ -16,8 +24,8 @@ type JoinRoomCommand struct {
 }
 
 type JoinRoomResponse struct {
-	RoomId string         `json:"room_id"`
-	Player PlayerResponse `json:"player"`
+	RoomId string                    `json:"room_id"`
+	Player PlayerCredentialsResponse `json:"player"`
 }
 
 type SetCombinationCommand struct {
@@ -31,7 +39,9 @@ type SuccessResponse struct {
 }
 
 type StartMatchResponse struct {
-	IsTurnOf string `json:"is_turn_of"`
+	Mode        domain.MatchMode  `json:"mode"`
+	IsTurnOf    string            `json:"is_turn_of"`
+	Commitments map[string]string `json:"commitments"`
 }
 
 type MakeGuessCommand struct {
@@ -42,5 +52,79 @@ type MakeGuessCommand struct {
 
 type MakeGuessResponse struct {
 	IsWinner bool                `json:"is_winner"`
+	Winner   string              `json:"winner"`
 	Guesses  domain.MatchGuesses `json:"guesses"`
 }
+
+type RoundResultResponse struct {
+	Round     int    `json:"round"`
+	Winner    string `json:"winner"`
+	Guesses   int    `json:"guesses"`
+	StartedBy string `json:"started_by"`
+}
+
+type SeriesResponse struct {
+	BestOf int                   `json:"best_of"`
+	Score  map[string]int        `json:"score"`
+	Rounds []RoundResultResponse `json:"rounds"`
+	Winner string                `json:"winner"`
+}
+
+type RematchCommand struct {
+	PlayerId string `json:"player_id" validate:"required"`
+	RoomId   string
+}
+
+type RematchOfferResponse struct {
+	OfferedBy string    `json:"offered_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type MatchStateResponse struct {
+	RoomId   string                `json:"room_id"`
+	Mode     domain.MatchMode      `json:"mode"`
+	Status   domain.MatchStatus    `json:"status"`
+	IsTurnOf string                `json:"is_turn_of"`
+	Winner   string                `json:"winner"`
+	Players  []PlayerResponse      `json:"players"`
+	Guesses  domain.MatchGuesses   `json:"guesses"`
+	Series   SeriesResponse        `json:"series"`
+	Rematch  *RematchOfferResponse `json:"rematch_offer"`
+	// Commitments is keyed by the player who set the secret, it is published once the
+	// match starts and the secrets are revealed once it is finished
+	Commitments map[string]CommitmentResponse `json:"commitments,omitempty"`
+}
+
+type CommitmentResponse struct {
+	Commitment string `json:"commitment"`
+	Secret     string `json:"secret,omitempty"`
+	Salt       string `json:"salt,omitempty"`
+}
+
+type VerifyCommitmentCommand struct {
+	Commitment string `json:"commitment" validate:"required,len=64,hexadecimal"`
+	Secret     string `json:"secret" validate:"required"`
+	Salt       string `json:"salt" validate:"required,hexadecimal"`
+}
+
+type VerifyCommitmentResponse struct {
+	Valid bool `json:"valid"`
+}
+
+type ChatMessageCommand struct {
+	PlayerId string `json:"player_id" validate:"required"`
+	Message  string `json:"message" validate:"required,max=280"`
+	RoomId   string
+}
+
+type ChatMessageResponse struct {
+	Id       string    `json:"id"`
+	Handle   string    `json:"handle"`
+	Username string    `json:"username"`
+	Message  string    `json:"message"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+type ChatResponse struct {
+	Messages []ChatMessageResponse `json:"messages"`
+}
@@ -1,6 +1,17 @@
 package contracts
 
+// PlayerResponse is a player as everyone who can read the room sees it, named by
+// its handle. Player ids, StartedBy, Winner and the other player keys of public
+// responses are handles too.
 type PlayerResponse struct {
 	Username string `json:"username"`
+	Handle   string `json:"handle"`
+}
+
+// PlayerCredentialsResponse is only returned to the player it belongs to, Id has to
+// be sent with every action of the player.
+type PlayerCredentialsResponse struct {
 	Id       string `json:"id"`
+	Username string `json:"username"`
+	Handle   string `json:"handle"`
 }
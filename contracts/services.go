@@ -8,5 +8,38 @@ type IMatchesService interface {
 	SetCombination(ctx context.Context, setCombinationCommand SetCombinationCommand) (*SuccessResponse, error)
 	StartGame(ctx context.Context, roomId string) (*StartMatchResponse, error)
 	MakeGuess(ctx context.Context, command MakeGuessCommand) (*MakeGuessResponse, error)
-	RestartGame(ctx context.Context, roomId string) (*SuccessResponse, error)
+	OfferRematch(ctx context.Context, command RematchCommand) (*SuccessResponse, error)
+	AcceptRematch(ctx context.Context, command RematchCommand) (*SuccessResponse, error)
+	DeclineRematch(ctx context.Context, command RematchCommand) (*SuccessResponse, error)
+	GetMatch(ctx context.Context, roomId string) (*MatchStateResponse, error)
+	WatchMatch(ctx context.Context, roomId string) (<-chan MatchEvent, error)
+	SendChatMessage(ctx context.Context, command ChatMessageCommand) (*ChatMessageResponse, error)
+	GetChat(ctx context.Context, roomId string) (*ChatResponse, error)
+	VerifyCommitment(ctx context.Context, command VerifyCommitmentCommand) (*VerifyCommitmentResponse, error)
+}
+
+type ITournamentsService interface {
+	CreateTournament(ctx context.Context, command CreateTournamentCommand) (*CreateTournamentResponse, error)
+	RegisterPlayer(ctx context.Context, command RegisterTournamentPlayerCommand) (*RegisterTournamentPlayerResponse, error)
+	StartTournament(ctx context.Context, tournamentId string) (*TournamentBracketResponse, error)
+	GetBracket(ctx context.Context, tournamentId string) (*TournamentBracketResponse, error)
+	GetStandings(ctx context.Context, tournamentId string) (*TournamentStandingsResponse, error)
+}
+
+type IDailyService interface {
+	StartAttempt(ctx context.Context, command StartDailyCommand) (*DailyAttemptResponse, error)
+	MakeGuess(ctx context.Context, command DailyGuessCommand) (*DailyAttemptResponse, error)
+	GetAttempt(ctx context.Context, query DailyAttemptQuery) (*DailyAttemptResponse, error)
+	GetStats(ctx context.Context, username string) (*DailyStatsResponse, error)
+}
+
+type IAdminService interface {
+	ListRooms(ctx context.Context, actor AdminActor, query ListRoomsQuery) (*AdminRoomsResponse, error)
+	InspectRoom(ctx context.Context, actor AdminActor, roomId string) (*AdminRoomResponse, error)
+	ForceFinish(ctx context.Context, actor AdminActor, command ForceFinishCommand) (*SuccessResponse, error)
+	DeleteRoom(ctx context.Context, actor AdminActor, roomId string) (*SuccessResponse, error)
+	KickPlayer(ctx context.Context, actor AdminActor, command KickPlayerCommand) (*SuccessResponse, error)
+	ExtendTTL(ctx context.Context, actor AdminActor, command ExtendTTLCommand) (*AdminRoomSummaryResponse, error)
+	GetStats(ctx context.Context, actor AdminActor) (*AdminStatsResponse, error)
+	GetAuditLog(ctx context.Context, actor AdminActor, limit int) (*AuditLogResponse, error)
 }
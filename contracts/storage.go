@@ -2,42 +2,87 @@ package contracts
 
 import (
 	"context"
+	"time"
 
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
 )
 
-type SetPlayersCommand struct {
-	RoomId  string
-	Players domain.MatchPlayers
+type CreateMatchCommand struct {
+	RoomId string
+	Player domain.Player
+	Mode   domain.MatchMode
+	BestOf int
 }
 
-type SetOpponentCombinationsCommand struct {
+type CreateFullRoomCommand struct {
 	RoomId       string
-	Combinations domain.MatchOpponentCombinations
+	TournamentId string
+	Players      domain.MatchPlayers
+	Mode         domain.MatchMode
+	BestOf       int
 }
 
-type SetNewGuessCommand struct {
-	RoomId   string
-	Guesses  domain.MatchGuesses
-	IsTurnOf string
-	IsWinner bool
+type SetPlayersCommand struct {
+	RoomId  string
+	Players domain.MatchPlayers
 }
 
 type IMatchesRepository interface {
-	CreateMatch(ctx context.Context, player domain.Player) (*domain.Match, error)
+	CreateMatch(ctx context.Context, command CreateMatchCommand) (*domain.Match, error)
+	CreateFullRoom(ctx context.Context, command CreateFullRoomCommand) error
 	GetRoomPlayers(ctx context.Context, roomId string) (domain.MatchPlayers, error)
 	SetPlayersAndFillRoom(ctx context.Context, command SetPlayersCommand) error
 	GetMatchStatusById(ctx context.Context, roomId string) (domain.MatchStatus, error)
-	SetPlayerCombination(ctx context.Context, command SetOpponentCombinationsCommand) error
-	GetPlayersAndCombinations(ctx context.Context, roomId string) (*domain.Match, error)
 	GetAllButGuesses(ctx context.Context, roomId string) (*domain.Match, error)
 	ChangeStatusAndTurn(ctx context.Context, roomId string, status domain.MatchStatus, isTurnOf string) error
 	GetAll(ctx context.Context, roomId string) (*domain.Match, error)
-	SetNewGuess(ctx context.Context, command SetNewGuessCommand) error
+	UpdateGuessesAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error
+	UpdatePlayersAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error
+	UpdateCombinationsAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error
 	Exists(ctx context.Context, roomId string) error
 	Restart(ctx context.Context, roomId string) error
+	SetRematchOffer(ctx context.Context, roomId string, offer *domain.RematchOffer) error
+	SweepExpiredRooms(ctx context.Context) ([]domain.ExpiredRoom, error)
+	IsExpired(ctx context.Context, roomId string) (bool, error)
+	CountRoomsByStatus(ctx context.Context) (map[domain.MatchStatus]int, error)
+	WatchRoom(ctx context.Context, roomId string) (<-chan struct{}, error)
+	AppendChatMessage(ctx context.Context, roomId string, message domain.ChatMessage) error
+	GetChatMessages(ctx context.Context, roomId string) ([]domain.ChatMessage, error)
+}
+
+type RoomSummary struct {
+	RoomId string
+	Status domain.MatchStatus
+	TTL    time.Duration
+}
+
+type IAdminRepository interface {
+	ScanRooms(ctx context.Context, status domain.MatchStatus, limit int) ([]RoomSummary, error)
+	GetRoom(ctx context.Context, roomId string) (*domain.Match, time.Duration, error)
+	UpdateRoom(ctx context.Context, roomId string, update func(match *domain.Match) error) error
+	DeleteRoom(ctx context.Context, roomId string) error
+	ExtendRoomTTL(ctx context.Context, roomId string, extra time.Duration) (time.Duration, error)
+	AppendAudit(ctx context.Context, entry AuditEntry) error
+	GetAuditLog(ctx context.Context, limit int) ([]AuditEntry, error)
+}
+
+type ITournamentsRepository interface {
+	CreateTournament(ctx context.Context, tournament *domain.Tournament) error
+	GetTournament(ctx context.Context, tournamentId string) (*domain.Tournament, error)
+	UpdateTournament(ctx context.Context, tournamentId string, update func(tournament *domain.Tournament) error) error
+}
+
+type IDailyRepository interface {
+	GetPlayer(ctx context.Context, username string) (*domain.DailyPlayer, error)
+	CreateAttempt(ctx context.Context, attempt *domain.DailyAttempt, claim func(player *domain.DailyPlayer) error) error
+	GetAttempt(ctx context.Context, day, username string) (*domain.DailyAttempt, error)
+	UpdateAttemptAndStats(ctx context.Context, day, username string, update func(attempt *domain.DailyAttempt, stats *domain.DailyStats) error) error
+	GetStats(ctx context.Context, username string) (*domain.DailyStats, error)
 }
 
 type Storage struct {
-	MatchesRepository IMatchesRepository
+	MatchesRepository     IMatchesRepository
+	TournamentsRepository ITournamentsRepository
+	DailyRepository       IDailyRepository
+	AdminRepository       IAdminRepository
 }
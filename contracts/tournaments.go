@@ -0,0 +1,71 @@
+package contracts
+
+import "github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+
+type CreateTournamentCommand struct {
+	Name   string                  `json:"name" validate:"required,max=64"`
+	Format domain.TournamentFormat `json:"format" validate:"required,oneof=SingleElimination DoubleElimination Swiss RoundRobin"`
+	Mode   domain.MatchMode        `json:"mode" validate:"omitempty,oneof=Turns Race"`
+	BestOf int                     `json:"best_of" validate:"omitempty,oneof=1 3 5 7"`
+	Rounds int                     `json:"rounds" validate:"omitempty,min=1,max=20"`
+}
+
+type CreateTournamentResponse struct {
+	TournamentId string                  `json:"tournament_id"`
+	Name         string                  `json:"name"`
+	Format       domain.TournamentFormat `json:"format"`
+}
+
+type RegisterTournamentPlayerCommand struct {
+	Username     string `json:"username" validate:"required"`
+	TournamentId string
+}
+
+type RegisterTournamentPlayerResponse struct {
+	TournamentId string                    `json:"tournament_id"`
+	Player       PlayerCredentialsResponse `json:"player"`
+}
+
+type TournamentRulesResponse struct {
+	Mode   domain.MatchMode `json:"mode"`
+	BestOf int              `json:"best_of"`
+	Rounds int              `json:"rounds"`
+}
+
+type TournamentPairingResponse struct {
+	Round   int                      `json:"round"`
+	Bracket domain.TournamentBracket `json:"bracket,omitempty"`
+	PlayerA string                   `json:"player_a"`
+	PlayerB string                   `json:"player_b"`
+	RoomId  string                   `json:"room_id"`
+	Winner  string                   `json:"winner"`
+}
+
+type TournamentBracketResponse struct {
+	TournamentId string                        `json:"tournament_id"`
+	Name         string                        `json:"name"`
+	Format       domain.TournamentFormat       `json:"format"`
+	Status       domain.TournamentStatus       `json:"status"`
+	Rules        TournamentRulesResponse       `json:"rules"`
+	Players      []PlayerResponse              `json:"players"`
+	Rounds       [][]TournamentPairingResponse `json:"rounds"`
+	Winner       string                        `json:"winner"`
+}
+
+type TournamentStandingResponse struct {
+	Handle     string `json:"handle"`
+	Username   string `json:"username"`
+	Played     int    `json:"played"`
+	Wins       int    `json:"wins"`
+	Losses     int    `json:"losses"`
+	Byes       int    `json:"byes"`
+	Points     int    `json:"points"`
+	Eliminated bool   `json:"eliminated"`
+}
+
+type TournamentStandingsResponse struct {
+	TournamentId string                       `json:"tournament_id"`
+	Status       domain.TournamentStatus      `json:"status"`
+	Winner       string                       `json:"winner"`
+	Standings    []TournamentStandingResponse `json:"standings"`
+}
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/ratelimit"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/store"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+const MIN_ADMIN_TOKEN_LENGTH = 16
+
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	GracefulTimeout   time.Duration `yaml:"graceful_timeout"`
+	DrainDelay        time.Duration `yaml:"drain_delay"`
+	ReadinessTimeout  time.Duration `yaml:"readiness_timeout"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+type RedisTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	ServerName         string `yaml:"server_name"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+type RedisConfig struct {
+	Mode             string         `yaml:"mode"`
+	Addrs            []string       `yaml:"addrs"`
+	MasterName       string         `yaml:"master_name"`
+	Username         string         `yaml:"username"`
+	Password         string         `yaml:"password"`
+	SentinelUsername string         `yaml:"sentinel_username"`
+	SentinelPassword string         `yaml:"sentinel_password"`
+	DB               int            `yaml:"db"`
+	TLS              RedisTLSConfig `yaml:"tls"`
+	PoolSize         int            `yaml:"pool_size"`
+	MinIdleConns     int            `yaml:"min_idle_conns"`
+	DialTimeout      time.Duration  `yaml:"dial_timeout"`
+	ReadTimeout      time.Duration  `yaml:"read_timeout"`
+	WriteTimeout     time.Duration  `yaml:"write_timeout"`
+}
+
+type RoomsConfig struct {
+	WaitingTTL    time.Duration `yaml:"waiting_ttl"`
+	PlayingTTL    time.Duration `yaml:"playing_ttl"`
+	FinishedTTL   time.Duration `yaml:"finished_ttl"`
+	TombstoneTTL  time.Duration `yaml:"tombstone_ttl"`
+	SweepInterval time.Duration `yaml:"sweep_interval"`
+}
+
+type GameConfig struct {
+	DefaultMode       domain.MatchMode `yaml:"default_mode"`
+	DefaultBestOf     int              `yaml:"default_best_of"`
+	RematchTimeout    time.Duration    `yaml:"rematch_timeout"`
+	RaceFinishTimeout time.Duration    `yaml:"race_finish_timeout"`
+}
+
+type DailyConfig struct {
+	Seed string `yaml:"seed"`
+}
+
+type RateLimitConfig struct {
+	Enabled     bool             `yaml:"enabled"`
+	TrustProxy  bool             `yaml:"trust_proxy"`
+	TrustedHops int              `yaml:"trusted_hops"`
+	Rules       []ratelimit.Rule `yaml:"rules"`
+}
+
+// ProxyHops returns how many X-Forwarded-For entries can be trusted: the proxies in
+// front of the server when TrustProxy is on, none otherwise.
+func (c RateLimitConfig) ProxyHops() int {
+	if !c.TrustProxy {
+		return 0
+	}
+	return c.TrustedHops
+}
+
+type AdminToken struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+}
+
+type AdminConfig struct {
+	Tokens []AdminToken `yaml:"tokens"`
+}
+
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Addr    string `yaml:"addr"`
+}
+
+type DocsConfig struct {
+	SwaggerUI bool `yaml:"swagger_ui"`
+}
+
+type Config struct {
+	HTTP      HTTPConfig      `yaml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Rooms     RoomsConfig     `yaml:"rooms"`
+	Game      GameConfig      `yaml:"game"`
+	Daily     DailyConfig     `yaml:"daily"`
+	Tracing   tracing.Config  `yaml:"tracing"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Admin     AdminConfig     `yaml:"admin"`
+	Docs      DocsConfig      `yaml:"docs"`
+}
+
+func Default() *Config {
+	return &Config{
+		HTTP: HTTPConfig{
+			Addr:              ":3000",
+			ReadTimeout:       time.Second * 15,
+			ReadHeaderTimeout: time.Second * 5,
+			WriteTimeout:      time.Second * 15,
+			IdleTimeout:       time.Second * 60,
+			GracefulTimeout:   time.Second * 15,
+			DrainDelay:        time.Second * 5,
+			ReadinessTimeout:  time.Second * 2,
+		},
+		GRPC: GRPCConfig{
+			Enabled: true,
+			Addr:    ":3001",
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{},
+		},
+		Redis: RedisConfig{
+			Mode:         store.RedisModeStandalone,
+			Addrs:        []string{"localhost:6379"},
+			PoolSize:     10,
+			DialTimeout:  time.Second * 5,
+			ReadTimeout:  time.Second * 3,
+			WriteTimeout: time.Second * 3,
+		},
+		Rooms: RoomsConfig{
+			WaitingTTL:    time.Minute * 10,
+			PlayingTTL:    time.Minute * 30,
+			FinishedTTL:   time.Minute * 5,
+			TombstoneTTL:  time.Hour * 24,
+			SweepInterval: time.Second * 30,
+		},
+		Game: GameConfig{
+			DefaultMode:       domain.MatchModeTurns,
+			DefaultBestOf:     1,
+			RematchTimeout:    time.Minute * 2,
+			RaceFinishTimeout: time.Minute * 2,
+		},
+		Tracing: tracing.Config{
+			Exporter:    tracing.ExporterNone,
+			ServiceName: "bullandcows-api",
+			SampleRatio: 1,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:     true,
+			TrustedHops: 1,
+			Rules:       ratelimit.DefaultRules(),
+		},
+	}
+}
+
+// Load starts from the defaults, applies the YAML file when a path is given and then
+// the environment variables, so env always wins. Every problem found is reported at
+// once instead of failing on the first one.
+func Load(path string) (*Config, error) {
+	config := Default()
+
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	env := &envLoader{}
+	config.loadEnv(env)
+
+	if err := errors.Join(append(env.errs, config.Validate()...)...); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("config file %v: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *Config) loadEnv(env *envLoader) {
+	env.string("API_ADDR", &c.HTTP.Addr)
+	env.bool("GRPC_ENABLED", &c.GRPC.Enabled)
+	env.string("GRPC_ADDR", &c.GRPC.Addr)
+	env.duration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout)
+	env.duration("HTTP_READ_HEADER_TIMEOUT", &c.HTTP.ReadHeaderTimeout)
+	env.duration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout)
+	env.duration("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout)
+	env.duration("HTTP_GRACEFUL_TIMEOUT", &c.HTTP.GracefulTimeout)
+	env.duration("HTTP_DRAIN_DELAY", &c.HTTP.DrainDelay)
+	env.duration("HTTP_READINESS_TIMEOUT", &c.HTTP.ReadinessTimeout)
+
+	env.list("ALLOWED_HOST", &c.CORS.AllowedOrigins)
+
+	env.string("DB_MATCHES_MODE", &c.Redis.Mode)
+	env.list("DB_MATCHES", &c.Redis.Addrs)
+	env.string("DB_MATCHES_MASTER_NAME", &c.Redis.MasterName)
+	env.string("DB_MATCHES_USERNAME", &c.Redis.Username)
+	env.string("DB_MATCHES_PWD", &c.Redis.Password)
+	env.string("DB_MATCHES_SENTINEL_USERNAME", &c.Redis.SentinelUsername)
+	env.string("DB_MATCHES_SENTINEL_PWD", &c.Redis.SentinelPassword)
+	env.int("DB_MATCHES_DB", &c.Redis.DB)
+	env.bool("DB_MATCHES_TLS", &c.Redis.TLS.Enabled)
+	env.string("DB_MATCHES_TLS_SERVER_NAME", &c.Redis.TLS.ServerName)
+	env.string("DB_MATCHES_TLS_CA_FILE", &c.Redis.TLS.CAFile)
+	env.string("DB_MATCHES_TLS_CERT_FILE", &c.Redis.TLS.CertFile)
+	env.string("DB_MATCHES_TLS_KEY_FILE", &c.Redis.TLS.KeyFile)
+	env.bool("DB_MATCHES_TLS_INSECURE_SKIP_VERIFY", &c.Redis.TLS.InsecureSkipVerify)
+	env.int("DB_MATCHES_POOL_SIZE", &c.Redis.PoolSize)
+	env.int("DB_MATCHES_MIN_IDLE_CONNS", &c.Redis.MinIdleConns)
+	env.duration("DB_MATCHES_DIAL_TIMEOUT", &c.Redis.DialTimeout)
+	env.duration("DB_MATCHES_READ_TIMEOUT", &c.Redis.ReadTimeout)
+	env.duration("DB_MATCHES_WRITE_TIMEOUT", &c.Redis.WriteTimeout)
+
+	env.duration("ROOM_WAITING_TTL", &c.Rooms.WaitingTTL)
+	env.duration("ROOM_PLAYING_TTL", &c.Rooms.PlayingTTL)
+	env.duration("ROOM_FINISHED_TTL", &c.Rooms.FinishedTTL)
+	env.duration("ROOM_TOMBSTONE_TTL", &c.Rooms.TombstoneTTL)
+	env.duration("ROOM_SWEEP_INTERVAL", &c.Rooms.SweepInterval)
+
+	mode := string(c.Game.DefaultMode)
+	env.string("GAME_DEFAULT_MODE", &mode)
+	c.Game.DefaultMode = domain.MatchMode(mode)
+	env.int("GAME_DEFAULT_BEST_OF", &c.Game.DefaultBestOf)
+	env.duration("GAME_REMATCH_TIMEOUT", &c.Game.RematchTimeout)
+	env.duration("GAME_RACE_FINISH_TIMEOUT", &c.Game.RaceFinishTimeout)
+
+	env.string("DAILY_SEED", &c.Daily.Seed)
+
+	env.string("OTEL_TRACES_EXPORTER", &c.Tracing.Exporter)
+	env.string("OTEL_SERVICE_NAME", &c.Tracing.ServiceName)
+	env.float("OTEL_TRACES_SAMPLER_ARG", &c.Tracing.SampleRatio)
+
+	env.bool("RATE_LIMIT_ENABLED", &c.RateLimit.Enabled)
+	env.bool("RATE_LIMIT_TRUST_PROXY", &c.RateLimit.TrustProxy)
+	env.int("RATE_LIMIT_TRUSTED_HOPS", &c.RateLimit.TrustedHops)
+	env.rateLimitRules("RATE_LIMITS", &c.RateLimit.Rules)
+
+	env.adminTokens("ADMIN_TOKENS", &c.Admin.Tokens)
+
+	env.bool("DOCS_SWAGGER_UI", &c.Docs.SwaggerUI)
+}
+
+func (c *Config) Validate() []error {
+	errs := []error{}
+
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.HTTP.Addr != "", "http.addr is required")
+	check(c.HTTP.ReadTimeout > 0, "http.read_timeout must be positive")
+	check(c.HTTP.ReadHeaderTimeout > 0, "http.read_header_timeout must be positive")
+	check(c.HTTP.WriteTimeout > 0, "http.write_timeout must be positive")
+	check(c.HTTP.IdleTimeout > 0, "http.idle_timeout must be positive")
+	check(c.HTTP.GracefulTimeout > 0, "http.graceful_timeout must be positive")
+	check(c.HTTP.DrainDelay >= 0, "http.drain_delay can not be negative")
+	check(c.HTTP.ReadinessTimeout > 0, "http.readiness_timeout must be positive")
+
+	if c.GRPC.Enabled {
+		check(c.GRPC.Addr != "", "grpc.addr is required when grpc is enabled")
+		check(c.GRPC.Addr != c.HTTP.Addr, "grpc.addr must be different from http.addr")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(origin != "", "cors.allowed_origins can not contain empty values")
+	}
+
+	switch c.Redis.Mode {
+	case store.RedisModeStandalone:
+		check(len(c.Redis.Addrs) == 1, "redis.addrs must have exactly one address in %v mode", store.RedisModeStandalone)
+	case store.RedisModeSentinel:
+		check(len(c.Redis.Addrs) > 0, "redis.addrs must list the sentinel addresses in %v mode", store.RedisModeSentinel)
+		check(c.Redis.MasterName != "", "redis.master_name is required in %v mode", store.RedisModeSentinel)
+	case store.RedisModeCluster:
+		check(len(c.Redis.Addrs) > 0, "redis.addrs must list at least one node in %v mode", store.RedisModeCluster)
+		check(c.Redis.DB == 0, "redis.db must be 0 in %v mode", store.RedisModeCluster)
+	default:
+		check(false, "redis.mode must be %v, %v or %v", store.RedisModeStandalone, store.RedisModeSentinel, store.RedisModeCluster)
+	}
+	for _, addr := range c.Redis.Addrs {
+		check(addr != "", "redis.addrs can not contain empty values")
+	}
+	check(c.Redis.DB >= 0, "redis.db can not be negative")
+	check(c.Redis.PoolSize > 0, "redis.pool_size must be positive")
+	check(c.Redis.MinIdleConns >= 0 && c.Redis.MinIdleConns <= c.Redis.PoolSize, "redis.min_idle_conns must be between 0 and redis.pool_size")
+	check(c.Redis.DialTimeout > 0, "redis.dial_timeout must be positive")
+	check(c.Redis.ReadTimeout > 0, "redis.read_timeout must be positive")
+	check(c.Redis.WriteTimeout > 0, "redis.write_timeout must be positive")
+	check(c.Redis.TLS.Enabled || (c.Redis.TLS.CAFile == "" && c.Redis.TLS.CertFile == "" && !c.Redis.TLS.InsecureSkipVerify),
+		"redis.tls settings require redis.tls.enabled")
+	check((c.Redis.TLS.CertFile == "") == (c.Redis.TLS.KeyFile == ""), "redis.tls.cert_file and redis.tls.key_file go together")
+
+	check(c.Rooms.WaitingTTL >= time.Minute, "rooms.waiting_ttl must be at least 1m")
+	check(c.Rooms.PlayingTTL >= time.Minute, "rooms.playing_ttl must be at least 1m")
+	check(c.Rooms.FinishedTTL >= c.Game.RematchTimeout, "rooms.finished_ttl must be at least game.rematch_timeout")
+	check(c.Rooms.PlayingTTL >= c.Game.RaceFinishTimeout, "rooms.playing_ttl must be at least game.race_finish_timeout")
+	check(c.Rooms.TombstoneTTL > 0, "rooms.tombstone_ttl must be positive")
+	check(c.Rooms.SweepInterval > 0, "rooms.sweep_interval must be positive")
+
+	check(c.Game.DefaultMode == domain.MatchModeTurns || c.Game.DefaultMode == domain.MatchModeRace,
+		"game.default_mode must be %v or %v", domain.MatchModeTurns, domain.MatchModeRace)
+	check(c.Game.DefaultBestOf > 0 && c.Game.DefaultBestOf <= 7 && c.Game.DefaultBestOf%2 == 1,
+		"game.default_best_of must be one of 1, 3, 5 or 7")
+	check(c.Game.RematchTimeout > 0, "game.rematch_timeout must be positive")
+	check(c.Game.RaceFinishTimeout > 0, "game.race_finish_timeout must be positive")
+
+	check(c.Tracing.Exporter == tracing.ExporterNone || c.Tracing.Exporter == tracing.ExporterStdout || c.Tracing.Exporter == tracing.ExporterOTLP,
+		"tracing.exporter must be %v, %v or %v", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP)
+	check(c.Tracing.ServiceName != "", "tracing.service_name is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(c.RateLimit.TrustedHops > 0, "rate_limit.trusted_hops must be positive")
+	for _, rule := range c.RateLimit.Rules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rate_limit.rules: %w", err))
+		}
+	}
+
+	names := make(map[string]bool, len(c.Admin.Tokens))
+	for _, token := range c.Admin.Tokens {
+		check(token.Name != "", "admin.tokens need a name to identify who acted in the audit log")
+		check(!names[token.Name], "admin.tokens name %q is repeated", token.Name)
+		check(len(token.Token) >= MIN_ADMIN_TOKEN_LENGTH, "admin.tokens token for %q must be at least %v characters", token.Name, MIN_ADMIN_TOKEN_LENGTH)
+		names[token.Name] = true
+	}
+
+	return errs
+}
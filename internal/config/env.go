@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/ratelimit"
+)
+
+// envLoader overrides a value only when its variable is set. Malformed values are
+// collected as errors instead of falling back to the default.
+type envLoader struct {
+	errs []error
+}
+
+func (e *envLoader) lookup(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	return strings.TrimSpace(value), ok
+}
+
+func (e *envLoader) fail(key, value string, err error) {
+	e.errs = append(e.errs, fmt.Errorf("%v=%q: %w", key, value, err))
+}
+
+func (e *envLoader) string(key string, dst *string) {
+	if value, ok := e.lookup(key); ok {
+		*dst = value
+	}
+}
+
+func (e *envLoader) int(key string, dst *int) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(key, value, fmt.Errorf("expected an integer"))
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envLoader) float(key string, dst *float64) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.fail(key, value, fmt.Errorf("expected a number"))
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envLoader) bool(key string, dst *bool) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(key, value, fmt.Errorf("expected true or false"))
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envLoader) duration(key string, dst *time.Duration) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.fail(key, value, fmt.Errorf("expected a duration like 30s or 1h"))
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envLoader) list(key string, dst *[]string) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+func (e *envLoader) rateLimitRules(key string, dst *[]ratelimit.Rule) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	rules, err := ratelimit.ParseRules(value)
+	if err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	*dst = rules
+}
+
+// adminTokens reads a comma separated list of name:token pairs.
+func (e *envLoader) adminTokens(key string, dst *[]AdminToken) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	tokens := []AdminToken{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, token, found := strings.Cut(item, ":")
+		if !found {
+			e.errs = append(e.errs, fmt.Errorf("%v: expected name:token pairs", key))
+			return
+		}
+		tokens = append(tokens, AdminToken{Name: strings.TrimSpace(name), Token: strings.TrimSpace(token)})
+	}
+	*dst = tokens
+}
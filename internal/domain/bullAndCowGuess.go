@@ -8,6 +8,17 @@ const (
 	None BullAndCowType = "none"
 )
 
+func (t BullAndCowType) Emoji() string {
+	switch t {
+	case Bull:
+		return "🟩"
+	case Cow:
+		return "🟨"
+	default:
+		return "⬛"
+	}
+}
+
 type BullAndCowGuess struct {
 	Value string
 	Type  BullAndCowType
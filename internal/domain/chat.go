@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChatMessage struct {
+	Id       string
+	PlayerId string
+	Message  string
+	SentAt   time.Time
+}
+
+func NewChatMessage(random RandomSource, playerId string, message string, sentAt time.Time) (ChatMessage, error) {
+	id, err := uuid.NewRandomFromReader(random)
+	if err != nil {
+		return ChatMessage{}, err
+	}
+
+	return ChatMessage{
+		Id:       id.String(),
+		PlayerId: playerId,
+		Message:  message,
+		SentAt:   sentAt,
+	}, nil
+}
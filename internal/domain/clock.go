@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same instant, replays and tests move it by hand.
+type FixedClock struct {
+	At time.Time
+}
+
+func (c *FixedClock) Now() time.Time {
+	return c.At
+}
+
+func (c *FixedClock) Advance(d time.Duration) {
+	c.At = c.At.Add(d)
+}
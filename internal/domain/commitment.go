@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"strings"
+)
+
+const COMMITMENT_SALT_BYTES = 16
+
+// SecretCommitment binds a secret before the match starts: the digest is published
+// while it is played and the salt is revealed with the secret once it is over, so
+// anyone can check the secret never changed.
+type SecretCommitment struct {
+	Digest string
+	Salt   string
+}
+
+// MatchCommitments is keyed by the player who set the secret.
+type MatchCommitments map[string]SecretCommitment
+
+// CommitSecret returns the hex encoded SHA-256 of the secret followed by the salt.
+func CommitSecret(secret string, salt string) string {
+	sum := sha256.Sum256([]byte(secret + salt))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewSecretCommitment(random RandomSource, secret string) (SecretCommitment, error) {
+	salt := make([]byte, COMMITMENT_SALT_BYTES)
+	if _, err := io.ReadFull(random, salt); err != nil {
+		return SecretCommitment{}, err
+	}
+
+	plainSalt := hex.EncodeToString(salt)
+	return SecretCommitment{
+		Digest: CommitSecret(secret, plainSalt),
+		Salt:   plainSalt,
+	}, nil
+}
+
+func VerifyCommitment(digest string, secret string, salt string) bool {
+	expected := CommitSecret(secret, salt)
+	return subtle.ConstantTimeCompare([]byte(strings.ToLower(digest)), []byte(expected)) == 1
+}
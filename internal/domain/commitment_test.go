@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+func TestCommitSecret(t *testing.T) {
+	// echo -n 123400ff | sha256sum
+	const want = "5da33a23e1c6316c8d6beaf089cd0897d4256e30b94e124e82e6c03c55ee5045"
+
+	if got := CommitSecret("1234", "00ff"); got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestVerifyCommitment(t *testing.T) {
+	commitment, err := NewSecretCommitment(NewSeededRandom(1), "1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commitment.Salt) != 2*COMMITMENT_SALT_BYTES {
+		t.Fatalf("expected a %v byte hex salt, got %q", COMMITMENT_SALT_BYTES, commitment.Salt)
+	}
+
+	tests := []struct {
+		name   string
+		digest string
+		secret string
+		salt   string
+		want   bool
+	}{
+		{"revealed secret", commitment.Digest, "1234", commitment.Salt, true},
+		{"changed secret", commitment.Digest, "4321", commitment.Salt, false},
+		{"changed salt", commitment.Digest, "1234", "00ff", false},
+		{"empty digest", "", "1234", commitment.Salt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyCommitment(tt.digest, tt.secret, tt.salt); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateDailyCommitment(t *testing.T) {
+	commitment := GenerateDailyCommitment("seed", "2024-03-01")
+
+	if commitment != GenerateDailyCommitment("seed", "2024-03-01") {
+		t.Fatal("expected every instance to publish the same commitment for the day")
+	}
+	if commitment == GenerateDailyCommitment("seed", "2024-03-02") {
+		t.Fatal("expected another commitment the next day")
+	}
+	if !VerifyCommitment(commitment.Digest, GenerateDailyCombination("seed", "2024-03-01"), commitment.Salt) {
+		t.Fatalf("expected the commitment to the secret of the day, got %+v", commitment)
+	}
+}
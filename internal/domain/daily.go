@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DAILY_MAX_GUESSES  = 10
+	DAILY_DAY_LAYOUT   = "2006-01-02"
+	DAILY_GRACE_PERIOD = time.Hour
+)
+
+type DailyAttempt struct {
+	Day       string
+	Username  string
+	Guesses   []GuessesHistoryItem
+	Solved    bool
+	Finished  bool
+	StartedAt time.Time
+}
+
+// DailyPlayer claims a username for the daily challenge. The token is issued on the
+// first start and has to come with every later start and guess; Day points at the
+// attempt the player is playing, which may have started before midnight.
+type DailyPlayer struct {
+	Token string
+	Day   string
+}
+
+type DailyStats struct {
+	Played        int
+	Wins          int
+	CurrentStreak int
+	MaxStreak     int
+	LastWonDay    string
+	Distribution  map[int]int
+}
+
+func GetDay(now time.Time) string {
+	return now.UTC().Format(DAILY_DAY_LAYOUT)
+}
+
+// IsDailyPlayable reports whether an attempt of day still takes guesses at now. An
+// attempt started just before midnight gets DAILY_GRACE_PERIOD into the next day;
+// after that no attempt of day can be played and its secret can be revealed.
+func IsDailyPlayable(day string, now time.Time) bool {
+	start, err := time.Parse(DAILY_DAY_LAYOUT, day)
+	if err != nil {
+		return false
+	}
+	return now.Before(start.Add(24*time.Hour + DAILY_GRACE_PERIOD))
+}
+
+// GenerateDailyCombination derives the secret of the day from the server seed, so
+// every instance serves the same puzzle without storing it. The first digit is
+// never zero because guesses travel as integers.
+func GenerateDailyCombination(seed, day string) string {
+	digits := make([]byte, 0, 4)
+	used := make(map[byte]bool)
+
+	for counter := uint64(0); len(digits) < 4; counter++ {
+		mac := hmac.New(sha256.New, []byte(seed))
+		mac.Write([]byte(day))
+		binary.Write(mac, binary.BigEndian, counter)
+
+		for _, b := range mac.Sum(nil) {
+			digit := b % 10
+			if used[digit] || (len(digits) == 0 && digit == 0) {
+				continue
+			}
+			used[digit] = true
+			digits = append(digits, '0'+digit)
+			if len(digits) == 4 {
+				break
+			}
+		}
+	}
+
+	return string(digits)
+}
+
+// GenerateDailyCommitment commits to the secret of the day. The salt is derived from
+// the seed as well, so every instance publishes the same digest.
+func GenerateDailyCommitment(seed, day string) SecretCommitment {
+	mac := hmac.New(sha256.New, []byte(seed))
+	mac.Write([]byte("salt:" + day))
+	salt := hex.EncodeToString(mac.Sum(nil)[:COMMITMENT_SALT_BYTES])
+
+	return SecretCommitment{
+		Digest: CommitSecret(GenerateDailyCombination(seed, day), salt),
+		Salt:   salt,
+	}
+}
+
+func (a *DailyAttempt) Remaining() int {
+	return DAILY_MAX_GUESSES - len(a.Guesses)
+}
+
+func (a *DailyAttempt) AddGuess(item GuessesHistoryItem) {
+	a.Guesses = append(a.Guesses, item)
+	a.Solved = item.IsWinnerCombination
+	a.Finished = a.Solved || a.Remaining() <= 0
+}
+
+func (a *DailyAttempt) ShareSummary() string {
+	result := "X"
+	if a.Solved {
+		result = fmt.Sprint(len(a.Guesses))
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Bulls & Cows %v %v/%v", a.Day, result, DAILY_MAX_GUESSES)
+	for _, item := range a.Guesses {
+		builder.WriteString("\n")
+		for _, guess := range item.Guess {
+			builder.WriteString(guess.Type.Emoji())
+		}
+	}
+	return builder.String()
+}
+
+func NewDailyStats() *DailyStats {
+	return &DailyStats{
+		Distribution: make(map[int]int),
+	}
+}
+
+func (s *DailyStats) Record(attempt *DailyAttempt) {
+	s.Played++
+
+	if !attempt.Solved {
+		s.CurrentStreak = 0
+		return
+	}
+
+	s.Wins++
+	s.Distribution[len(attempt.Guesses)]++
+
+	if s.LastWonDay == previousDay(attempt.Day) {
+		s.CurrentStreak++
+	} else {
+		s.CurrentStreak = 1
+	}
+	s.LastWonDay = attempt.Day
+
+	if s.CurrentStreak > s.MaxStreak {
+		s.MaxStreak = s.CurrentStreak
+	}
+}
+
+// StreakOn returns the streak as seen on the given day: it is broken once a whole
+// day goes by without a win.
+func (s *DailyStats) StreakOn(day string) int {
+	if s.LastWonDay == day || s.LastWonDay == previousDay(day) {
+		return s.CurrentStreak
+	}
+	return 0
+}
+
+func previousDay(day string) string {
+	parsed, err := time.Parse(DAILY_DAY_LAYOUT, day)
+	if err != nil {
+		return ""
+	}
+	return parsed.AddDate(0, 0, -1).Format(DAILY_DAY_LAYOUT)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDailyPlayable(t *testing.T) {
+	tests := []struct {
+		name string
+		day  string
+		now  time.Time
+		want bool
+	}{
+		{"during the day", "2024-03-09", time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC), true},
+		{"within the grace period", "2024-03-09", time.Date(2024, 3, 10, 0, 59, 59, 0, time.UTC), true},
+		{"after the grace period", "2024-03-09", time.Date(2024, 3, 10, 1, 0, 0, 0, time.UTC), false},
+		{"days before", "2024-03-07", time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC), false},
+		{"malformed day", "09-03-2024", time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDailyPlayable(tt.day, tt.now); got != tt.want {
+				t.Fatalf("IsDailyPlayable(%q, %v) = %v, want %v", tt.day, tt.now, got, tt.want)
+			}
+		})
+	}
+}
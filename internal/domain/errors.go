@@ -3,5 +3,6 @@ package domain
 import "fmt"
 
 var (
-	ErrEmptyResult = fmt.Errorf("empty result")
+	ErrEmptyResult   = fmt.Errorf("empty result")
+	ErrAlreadyExists = fmt.Errorf("already exists")
 )
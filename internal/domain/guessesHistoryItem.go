@@ -1,6 +1,10 @@
 package domain
 
+import "time"
+
 type GuessesHistoryItem struct {
 	Guess               []BullAndCowGuess
 	IsWinnerCombination bool
+	Round               int
+	PlayedAt            time.Time
 }
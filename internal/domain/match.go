@@ -1,9 +1,8 @@
 package domain
 
 import (
-	"crypto/rand"
 	"fmt"
-	"math/big"
+	"sort"
 	"time"
 )
 
@@ -25,6 +24,18 @@ const (
 	MatchStateFinished = MatchStatus("Finished")
 )
 
+const (
+	MATCH_ID_LENGTH  = 7
+	MATCH_ID_CHARSET = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+type MatchMode string
+
+const (
+	MatchModeTurns = MatchMode("Turns")
+	MatchModeRace  = MatchMode("Race")
+)
+
 type Match struct {
 	RoomId                string
 	Players               MatchPlayers
@@ -32,19 +43,30 @@ type Match struct {
 	Guesses               MatchGuesses
 	Status                MatchStatus
 	IsTurnOf              string
+	Mode                  MatchMode
+	Winner                string
+	Series                MatchSeries
+	StartedBy             string
+	RematchOffer          *RematchOffer
+	Commitments           MatchCommitments
+	// TournamentId is set on the rooms a tournament opens for its pairings
+	TournamentId string
 }
 
-func GenerateMatchId() (string, error) {
-	const lenght = 7
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+type ExpiredRoom struct {
+	RoomId     string
+	LastStatus MatchStatus
+	ExpiredAt  time.Time
+}
 
-	result := make([]byte, lenght)
-	for i := 0; i < lenght; i++ {
-		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+func GenerateMatchId(random RandomSource) (string, error) {
+	result := make([]byte, MATCH_ID_LENGTH)
+	for i := 0; i < MATCH_ID_LENGTH; i++ {
+		num, err := random.IntN(len(MATCH_ID_CHARSET))
 		if err != nil {
 			return "", err
 		}
-		result[i] = charset[num.Int64()]
+		result[i] = MATCH_ID_CHARSET[num]
 	}
 	return string(result), nil
 }
@@ -65,23 +87,95 @@ func ValidateCombination(combination string) error {
 	return nil
 }
 
-func (m *Match) GetRandomUser() (string, error) {
+func (m *Match) GetRandomUser(random RandomSource) (string, error) {
 	if len(m.Players) != 2 {
 		return "", fmt.Errorf("")
 	}
 
-	now := time.Now().UnixNano()
-	index := now % 2
-
 	values := make([]string, 0, len(m.Players))
 	for _, player := range m.Players {
 		values = append(values, player.Id)
 	}
+	// map order changes between runs, the same source must pick the same player
+	sort.Strings(values)
+
+	index, err := random.IntN(len(values))
+	if err != nil {
+		return "", err
+	}
 
 	selected := values[index]
 	return selected, nil
 }
 
+// SecretSetBy returns the combination the player chose, the one the opponent has to
+// guess.
+func (m *Match) SecretSetBy(playerId string) string {
+	for opponentId := range m.Players {
+		if opponentId != playerId {
+			return m.OpponentsCombinations[opponentId]
+		}
+	}
+	return ""
+}
+
+// Finish ends the game and records the round in the series; an empty winner records
+// a round nobody won.
+func (m *Match) Finish(winner string) {
+	m.Winner = winner
+	m.Status = MatchStateFinished
+	m.Series.RecordRound(winner, len(m.Guesses[winner]), m.StartedBy)
+}
+
+func (m *Match) HasSolved(playerId string) bool {
+	guesses := m.Guesses[playerId]
+	return len(guesses) > 0 && guesses[len(guesses)-1].IsWinnerCombination
+}
+
+// ResolveRaceWinner returns the winner of a race match, or an empty string while
+// the result is still open. A player who cracks the code in round N only wins once
+// the opponent has also played round N without doing better, or once the opponent
+// let finishTimeout pass without getting there; ties within the same round are
+// resolved by guess count and then by the time of the winning guess.
+func (m *Match) ResolveRaceWinner(now time.Time, finishTimeout time.Duration) string {
+	type solution struct {
+		playerId string
+		round    int
+		playedAt time.Time
+	}
+
+	solutions := make([]solution, 0, len(m.Players))
+	for playerId := range m.Players {
+		if !m.HasSolved(playerId) {
+			continue
+		}
+		guesses := m.Guesses[playerId]
+		last := guesses[len(guesses)-1]
+		solutions = append(solutions, solution{playerId: playerId, round: len(guesses), playedAt: last.PlayedAt})
+	}
+
+	switch len(solutions) {
+	case 0:
+		return ""
+	case 1:
+		for playerId := range m.Players {
+			if playerId == solutions[0].playerId {
+				continue
+			}
+			if len(m.Guesses[playerId]) < solutions[0].round && now.Before(solutions[0].playedAt.Add(finishTimeout)) {
+				return ""
+			}
+		}
+		return solutions[0].playerId
+	}
+
+	first, second := solutions[0], solutions[1]
+	if second.round < first.round || (second.round == first.round && second.playedAt.Before(first.playedAt)) {
+		return second.playerId
+	}
+	return first.playerId
+}
+
 func (m *Match) GetNewGuess(guess, comparedCombination string) (*GuessesHistoryItem, error) {
 	combinationMap := make(map[rune]rune)
 
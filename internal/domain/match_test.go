@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateCombination(t *testing.T) {
+	tests := []struct {
+		name        string
+		combination string
+		want        error
+	}{
+		{"valid", "1234", nil},
+		{"valid with zero", "9012", nil},
+		{"empty", "", ErrInvalidCombination},
+		{"too short", "123", ErrInvalidCombination},
+		{"too long", "12345", ErrInvalidCombination},
+		{"repeated digit", "1123", ErrInvalidUniqueCombination},
+		{"repeated apart", "1231", ErrInvalidUniqueCombination},
+		{"all the same", "7777", ErrInvalidUniqueCombination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCombination(tt.combination); !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateCombination(%q) = %v, want %v", tt.combination, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNewGuess(t *testing.T) {
+	tests := []struct {
+		name     string
+		guess    string
+		secret   string
+		want     []BullAndCowType
+		isWinner bool
+		err      error
+	}{
+		{"all bulls", "1234", "1234", []BullAndCowType{Bull, Bull, Bull, Bull}, true, nil},
+		{"all cows", "1234", "4321", []BullAndCowType{Cow, Cow, Cow, Cow}, false, nil},
+		{"nothing", "1234", "5678", []BullAndCowType{None, None, None, None}, false, nil},
+		{"bulls and cows", "1234", "1243", []BullAndCowType{Bull, Bull, Cow, Cow}, false, nil},
+		{"mixed", "1596", "1960", []BullAndCowType{Bull, None, Cow, Cow}, false, nil},
+		{"short guess", "123", "1234", nil, false, ErrInvalidCombination},
+		{"long secret", "1234", "12345", nil, false, ErrInvalidCombination},
+	}
+
+	match := &Match{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := match.GetNewGuess(tt.guess, tt.secret)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GetNewGuess(%q, %q) error = %v, want %v", tt.guess, tt.secret, err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			if len(item.Guess) != len(tt.want) {
+				t.Fatalf("expected %v digits, got %v", len(tt.want), len(item.Guess))
+			}
+			for i, digit := range item.Guess {
+				if digit.Value != string(tt.guess[i]) || digit.Type != tt.want[i] {
+					t.Errorf("digit %v = %v %v, want %v %v", i, digit.Value, digit.Type, string(tt.guess[i]), tt.want[i])
+				}
+			}
+			if item.IsWinnerCombination != tt.isWinner {
+				t.Errorf("IsWinnerCombination = %v, want %v", item.IsWinnerCombination, tt.isWinner)
+			}
+		})
+	}
+}
+
+func TestGetRandomUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		players MatchPlayers
+		wantErr bool
+	}{
+		{"no players", MatchPlayers{}, true},
+		{"one player", MatchPlayers{"p1": {Id: "p1"}}, true},
+		{"two players", MatchPlayers{"p1": {Id: "p1"}, "p2": {Id: "p2"}}, false},
+		{"three players", MatchPlayers{"p1": {Id: "p1"}, "p2": {Id: "p2"}, "p3": {Id: "p3"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &Match{Players: tt.players}
+			selected, err := match.GetRandomUser(NewSeededRandom(1))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", selected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, exists := tt.players[selected]; !exists {
+				t.Fatalf("selected %q is not a player of the match", selected)
+			}
+		})
+	}
+}
+
+func TestGetRandomUserIsDeterministic(t *testing.T) {
+	picked := map[string]bool{}
+
+	for seed := uint64(0); seed < 16; seed++ {
+		match := &Match{Players: MatchPlayers{"p1": {Id: "p1"}, "p2": {Id: "p2"}}}
+		replay := &Match{Players: MatchPlayers{"p2": {Id: "p2"}, "p1": {Id: "p1"}}}
+
+		selected, err := match.GetRandomUser(NewSeededRandom(seed))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		replayed, _ := replay.GetRandomUser(NewSeededRandom(seed))
+		if selected != replayed {
+			t.Fatalf("seed %v picked %q and then %q", seed, selected, replayed)
+		}
+		picked[selected] = true
+	}
+
+	if len(picked) != 2 {
+		t.Fatalf("expected both players to be picked across seeds, got %v", picked)
+	}
+}
+
+func TestGenerateMatchId(t *testing.T) {
+	id, err := GenerateMatchId(NewSeededRandom(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(id) != 7 {
+		t.Fatalf("expected a 7 character id, got %q", id)
+	}
+
+	replayed, _ := GenerateMatchId(NewSeededRandom(1))
+	if replayed != id {
+		t.Fatalf("expected the same seed to generate %q, got %q", id, replayed)
+	}
+
+	random, err := GenerateMatchId(CryptoRandom{})
+	if err != nil || len(random) != 7 {
+		t.Fatalf("expected a 7 character id, got %q, %v", random, err)
+	}
+}
+
+func TestResolveRaceWinner(t *testing.T) {
+	start := time.Now()
+	miss := GuessesHistoryItem{}
+	hit := func(at time.Duration) GuessesHistoryItem {
+		return GuessesHistoryItem{IsWinnerCombination: true, PlayedAt: start.Add(at)}
+	}
+
+	tests := []struct {
+		name    string
+		guesses MatchGuesses
+		now     time.Duration
+		want    string
+	}{
+		{"nobody solved", MatchGuesses{"p1": {miss}, "p2": {miss}}, 0, ""},
+		{"opponent still has to play the round", MatchGuesses{"p1": {miss, hit(0)}, "p2": {miss}}, 0, ""},
+		{"opponent still in time", MatchGuesses{"p1": {miss, hit(0)}, "p2": {miss}}, time.Minute - time.Second, ""},
+		{"opponent ran out of time", MatchGuesses{"p1": {miss, hit(0)}, "p2": {miss}}, time.Minute, "p1"},
+		{"opponent never played", MatchGuesses{"p1": {hit(0)}}, time.Minute, "p1"},
+		{"opponent played the round", MatchGuesses{"p1": {miss, hit(0)}, "p2": {miss, miss}}, 0, "p1"},
+		{"fewer guesses wins", MatchGuesses{"p1": {miss, hit(0)}, "p2": {hit(time.Second)}}, 0, "p2"},
+		{"same round, earlier wins", MatchGuesses{"p1": {hit(time.Second)}, "p2": {hit(0)}}, 0, "p2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &Match{
+				Players: MatchPlayers{"p1": {Id: "p1"}, "p2": {Id: "p2"}},
+				Guesses: tt.guesses,
+			}
+			if got := match.ResolveRaceWinner(start.Add(tt.now), time.Minute); got != tt.want {
+				t.Fatalf("ResolveRaceWinner() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,36 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+)
 
 type Player struct {
 	Id       string
 	Username string
 }
 
-func GeneratePlayerId() string {
-	return uuid.NewString()
+func GeneratePlayerId(random RandomSource) (string, error) {
+	id, err := uuid.NewRandomFromReader(random)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// PlayerHandle is how a player is named in everything other players and spectators
+// can read. The id authenticates every action of the player so it is never
+// published; the handle is derived from it and can not be turned back into it.
+func PlayerHandle(playerId string) string {
+	if playerId == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(playerId))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (p Player) Handle() string {
+	return PlayerHandle(p.Id)
 }
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	mathrand "math/rand/v2"
+	"sync"
+)
+
+// RandomSource backs every random decision of a match: room and player ids, chat
+// message ids and who starts.
+type RandomSource interface {
+	// IntN returns a uniform number in [0, n).
+	IntN(n int) (int, error)
+	Read(p []byte) (int, error)
+}
+
+type CryptoRandom struct{}
+
+func (CryptoRandom) IntN(n int) (int, error) {
+	num, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(num.Int64()), nil
+}
+
+func (CryptoRandom) Read(p []byte) (int, error) {
+	return rand.Read(p)
+}
+
+// SeededRandom gives the same sequence for the same seed, so a match can be replayed
+// exactly. It is not meant for production, ids would be predictable.
+type SeededRandom struct {
+	mu     sync.Mutex
+	source *mathrand.ChaCha8
+	rand   *mathrand.Rand
+}
+
+func NewSeededRandom(seed uint64) *SeededRandom {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+
+	source := mathrand.NewChaCha8(key)
+	return &SeededRandom{
+		source: source,
+		rand:   mathrand.New(source),
+	}
+}
+
+func (r *SeededRandom) IntN(n int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.IntN(n), nil
+}
+
+func (r *SeededRandom) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.source.Read(p)
+}
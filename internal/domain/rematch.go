@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+type RematchOffer struct {
+	OfferedBy string
+	ExpiresAt time.Time
+}
+
+func NewRematchOffer(playerId string, timeout time.Duration, now time.Time) *RematchOffer {
+	return &RematchOffer{
+		OfferedBy: playerId,
+		ExpiresAt: now.Add(timeout),
+	}
+}
+
+func (o *RematchOffer) IsPending(now time.Time) bool {
+	return o != nil && now.Before(o.ExpiresAt)
+}
@@ -0,0 +1,75 @@
+package domain
+
+type RoundResult struct {
+	Round     int
+	Winner    string
+	Guesses   int
+	StartedBy string
+}
+
+type MatchSeries struct {
+	BestOf int
+	Rounds []RoundResult
+	Winner string
+}
+
+func NewMatchSeries(bestOf int) MatchSeries {
+	if bestOf <= 0 {
+		bestOf = 1
+	}
+	return MatchSeries{
+		BestOf: bestOf,
+		Rounds: []RoundResult{},
+	}
+}
+
+// Score counts the rounds won by each player, rounds nobody won are left out.
+func (s *MatchSeries) Score() map[string]int {
+	score := make(map[string]int)
+	for _, round := range s.Rounds {
+		if round.Winner == "" {
+			continue
+		}
+		score[round.Winner]++
+	}
+	return score
+}
+
+func (s *MatchSeries) WinsNeeded() int {
+	return s.BestOf/2 + 1
+}
+
+// IsOver reports whether a best-of-N series has been decided. A single game is
+// not a series, so best-of-1 rooms can keep restarting.
+func (s *MatchSeries) IsOver() bool {
+	return s.BestOf > 1 && s.Winner != ""
+}
+
+func (s *MatchSeries) RecordRound(winner string, guesses int, startedBy string) {
+	s.Rounds = append(s.Rounds, RoundResult{
+		Round:     len(s.Rounds) + 1,
+		Winner:    winner,
+		Guesses:   guesses,
+		StartedBy: startedBy,
+	})
+
+	if winner != "" && s.Score()[winner] >= s.WinsNeeded() {
+		s.Winner = winner
+	}
+}
+
+// NextStarter alternates the starting player between rounds. It returns an empty
+// string for the first round so the caller can pick one at random.
+func (s *MatchSeries) NextStarter(players MatchPlayers) string {
+	if len(s.Rounds) == 0 {
+		return ""
+	}
+
+	previous := s.Rounds[len(s.Rounds)-1].StartedBy
+	for playerId := range players {
+		if playerId != previous {
+			return playerId
+		}
+	}
+	return ""
+}
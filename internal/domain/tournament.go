@@ -0,0 +1,464 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type TournamentFormat string
+
+const (
+	TournamentFormatSingleElimination = TournamentFormat("SingleElimination")
+	TournamentFormatDoubleElimination = TournamentFormat("DoubleElimination")
+	TournamentFormatSwiss             = TournamentFormat("Swiss")
+	TournamentFormatRoundRobin        = TournamentFormat("RoundRobin")
+)
+
+type TournamentStatus string
+
+const (
+	TournamentStateRegistering = TournamentStatus("Registering")
+	TournamentStateRunning     = TournamentStatus("Running")
+	TournamentStateFinished    = TournamentStatus("Finished")
+)
+
+type TournamentBracket string
+
+const (
+	TournamentBracketWinners = TournamentBracket("Winners")
+	TournamentBracketLosers  = TournamentBracket("Losers")
+	TournamentBracketFinal   = TournamentBracket("Final")
+)
+
+type TournamentRules struct {
+	Mode   MatchMode
+	BestOf int
+	Rounds int
+}
+
+type TournamentPairing struct {
+	Round   int
+	Bracket TournamentBracket
+	PlayerA string
+	PlayerB string
+	RoomId  string
+	Winner  string
+}
+
+func (p TournamentPairing) IsBye() bool {
+	return p.PlayerB == ""
+}
+
+func (p TournamentPairing) Loser() string {
+	if p.Winner == "" || p.IsBye() {
+		return ""
+	}
+	if p.Winner == p.PlayerA {
+		return p.PlayerB
+	}
+	return p.PlayerA
+}
+
+type TournamentStanding struct {
+	PlayerId   string
+	Played     int
+	Wins       int
+	Losses     int
+	Byes       int
+	Points     int
+	Eliminated bool
+}
+
+type Tournament struct {
+	Id      string
+	Name    string
+	Format  TournamentFormat
+	Rules   TournamentRules
+	Status  TournamentStatus
+	Players []Player
+	Rounds  [][]TournamentPairing
+	Winner  string
+}
+
+func GenerateTournamentId(random RandomSource) (string, error) {
+	return GenerateMatchId(random)
+}
+
+// TournamentRoomId derives the room of a pairing from its place in the tournament,
+// so opening the rooms of a round can be retried without creating new ones.
+func TournamentRoomId(tournamentId string, round, pairing int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v:%v:%v", tournamentId, round, pairing)))
+
+	result := make([]byte, MATCH_ID_LENGTH)
+	for i := range result {
+		result[i] = MATCH_ID_CHARSET[int(sum[i])%len(MATCH_ID_CHARSET)]
+	}
+	return string(result)
+}
+
+func (t *Tournament) GetPlayer(playerId string) (Player, bool) {
+	for _, player := range t.Players {
+		if player.Id == playerId {
+			return player, true
+		}
+	}
+	return Player{}, false
+}
+
+func (t *Tournament) CurrentRound() []TournamentPairing {
+	if len(t.Rounds) == 0 {
+		return nil
+	}
+	return t.Rounds[len(t.Rounds)-1]
+}
+
+func (t *Tournament) IsCurrentRoundComplete() bool {
+	for _, pairing := range t.CurrentRound() {
+		if pairing.Winner == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Advance pairs the next round once every pairing of the current one has a winner.
+// Byes are decided right away and every other pairing gets its room id. When there
+// is nothing left to pair the tournament is finished and the leader of the
+// standings becomes the winner. It reports whether a new round was added.
+func (t *Tournament) Advance() bool {
+	if t.Status != TournamentStateRunning || !t.IsCurrentRoundComplete() {
+		return false
+	}
+
+	var pairings []TournamentPairing
+
+	switch t.Format {
+	case TournamentFormatSingleElimination:
+		pairings = t.pairSingleElimination()
+	case TournamentFormatDoubleElimination:
+		pairings = t.pairDoubleElimination()
+	case TournamentFormatSwiss:
+		pairings = t.pairSwiss()
+	case TournamentFormatRoundRobin:
+		pairings = t.pairRoundRobin()
+	}
+
+	if len(pairings) == 0 {
+		t.Status = TournamentStateFinished
+		if standings := t.Standings(); len(standings) > 0 {
+			t.Winner = standings[0].PlayerId
+		}
+		return false
+	}
+
+	round := len(t.Rounds) + 1
+	for i := range pairings {
+		pairings[i].Round = round
+		if pairings[i].IsBye() {
+			pairings[i].Winner = pairings[i].PlayerA
+			continue
+		}
+		pairings[i].RoomId = TournamentRoomId(t.Id, round, i)
+	}
+
+	t.Rounds = append(t.Rounds, pairings)
+	return true
+}
+
+// RecordResult sets the winner of the pairing of the current round played in
+// roomId. It reports whether the pairing was still open and winner played it.
+func (t *Tournament) RecordResult(roomId, winner string) bool {
+	round := t.CurrentRound()
+	for i := range round {
+		if round[i].RoomId != roomId || round[i].Winner != "" {
+			continue
+		}
+		if winner != round[i].PlayerA && winner != round[i].PlayerB {
+			return false
+		}
+		round[i].Winner = winner
+		return true
+	}
+	return false
+}
+
+func (t *Tournament) Standings() []TournamentStanding {
+	seeds := make(map[string]int, len(t.Players))
+	standings := make(map[string]*TournamentStanding, len(t.Players))
+	for i, player := range t.Players {
+		seeds[player.Id] = i
+		standings[player.Id] = &TournamentStanding{PlayerId: player.Id}
+	}
+
+	for _, round := range t.Rounds {
+		for _, pairing := range round {
+			if pairing.Winner == "" {
+				continue
+			}
+			if pairing.IsBye() {
+				standings[pairing.PlayerA].Byes++
+				standings[pairing.PlayerA].Wins++
+				continue
+			}
+			standings[pairing.PlayerA].Played++
+			standings[pairing.PlayerB].Played++
+			standings[pairing.Winner].Wins++
+			standings[pairing.Loser()].Losses++
+		}
+	}
+
+	result := make([]TournamentStanding, 0, len(standings))
+	for _, standing := range standings {
+		standing.Points = standing.Wins
+		switch t.Format {
+		case TournamentFormatSingleElimination:
+			standing.Eliminated = standing.Losses >= 1
+		case TournamentFormatDoubleElimination:
+			standing.Eliminated = standing.Losses >= 2
+		}
+		result = append(result, *standing)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Losses != b.Losses {
+			return a.Losses < b.Losses
+		}
+		return seeds[a.PlayerId] < seeds[b.PlayerId]
+	})
+
+	return result
+}
+
+func (t *Tournament) playerIds() []string {
+	ids := make([]string, 0, len(t.Players))
+	for _, player := range t.Players {
+		ids = append(ids, player.Id)
+	}
+	return ids
+}
+
+func (t *Tournament) lossCounts() map[string]int {
+	losses := make(map[string]int, len(t.Players))
+	for _, round := range t.Rounds {
+		for _, pairing := range round {
+			if loser := pairing.Loser(); loser != "" {
+				losses[loser]++
+			}
+		}
+	}
+	return losses
+}
+
+func (t *Tournament) pairSingleElimination() []TournamentPairing {
+	if len(t.Rounds) == 0 {
+		return seededPairs(t.playerIds(), TournamentBracketWinners)
+	}
+
+	alive := make([]string, 0, len(t.CurrentRound()))
+	for _, pairing := range t.CurrentRound() {
+		alive = append(alive, pairing.Winner)
+	}
+
+	if len(alive) <= 1 {
+		return nil
+	}
+	return adjacentPairs(alive, TournamentBracketWinners)
+}
+
+func (t *Tournament) pairDoubleElimination() []TournamentPairing {
+	if len(t.Rounds) == 0 {
+		return seededPairs(t.playerIds(), TournamentBracketWinners)
+	}
+
+	losses := t.lossCounts()
+	winners := []string{}
+	losers := []string{}
+	for _, playerId := range t.playerIds() {
+		switch losses[playerId] {
+		case 0:
+			winners = append(winners, playerId)
+		case 1:
+			losers = append(losers, playerId)
+		}
+	}
+
+	switch len(winners) + len(losers) {
+	case 0, 1:
+		return nil
+	case 2:
+		finalists := append(winners, losers...)
+		return []TournamentPairing{{Bracket: TournamentBracketFinal, PlayerA: finalists[0], PlayerB: finalists[1]}}
+	}
+
+	return append(
+		adjacentPairs(winners, TournamentBracketWinners),
+		adjacentPairs(losers, TournamentBracketLosers)...,
+	)
+}
+
+func (t *Tournament) pairSwiss() []TournamentPairing {
+	rounds := t.Rules.Rounds
+	if rounds <= 0 {
+		rounds = int(math.Ceil(math.Log2(float64(len(t.Players)))))
+	}
+	if rounds < 1 {
+		rounds = 1
+	}
+
+	if len(t.Rounds) >= rounds {
+		return nil
+	}
+
+	played := make(map[string]map[string]bool, len(t.Players))
+	hadBye := make(map[string]bool, len(t.Players))
+	for _, round := range t.Rounds {
+		for _, pairing := range round {
+			if pairing.IsBye() {
+				hadBye[pairing.PlayerA] = true
+				continue
+			}
+			if played[pairing.PlayerA] == nil {
+				played[pairing.PlayerA] = make(map[string]bool)
+			}
+			if played[pairing.PlayerB] == nil {
+				played[pairing.PlayerB] = make(map[string]bool)
+			}
+			played[pairing.PlayerA][pairing.PlayerB] = true
+			played[pairing.PlayerB][pairing.PlayerA] = true
+		}
+	}
+
+	ranking := make([]string, 0, len(t.Players))
+	for _, standing := range t.Standings() {
+		ranking = append(ranking, standing.PlayerId)
+	}
+
+	pairings := []TournamentPairing{}
+
+	if len(ranking)%2 == 1 {
+		byeIndex := len(ranking) - 1
+		for i := len(ranking) - 1; i >= 0; i-- {
+			if !hadBye[ranking[i]] {
+				byeIndex = i
+				break
+			}
+		}
+		pairings = append(pairings, TournamentPairing{PlayerA: ranking[byeIndex]})
+		ranking = append(ranking[:byeIndex:byeIndex], ranking[byeIndex+1:]...)
+	}
+
+	if rematchFree := pairUnplayed(ranking, played); rematchFree != nil {
+		return append(pairings, rematchFree...)
+	}
+
+	// every way of pairing the ranking repeats a game, fall back to pairing neighbours
+	// and avoid rematches where that is still possible
+	paired := make(map[string]bool, len(ranking))
+	for i, playerId := range ranking {
+		if paired[playerId] {
+			continue
+		}
+
+		opponent := ""
+		for _, candidate := range ranking[i+1:] {
+			if paired[candidate] {
+				continue
+			}
+			if opponent == "" {
+				opponent = candidate
+			}
+			if !played[playerId][candidate] {
+				opponent = candidate
+				break
+			}
+		}
+
+		paired[playerId] = true
+		paired[opponent] = true
+		pairings = append(pairings, TournamentPairing{PlayerA: playerId, PlayerB: opponent})
+	}
+
+	return pairings
+}
+
+// pairUnplayed pairs the ranking, an even number of players, so nobody meets a
+// previous opponent. It prefers the closest ranked opponent and returns nil when
+// there is no such pairing.
+func pairUnplayed(ranking []string, played map[string]map[string]bool) []TournamentPairing {
+	if len(ranking) == 0 {
+		return []TournamentPairing{}
+	}
+
+	playerId := ranking[0]
+	for i := 1; i < len(ranking); i++ {
+		if played[playerId][ranking[i]] {
+			continue
+		}
+
+		rest := append(append([]string{}, ranking[1:i]...), ranking[i+1:]...)
+		if pairings := pairUnplayed(rest, played); pairings != nil {
+			return append([]TournamentPairing{{PlayerA: playerId, PlayerB: ranking[i]}}, pairings...)
+		}
+	}
+	return nil
+}
+
+func (t *Tournament) pairRoundRobin() []TournamentPairing {
+	ids := t.playerIds()
+	if len(ids)%2 == 1 {
+		ids = append(ids, "")
+	}
+
+	if len(t.Rounds) >= len(ids)-1 {
+		return nil
+	}
+
+	rotating := ids[1:]
+	shift := len(t.Rounds) % len(rotating)
+	arrangement := append([]string{ids[0]}, append(append([]string{}, rotating[len(rotating)-shift:]...), rotating[:len(rotating)-shift]...)...)
+
+	pairings := []TournamentPairing{}
+	for i := 0; i < len(arrangement)/2; i++ {
+		playerA, playerB := arrangement[i], arrangement[len(arrangement)-1-i]
+		if playerA == "" {
+			playerA, playerB = playerB, playerA
+		}
+		pairings = append(pairings, TournamentPairing{PlayerA: playerA, PlayerB: playerB})
+	}
+	return pairings
+}
+
+// seededPairs pairs the first seed against the last one; with an odd number of
+// players the top seed gets the bye.
+func seededPairs(ids []string, bracket TournamentBracket) []TournamentPairing {
+	pairings := []TournamentPairing{}
+	if len(ids)%2 == 1 {
+		pairings = append(pairings, TournamentPairing{Bracket: bracket, PlayerA: ids[0]})
+		ids = ids[1:]
+	}
+	for i := 0; i < len(ids)/2; i++ {
+		pairings = append(pairings, TournamentPairing{Bracket: bracket, PlayerA: ids[i], PlayerB: ids[len(ids)-1-i]})
+	}
+	return pairings
+}
+
+func adjacentPairs(ids []string, bracket TournamentBracket) []TournamentPairing {
+	pairings := []TournamentPairing{}
+	for i := 0; i < len(ids); i += 2 {
+		pairing := TournamentPairing{Bracket: bracket, PlayerA: ids[i]}
+		if i+1 < len(ids) {
+			pairing.PlayerB = ids[i+1]
+		}
+		pairings = append(pairings, pairing)
+	}
+	return pairings
+}
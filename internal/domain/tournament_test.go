@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func newTestTournament(format TournamentFormat, players int, rules TournamentRules) *Tournament {
+	tournament := &Tournament{
+		Id:     "tourney",
+		Format: format,
+		Rules:  rules,
+		Status: TournamentStateRunning,
+	}
+	for i := 1; i <= players; i++ {
+		tournament.Players = append(tournament.Players, Player{Id: fmt.Sprintf("p%v", i)})
+	}
+	return tournament
+}
+
+// playTournament starts the tournament and plays every room, the better seed always
+// wins. It fails the test when the tournament does not finish.
+func playTournament(t *testing.T, tournament *Tournament) {
+	t.Helper()
+
+	seeds := make(map[string]int, len(tournament.Players))
+	for i, player := range tournament.Players {
+		seeds[player.Id] = i
+	}
+
+	for tournament.Advance() {
+	}
+
+	for i := 0; tournament.Status == TournamentStateRunning; i++ {
+		if i > 100 {
+			t.Fatalf("tournament did not finish after %v rounds", len(tournament.Rounds))
+		}
+
+		for _, pairing := range tournament.CurrentRound() {
+			if pairing.Winner != "" {
+				continue
+			}
+			winner := pairing.PlayerA
+			if seeds[pairing.PlayerB] < seeds[winner] {
+				winner = pairing.PlayerB
+			}
+			if !tournament.RecordResult(pairing.RoomId, winner) {
+				t.Fatalf("RecordResult(%q, %q) = false on round %v", pairing.RoomId, winner, pairing.Round)
+			}
+		}
+
+		for tournament.Advance() {
+		}
+	}
+}
+
+func TestTournamentFirstRound(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  TournamentFormat
+		players int
+		bye     string
+		games   [][2]string
+	}{
+		{"single elimination even", TournamentFormatSingleElimination, 4, "", [][2]string{{"p1", "p4"}, {"p2", "p3"}}},
+		{"single elimination odd", TournamentFormatSingleElimination, 5, "p1", [][2]string{{"p2", "p5"}, {"p3", "p4"}}},
+		{"double elimination odd", TournamentFormatDoubleElimination, 3, "p1", [][2]string{{"p2", "p3"}}},
+		{"swiss odd", TournamentFormatSwiss, 3, "p3", [][2]string{{"p1", "p2"}}},
+		{"round robin odd", TournamentFormatRoundRobin, 3, "p1", [][2]string{{"p2", "p3"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := newTestTournament(tt.format, tt.players, TournamentRules{})
+			if !tournament.Advance() {
+				t.Fatal("Advance() = false, want the first round")
+			}
+
+			bye := ""
+			games := [][2]string{}
+			for i, pairing := range tournament.CurrentRound() {
+				if pairing.Round != 1 {
+					t.Fatalf("pairing %v round = %v, want 1", i, pairing.Round)
+				}
+				if pairing.IsBye() {
+					bye = pairing.PlayerA
+					if pairing.Winner != pairing.PlayerA || pairing.RoomId != "" {
+						t.Fatalf("bye = %+v, want it decided without a room", pairing)
+					}
+					continue
+				}
+				if pairing.Winner != "" {
+					t.Fatalf("pairing %v winner = %q, want it open", i, pairing.Winner)
+				}
+				if want := TournamentRoomId(tournament.Id, 1, i); pairing.RoomId != want {
+					t.Fatalf("pairing %v room = %q, want %q", i, pairing.RoomId, want)
+				}
+				games = append(games, [2]string{pairing.PlayerA, pairing.PlayerB})
+			}
+
+			if bye != tt.bye {
+				t.Fatalf("bye = %q, want %q", bye, tt.bye)
+			}
+			if fmt.Sprint(games) != fmt.Sprint(tt.games) {
+				t.Fatalf("games = %v, want %v", games, tt.games)
+			}
+		})
+	}
+}
+
+func TestTournamentElimination(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  TournamentFormat
+		players int
+		rounds  int
+		winner  string
+	}{
+		{"single elimination of two", TournamentFormatSingleElimination, 2, 1, "p1"},
+		{"single elimination of four", TournamentFormatSingleElimination, 4, 2, "p1"},
+		{"single elimination with byes", TournamentFormatSingleElimination, 5, 3, "p1"},
+		{"double elimination of four", TournamentFormatDoubleElimination, 4, 4, "p1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := newTestTournament(tt.format, tt.players, TournamentRules{})
+			playTournament(t, tournament)
+
+			if len(tournament.Rounds) != tt.rounds {
+				t.Fatalf("rounds = %v, want %v", len(tournament.Rounds), tt.rounds)
+			}
+			if tournament.Winner != tt.winner {
+				t.Fatalf("winner = %q, want %q", tournament.Winner, tt.winner)
+			}
+
+			// an eliminated player is never paired again
+			limit := 1
+			if tt.format == TournamentFormatDoubleElimination {
+				limit = 2
+			}
+			losses := map[string]int{}
+			for _, round := range tournament.Rounds {
+				for _, pairing := range round {
+					for _, playerId := range []string{pairing.PlayerA, pairing.PlayerB} {
+						if playerId != "" && losses[playerId] >= limit {
+							t.Fatalf("%q plays round %v after being eliminated", playerId, pairing.Round)
+						}
+					}
+				}
+				for _, pairing := range round {
+					if loser := pairing.Loser(); loser != "" {
+						losses[loser]++
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTournamentSwiss(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		rules   int
+		rounds  int
+	}{
+		{"rounds from the number of players", 4, 0, 2},
+		{"odd number of players", 5, 3, 3},
+		{"configured rounds", 6, 4, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := newTestTournament(TournamentFormatSwiss, tt.players, TournamentRules{Rounds: tt.rules})
+			playTournament(t, tournament)
+
+			if len(tournament.Rounds) != tt.rounds {
+				t.Fatalf("rounds = %v, want %v", len(tournament.Rounds), tt.rounds)
+			}
+
+			games := map[string]bool{}
+			byes := map[string]int{}
+			for _, round := range tournament.Rounds {
+				for _, pairing := range round {
+					if pairing.IsBye() {
+						byes[pairing.PlayerA]++
+						continue
+					}
+					key := fmt.Sprint(min(pairing.PlayerA, pairing.PlayerB), max(pairing.PlayerA, pairing.PlayerB))
+					if games[key] {
+						t.Fatalf("%q and %q meet twice", pairing.PlayerA, pairing.PlayerB)
+					}
+					games[key] = true
+				}
+			}
+
+			for playerId, count := range byes {
+				if count > 1 {
+					t.Fatalf("%q got %v byes", playerId, count)
+				}
+			}
+		})
+	}
+}
+
+func TestTournamentRoundRobin(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		rounds  int
+	}{
+		{"even number of players", 4, 3},
+		{"odd number of players", 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := newTestTournament(TournamentFormatRoundRobin, tt.players, TournamentRules{})
+			playTournament(t, tournament)
+
+			if len(tournament.Rounds) != tt.rounds {
+				t.Fatalf("rounds = %v, want %v", len(tournament.Rounds), tt.rounds)
+			}
+
+			games := map[string]int{}
+			byes := map[string]int{}
+			for _, round := range tournament.Rounds {
+				for _, pairing := range round {
+					if pairing.IsBye() {
+						byes[pairing.PlayerA]++
+						continue
+					}
+					games[fmt.Sprint(min(pairing.PlayerA, pairing.PlayerB), max(pairing.PlayerA, pairing.PlayerB))]++
+				}
+			}
+
+			for i := 1; i <= tt.players; i++ {
+				for j := i + 1; j <= tt.players; j++ {
+					if key := fmt.Sprint(fmt.Sprintf("p%v", i), fmt.Sprintf("p%v", j)); games[key] != 1 {
+						t.Fatalf("p%v and p%v meet %v times, want 1", i, j, games[key])
+					}
+				}
+				if wantByes := tt.players % 2; byes[fmt.Sprintf("p%v", i)] != wantByes {
+					t.Fatalf("p%v got %v byes, want %v", i, byes[fmt.Sprintf("p%v", i)], wantByes)
+				}
+			}
+		})
+	}
+}
+
+func TestTournamentRecordResult(t *testing.T) {
+	tournament := newTestTournament(TournamentFormatSingleElimination, 4, TournamentRules{})
+	tournament.Advance()
+	roomId := tournament.CurrentRound()[0].RoomId
+
+	tests := []struct {
+		name   string
+		roomId string
+		winner string
+		want   bool
+	}{
+		{"unknown room", "missing", "p1", false},
+		{"winner did not play", roomId, "p2", false},
+		{"open pairing", roomId, "p4", true},
+		{"already decided", roomId, "p1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tournament.RecordResult(tt.roomId, tt.winner); got != tt.want {
+				t.Fatalf("RecordResult(%q, %q) = %v, want %v", tt.roomId, tt.winner, got, tt.want)
+			}
+		})
+	}
+
+	if winner := tournament.CurrentRound()[0].Winner; winner != "p4" {
+		t.Fatalf("winner = %q, want p4", winner)
+	}
+}
+
+func TestTournamentRoomId(t *testing.T) {
+	roomId := TournamentRoomId("tourney", 1, 0)
+
+	if len(roomId) != MATCH_ID_LENGTH {
+		t.Fatalf("len(%q) = %v, want %v", roomId, len(roomId), MATCH_ID_LENGTH)
+	}
+	if again := TournamentRoomId("tourney", 1, 0); again != roomId {
+		t.Fatalf("TournamentRoomId changed from %q to %q", roomId, again)
+	}
+	for _, other := range []string{TournamentRoomId("tourney", 2, 0), TournamentRoomId("tourney", 1, 1), TournamentRoomId("other", 1, 0)} {
+		if other == roomId {
+			t.Fatalf("TournamentRoomId = %q for two different pairings", roomId)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package embedded runs the whole HTTP API in process on top of an in-memory
+// Redis, for offline play, load tests and end-to-end tests. Everything is lost
+// when the server is closed.
+package embedded
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/cmd/api"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/config"
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	// URL is the base URL of the API, e.g. http://127.0.0.1:41234/api/v1.
+	URL    string
+	redis  *miniredis.Miniredis
+	server *http.Server
+	done   chan struct{}
+}
+
+// Start serves the API on a random local port. Rate limiting and gRPC are off.
+func Start() (*Server, error) {
+	redisServer, err := miniredis.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.Default()
+	cfg.Redis.Addrs = []string{redisServer.Addr()}
+	cfg.RateLimit.Enabled = false
+	cfg.GRPC.Enabled = false
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		redisServer.Close()
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	app := api.NewEmbeddedApplication(cfg, zap.NewNop().Sugar())
+	server := &http.Server{Handler: app.Handler(done)}
+	go server.Serve(listener)
+
+	return &Server{
+		URL:    "http://" + listener.Addr().String() + "/api/v1",
+		redis:  redisServer,
+		server: server,
+		done:   done,
+	}, nil
+}
+
+func (s *Server) Close() {
+	close(s.done)
+	s.server.Shutdown(context.Background())
+	s.redis.Close()
+}
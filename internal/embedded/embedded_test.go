@@ -0,0 +1,25 @@
+package embedded
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStartTwice(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		server, err := Start()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer server.Close()
+
+		res, err := http.Get(server.URL + "/matches/missing")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusNotFound {
+			t.Fatalf("server %v answered %v, want %v", i, res.StatusCode, http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package i18n
+
+var english = map[string]string{
+	"internal_error.title":              "Internal server error",
+	"internal_error.detail":             "The server encountered a problem",
+	"invalid_request.title":             "Invalid request",
+	"invalid_request.detail":            "The request could not be read",
+	"invalid_request.missing_body":      "The request body is missing",
+	"invalid_request.malformed_json":    "The request body is not valid JSON",
+	"invalid_request.wrong_type":        "The field %v has the wrong type",
+	"validation_failed.title":           "Validation failed",
+	"validation_failed.detail":          "One or more fields are invalid",
+	"route_not_found.title":             "Route not found",
+	"route_not_found.detail":            "No route matches %v %v",
+	"method_not_allowed.title":          "Method not allowed",
+	"method_not_allowed.detail":         "%v is not allowed on %v",
+	"unauthorized.title":                "Unauthorized",
+	"unauthorized.detail":               "A valid admin bearer token is required",
+	"rate_limited.title":                "Too many requests",
+	"rate_limited.detail":               "Try again in %v seconds",
+	"invalid_combination.title":         "Invalid combination",
+	"invalid_combination.detail":        "The combination must have exactly 4 digits",
+	"repeated_digits.title":             "Combination has repeated digits",
+	"repeated_digits.detail":            "The digits of a combination can not be repeated",
+	"resource_not_found.title":          "Resource not found",
+	"resource_not_found.detail":         "The requested resource does not exist",
+	"resource_already_exists.title":     "Resource already exists",
+	"resource_already_exists.detail":    "The resource already exists",
+	"room_full.title":                   "Room is full",
+	"room_full.detail":                  "Can not add another player to this room",
+	"room_not_ready.title":              "Room is not ready",
+	"room_not_ready.detail":             "The match is being played already or the room is not complete",
+	"match_not_found.title":             "Match not found",
+	"match_not_found.detail":            "The match does not exist",
+	"match_expired.title":               "Match expired",
+	"match_expired.detail":              "The match expired after being inactive for too long",
+	"combinations_pending.title":        "Combinations are pending",
+	"combinations_pending.detail":       "The game can not start until both players set their combinations",
+	"match_not_started.title":           "Match not started",
+	"match_not_started.detail":          "The match has not started yet or has finished already",
+	"match_finished.title":              "Match is finished",
+	"match_finished.detail":             "The match is finished",
+	"not_your_turn.title":               "Not your turn",
+	"not_your_turn.detail":              "Wait for your opponent to play",
+	"already_solved.title":              "Combination already solved",
+	"already_solved.detail":             "You already cracked the combination, wait for your opponent",
+	"series_over.title":                 "Series is over",
+	"series_over.detail":                "The series is over, create a new room to play again",
+	"match_not_finished.title":          "Match not finished",
+	"match_not_finished.detail":         "A rematch can only be offered once the match is finished",
+	"rematch_already_offered.title":     "Rematch already offered",
+	"rematch_already_offered.detail":    "You already offered a rematch, wait for your opponent",
+	"no_rematch_offer.title":            "No rematch offer",
+	"no_rematch_offer.detail":           "There is no pending rematch offer",
+	"own_rematch_offer.title":           "Own rematch offer",
+	"own_rematch_offer.detail":          "You can not answer your own rematch offer",
+	"tournament_not_found.title":        "Tournament not found",
+	"tournament_not_found.detail":       "The tournament does not exist",
+	"registration_closed.title":         "Registration closed",
+	"registration_closed.detail":        "The tournament registration is closed",
+	"not_enough_players.title":          "Not enough players",
+	"not_enough_players.detail":         "The tournament needs at least 2 players to start",
+	"tournament_already_started.title":  "Tournament already started",
+	"tournament_already_started.detail": "The tournament has started already",
+	"daily_already_played.title":        "Daily challenge already played",
+	"daily_already_played.detail":       "You already played today's challenge, come back tomorrow",
+	"daily_not_started.title":           "Daily challenge not started",
+	"daily_not_started.detail":          "You have not started today's challenge",
+	"daily_attempt_over.title":          "Daily attempt is over",
+	"daily_attempt_over.detail":         "Today's attempt is over, come back tomorrow",
+	"daily_player_not_found.title":      "Player not found",
+	"daily_player_not_found.detail":     "The player has no daily results",
+	"daily_invalid_token.title":         "Invalid daily token",
+	"daily_invalid_token.detail":        "The token does not belong to this player",
+	"player_not_in_room.title":          "Player not in room",
+	"player_not_in_room.detail":         "The player is not in this room",
+	"kick_last_player.title":            "Can not kick the last player",
+	"kick_last_player.detail":           "Can not kick the last player, delete the room instead",
+}
@@ -0,0 +1,79 @@
+package i18n
+
+var spanish = map[string]string{
+	"internal_error.title":              "Error interno del servidor",
+	"internal_error.detail":             "El servidor tuvo un problema",
+	"invalid_request.title":             "Solicitud inválida",
+	"invalid_request.detail":            "No se pudo leer la solicitud",
+	"invalid_request.missing_body":      "Falta el cuerpo de la solicitud",
+	"invalid_request.malformed_json":    "El cuerpo de la solicitud no es un JSON válido",
+	"invalid_request.wrong_type":        "El campo %v tiene un tipo incorrecto",
+	"validation_failed.title":           "Validación fallida",
+	"validation_failed.detail":          "Uno o más campos no son válidos",
+	"route_not_found.title":             "Ruta no encontrada",
+	"route_not_found.detail":            "Ninguna ruta coincide con %v %v",
+	"method_not_allowed.title":          "Método no permitido",
+	"method_not_allowed.detail":         "%v no está permitido en %v",
+	"unauthorized.title":                "No autorizado",
+	"unauthorized.detail":               "Se requiere un token de administrador válido",
+	"rate_limited.title":                "Demasiadas solicitudes",
+	"rate_limited.detail":               "Inténtalo de nuevo en %v segundos",
+	"invalid_combination.title":         "Combinación inválida",
+	"invalid_combination.detail":        "La combinación debe tener exactamente 4 dígitos",
+	"repeated_digits.title":             "La combinación tiene dígitos repetidos",
+	"repeated_digits.detail":            "Los dígitos de una combinación no se pueden repetir",
+	"resource_not_found.title":          "Recurso no encontrado",
+	"resource_not_found.detail":         "El recurso solicitado no existe",
+	"resource_already_exists.title":     "El recurso ya existe",
+	"resource_already_exists.detail":    "El recurso ya existe",
+	"room_full.title":                   "La sala está llena",
+	"room_full.detail":                  "No se puede agregar otro jugador a esta sala",
+	"room_not_ready.title":              "La sala no está lista",
+	"room_not_ready.detail":             "La partida ya se está jugando o la sala no está completa",
+	"match_not_found.title":             "Partida no encontrada",
+	"match_not_found.detail":            "La partida no existe",
+	"match_expired.title":               "Partida expirada",
+	"match_expired.detail":              "La partida expiró tras estar inactiva demasiado tiempo",
+	"combinations_pending.title":        "Faltan combinaciones",
+	"combinations_pending.detail":       "El juego no puede empezar hasta que ambos jugadores elijan su combinación",
+	"match_not_started.title":           "Partida no iniciada",
+	"match_not_started.detail":          "La partida aún no ha empezado o ya terminó",
+	"match_finished.title":              "Partida terminada",
+	"match_finished.detail":             "La partida ha terminado",
+	"not_your_turn.title":               "No es tu turno",
+	"not_your_turn.detail":              "Espera a que juegue tu oponente",
+	"already_solved.title":              "Combinación ya descifrada",
+	"already_solved.detail":             "Ya descifraste la combinación, espera a tu oponente",
+	"series_over.title":                 "La serie terminó",
+	"series_over.detail":                "La serie terminó, crea una sala nueva para volver a jugar",
+	"match_not_finished.title":          "Partida no terminada",
+	"match_not_finished.detail":         "Solo se puede ofrecer la revancha cuando la partida termina",
+	"rematch_already_offered.title":     "Revancha ya ofrecida",
+	"rematch_already_offered.detail":    "Ya ofreciste una revancha, espera a tu oponente",
+	"no_rematch_offer.title":            "Sin oferta de revancha",
+	"no_rematch_offer.detail":           "No hay ninguna oferta de revancha pendiente",
+	"own_rematch_offer.title":           "Oferta de revancha propia",
+	"own_rematch_offer.detail":          "No puedes responder tu propia oferta de revancha",
+	"tournament_not_found.title":        "Torneo no encontrado",
+	"tournament_not_found.detail":       "El torneo no existe",
+	"registration_closed.title":         "Inscripciones cerradas",
+	"registration_closed.detail":        "Las inscripciones del torneo están cerradas",
+	"not_enough_players.title":          "Jugadores insuficientes",
+	"not_enough_players.detail":         "El torneo necesita al menos 2 jugadores para empezar",
+	"tournament_already_started.title":  "Torneo ya iniciado",
+	"tournament_already_started.detail": "El torneo ya empezó",
+	"daily_already_played.title":        "Reto diario ya jugado",
+	"daily_already_played.detail":       "Ya jugaste el reto de hoy, vuelve mañana",
+	"daily_not_started.title":           "Reto diario no iniciado",
+	"daily_not_started.detail":          "No has empezado el reto de hoy",
+	"daily_attempt_over.title":          "Intento diario terminado",
+	"daily_attempt_over.detail":         "El intento de hoy terminó, vuelve mañana",
+	"daily_player_not_found.title":      "Jugador no encontrado",
+	"daily_player_not_found.detail":     "El jugador no tiene resultados diarios",
+	"daily_invalid_token.title":         "Token diario inválido",
+	"daily_invalid_token.detail":        "El token no pertenece a este jugador",
+	"player_not_in_room.title":          "Jugador fuera de la sala",
+	"player_not_in_room.detail":         "El jugador no está en esta sala",
+	"kick_last_player.title":            "No se puede expulsar al último jugador",
+	"kick_last_player.detail":           "No se puede expulsar al último jugador, elimina la sala en su lugar",
+}
@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	ut "github.com/go-playground/universal-translator"
+	"golang.org/x/text/language"
+)
+
+// Localizer resolves user facing messages for one language. Error codes are never
+// translated, only the titles and details that go along with them.
+type Localizer struct {
+	Tag        language.Tag
+	Translator ut.Translator
+	messages   map[string]string
+}
+
+var (
+	localizers []*Localizer
+	matcher    language.Matcher
+	universal  *ut.UniversalTranslator
+)
+
+func init() {
+	supported := []struct {
+		tag      language.Tag
+		locale   locales.Translator
+		messages map[string]string
+	}{
+		// the first language is the fallback when nothing in Accept-Language matches
+		{language.English, en.New(), english},
+		{language.Spanish, es.New(), spanish},
+	}
+
+	universal = ut.New(supported[0].locale, supported[0].locale, supported[1].locale)
+
+	tags := []language.Tag{}
+	for _, entry := range supported {
+		translator, _ := universal.GetTranslator(entry.locale.Locale())
+		localizers = append(localizers, &Localizer{
+			Tag:        entry.tag,
+			Translator: translator,
+			messages:   entry.messages,
+		})
+		tags = append(tags, entry.tag)
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+// Negotiate picks the best supported language for an Accept-Language header.
+func Negotiate(acceptLanguage string) *Localizer {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default()
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default()
+	}
+	return localizers[index]
+}
+
+func Default() *Localizer {
+	return localizers[0]
+}
+
+func (l *Localizer) Title(code contracts.ErrorCode) string {
+	return l.Message(string(code) + ".title")
+}
+
+func (l *Localizer) Detail(code contracts.ErrorCode, args ...any) string {
+	return l.Message(string(code)+".detail", args...)
+}
+
+// Message formats the message stored under key, falling back to English and then to
+// the key itself so a missing translation never hides an error.
+func (l *Localizer) Message(key string, args ...any) string {
+	format, ok := l.messages[key]
+	if !ok {
+		if format, ok = english[key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           language.Tag
+	}{
+		{"empty header", "", language.English},
+		{"english", "en", language.English},
+		{"english region", "en-GB", language.English},
+		{"spanish", "es", language.Spanish},
+		{"spanish region", "es-MX", language.Spanish},
+		{"spanish by weight", "fr-FR, es;q=0.8, en;q=0.5", language.Spanish},
+		{"english by weight", "es;q=0.4, en;q=0.9", language.English},
+		{"unknown language", "fr-FR", language.English},
+		{"unknown languages", "de-CH, ja;q=0.7", language.English},
+		{"malformed header", ";;q=abc", language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.acceptLanguage).Tag; got != tt.want {
+				t.Fatalf("Negotiate(%q) = %v, want %v", tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+// errorCodes reads every ErrorCode constant from the contracts package, so a code
+// added without its messages fails here.
+func errorCodes(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "../../contracts/errors.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parsing the error codes: %v", err)
+	}
+
+	codes := []string{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			if ident, ok := value.Type.(*ast.Ident); !ok || ident.Name != "ErrorCode" {
+				continue
+			}
+			for _, literal := range value.Values {
+				code, err := strconv.Unquote(literal.(*ast.BasicLit).Value)
+				if err != nil {
+					t.Fatalf("reading error code %v: %v", literal, err)
+				}
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	if len(codes) == 0 {
+		t.Fatal("no error codes found")
+	}
+	return codes
+}
+
+func TestCatalogsCoverErrorCodes(t *testing.T) {
+	catalogs := map[string]map[string]string{"en": english, "es": spanish}
+
+	for _, code := range errorCodes(t) {
+		for name, catalog := range catalogs {
+			for _, key := range []string{code + ".title", code + ".detail"} {
+				if catalog[key] == "" {
+					t.Errorf("the %v catalog has no %q", name, key)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for key := range english {
+		if _, ok := spanish[key]; !ok {
+			t.Errorf("the es catalog has no %q", key)
+		}
+	}
+	for key := range spanish {
+		if _, ok := english[key]; !ok {
+			t.Errorf("the es catalog has %q, which is not in the en catalog", key)
+		}
+	}
+}
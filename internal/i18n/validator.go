@@ -0,0 +1,30 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+	"golang.org/x/text/language"
+)
+
+// RegisterValidatorTranslations makes FieldError.Translate available for every
+// supported language.
+func RegisterValidatorTranslations(v *validator.Validate) error {
+	for _, localizer := range localizers {
+		var err error
+		switch localizer.Tag {
+		case language.English:
+			err = en_translations.RegisterDefaultTranslations(v, localizer.Translator)
+		case language.Spanish:
+			err = es_translations.RegisterDefaultTranslations(v, localizer.Translator)
+		default:
+			err = fmt.Errorf("no validator translations for %v", localizer.Tag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
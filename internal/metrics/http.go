@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/utils"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+type routeKey struct{}
+
+// Middleware must wrap the whole router, so requests that match no route or method
+// are counted as well. The route label comes from RouteMiddleware, using the raw path
+// would explode the label cardinality; requests no route matched are labelled
+// unmatched.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := utils.NewResponseRecorder(w)
+
+		route := "unmatched"
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), routeKey{}, &route)))
+
+		HTTPRequests.WithLabelValues(r.Method, route, strconv.Itoa(recorder.Status)).Inc()
+		HTTPDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// RouteMiddleware must be registered with router.Use, it hands the template of the
+// matched route to Middleware.
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route, ok := r.Context().Value(routeKey{}).(*string); ok {
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					*route = template
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
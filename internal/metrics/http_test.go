@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMiddlewareCountsEveryRequest(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(RouteMiddleware)
+	router.HandleFunc("/metrics-test/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+	handler := Middleware(router)
+
+	for _, request := range []struct{ method, path string }{
+		{"GET", "/metrics-test/abc"},
+		{"GET", "/metrics-test-missing"},
+		{"DELETE", "/metrics-test/abc"},
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(request.method, request.path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, series := range []string{
+		`bullandcows_http_requests_total{method="GET",route="/metrics-test/{id}",status="200"}`,
+		`bullandcows_http_requests_total{method="GET",route="unmatched",status="404"}`,
+		`bullandcows_http_requests_total{method="DELETE",route="unmatched",status="405"}`,
+	} {
+		if !strings.Contains(string(body), series) {
+			t.Fatalf("expected %v in the exposition:\n%s", series, body)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+type matchesService struct {
+	contracts.IMatchesService
+}
+
+// NewMatchesService decorates the matches service with the domain counters.
+func NewMatchesService(service contracts.IMatchesService) contracts.IMatchesService {
+	return &matchesService{
+		IMatchesService: service,
+	}
+}
+
+func (s *matchesService) CreateRoom(ctx context.Context, command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error) {
+	res, err := s.IMatchesService.CreateRoom(ctx, command)
+	if err == nil {
+		RoomsCreated.WithLabelValues(string(res.Mode)).Inc()
+	}
+	return res, err
+}
+
+func (s *matchesService) StartGame(ctx context.Context, roomId string) (*contracts.StartMatchResponse, error) {
+	res, err := s.IMatchesService.StartGame(ctx, roomId)
+	if err == nil {
+		MatchesStarted.WithLabelValues(string(res.Mode)).Inc()
+	}
+	return res, err
+}
+
+// ObserveFinishedMatch counts a finished match and the guesses both players made,
+// it is meant for services.GameConfig.OnFinish.
+func ObserveFinishedMatch(match *domain.Match) {
+	guesses := 0
+	for _, items := range match.Guesses {
+		guesses += len(items)
+	}
+	MatchesFinished.Inc()
+	GuessesPerMatch.Observe(float64(guesses))
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func guessesPerMatch(t *testing.T) *dto.Histogram {
+	t.Helper()
+
+	metric := &dto.Metric{}
+	if err := GuessesPerMatch.Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	return metric.GetHistogram()
+}
+
+func TestObserveFinishedMatch(t *testing.T) {
+	finished := testutil.ToFloat64(MatchesFinished)
+	before := guessesPerMatch(t)
+
+	// a round nobody won is counted as well
+	ObserveFinishedMatch(&domain.Match{
+		Guesses: domain.MatchGuesses{"p1": {{}, {}}, "p2": {{}}},
+	})
+
+	if got := testutil.ToFloat64(MatchesFinished); got != finished+1 {
+		t.Fatalf("matches finished = %v, want %v", got, finished+1)
+	}
+	after := guessesPerMatch(t)
+	if after.GetSampleCount() != before.GetSampleCount()+1 || after.GetSampleSum() != before.GetSampleSum()+3 {
+		t.Fatalf("expected one match with 3 guesses observed, got %v matches and %v guesses more", after.GetSampleCount()-before.GetSampleCount(), after.GetSampleSum()-before.GetSampleSum())
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "bullandcows"
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	RedisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Redis command latency by command.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command"})
+
+	RedisErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_command_errors_total",
+		Help:      "Redis command errors by command.",
+	}, []string{"command"})
+
+	RoomsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rooms_created_total",
+		Help:      "Rooms created by match mode.",
+	}, []string{"mode"})
+
+	MatchesStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "matches_started_total",
+		Help:      "Matches started by match mode.",
+	}, []string{"mode"})
+
+	MatchesFinished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "matches_finished_total",
+		Help:      "Matches finished, won or not.",
+	})
+
+	GuessesPerMatch = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "match_guesses",
+		Help:      "Guesses made by both players in a finished match.",
+		Buckets:   prometheus.LinearBuckets(2, 2, 10),
+	})
+
+	MatchesAbandoned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "matches_abandoned_total",
+		Help:      "Rooms that expired before their match finished, by last status.",
+	}, []string{"status"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		RedisDuration,
+		RedisErrors,
+		RoomsCreated,
+		MatchesStarted,
+		MatchesFinished,
+		GuessesPerMatch,
+		MatchesAbandoned,
+	)
+}
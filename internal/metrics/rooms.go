@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type RoomsCounter interface {
+	CountRoomsByStatus(ctx context.Context) (map[domain.MatchStatus]int, error)
+}
+
+type activeRoomsCollector struct {
+	mu      sync.Mutex
+	counter RoomsCounter
+	desc    *prometheus.Desc
+}
+
+var (
+	activeRooms = &activeRoomsCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_rooms"),
+			"Live rooms by match status.",
+			[]string{"status"},
+			nil,
+		),
+	}
+	registerActiveRooms sync.Once
+)
+
+// RegisterActiveRooms reports the live rooms counted by counter at scrape time. The
+// registry is global and every application of the process calls it, e.g. embedded
+// servers, so the collector is registered once and the latest counter is the one read.
+func RegisterActiveRooms(counter RoomsCounter) {
+	activeRooms.mu.Lock()
+	activeRooms.counter = counter
+	activeRooms.mu.Unlock()
+
+	registerActiveRooms.Do(func() {
+		Registry.MustRegister(activeRooms)
+	})
+}
+
+func (c *activeRoomsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *activeRoomsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	c.mu.Lock()
+	counter := c.counter
+	c.mu.Unlock()
+
+	counts, err := counter.CountRoomsByStatus(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	for _, status := range []domain.MatchStatus{
+		domain.MatchStateWaiting,
+		domain.MatchStateFullRoom,
+		domain.MatchStatePlaying,
+		domain.MatchStateFinished,
+	} {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts[status]), string(status))
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+type fixedRoomsCounter map[domain.MatchStatus]int
+
+func (c fixedRoomsCounter) CountRoomsByStatus(ctx context.Context) (map[domain.MatchStatus]int, error) {
+	return c, nil
+}
+
+func TestRegisterActiveRoomsTwice(t *testing.T) {
+	RegisterActiveRooms(fixedRoomsCounter{domain.MatchStatePlaying: 1})
+	RegisterActiveRooms(fixedRoomsCounter{domain.MatchStatePlaying: 7})
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	if series := `bullandcows_active_rooms{status="Playing"} 7`; !strings.Contains(string(body), series) {
+		t.Fatalf("expected %v in the exposition:\n%s", series, body)
+	}
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (*Result, error)
+}
+
+// slidingWindowScript keeps one sorted set entry per accepted request, scored by its
+// timestamp in milliseconds. Entries older than the window are trimmed first, so the
+// cardinality is the number of requests in the last window.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local member = ARGV[4]
+
+redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+local count = redis.call("ZCARD", key)
+
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", key, now, member)
+	redis.call("PEXPIRE", key, window)
+	count = count + 1
+	allowed = 1
+end
+
+local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+local reset = window
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+type RedisLimiter struct {
+	rdb redis.Scripter
+}
+
+func NewRedisLimiter(rdb redis.Scripter) *RedisLimiter {
+	return &RedisLimiter{
+		rdb: rdb,
+	}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (*Result, error) {
+	now := time.Now().UnixMilli()
+
+	values, err := slidingWindowScript.Run(ctx, l.rdb, []string{key}, now, window.Milliseconds(), limit, uuid.NewString()).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	result := &Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}
+
+	if !result.Allowed {
+		result.RetryAfter = result.ResetAfter
+	}
+
+	return result, nil
+}
@@ -0,0 +1,185 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type Identity string
+
+const (
+	IdentityIP     = Identity("ip")
+	IdentityPlayer = Identity("player")
+	IdentityRoom   = Identity("room")
+
+	maxPeekBodySize = 1 << 20
+)
+
+type Rule struct {
+	Name     string        `yaml:"name"`
+	Method   string        `yaml:"method"`
+	Route    string        `yaml:"route"`
+	Identity Identity      `yaml:"identity"`
+	Limit    int           `yaml:"limit"`
+	Window   time.Duration `yaml:"window"`
+}
+
+func DefaultRules() []Rule {
+	return []Rule{
+		{Name: "create-room", Method: "POST", Route: "/api/v1/matches/create", Identity: IdentityIP, Limit: 10, Window: time.Minute},
+		{Name: "join-room", Method: "PUT", Route: "/api/v1/matches/join/{roomId}", Identity: IdentityIP, Limit: 30, Window: time.Minute},
+		{Name: "guess-player", Method: "PUT", Route: "/api/v1/matches/makeGuess/{roomId}", Identity: IdentityPlayer, Limit: 30, Window: time.Minute},
+		{Name: "guess-room", Method: "PUT", Route: "/api/v1/matches/makeGuess/{roomId}", Identity: IdentityRoom, Limit: 60, Window: time.Minute},
+		{Name: "chat-player", Method: "POST", Route: "/api/v1/matches/chat/{roomId}", Identity: IdentityPlayer, Limit: 20, Window: time.Minute},
+		{Name: "create-tournament", Method: "POST", Route: "/api/v1/tournaments/create", Identity: IdentityIP, Limit: 5, Window: time.Minute},
+		{Name: "daily-guess", Method: "PUT", Route: "/api/v1/daily/guess", Identity: IdentityIP, Limit: 30, Window: time.Minute},
+	}
+}
+
+// ParseRules reads rules separated by ";" where each rule is
+// "name METHOD route identity limit/window", e.g.
+// "create-room POST /api/v1/matches/create ip 10/1m".
+func ParseRules(spec string) ([]Rule, error) {
+	rules := []Rule{}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.Fields(entry)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("rate limit rule %q: expected 5 fields", entry)
+		}
+
+		limitAndWindow := strings.SplitN(fields[4], "/", 2)
+		if len(limitAndWindow) != 2 {
+			return nil, fmt.Errorf("rate limit rule %q: expected limit/window", entry)
+		}
+
+		limit, err := strconv.Atoi(limitAndWindow[0])
+		if err != nil {
+			return nil, fmt.Errorf("rate limit rule %q: invalid limit", entry)
+		}
+
+		window, err := time.ParseDuration(limitAndWindow[1])
+		if err != nil {
+			return nil, fmt.Errorf("rate limit rule %q: invalid window", entry)
+		}
+
+		rule := Rule{
+			Name:     fields[0],
+			Method:   strings.ToUpper(fields[1]),
+			Route:    fields[2],
+			Identity: Identity(fields[3]),
+			Limit:    limit,
+			Window:   window,
+		}
+
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (rule Rule) Validate() error {
+	if rule.Name == "" || rule.Method == "" || rule.Route == "" {
+		return fmt.Errorf("rate limit rule %q: name, method and route are required", rule.Name)
+	}
+	if rule.Identity != IdentityIP && rule.Identity != IdentityPlayer && rule.Identity != IdentityRoom {
+		return fmt.Errorf("rate limit rule %q: unknown identity %q", rule.Name, rule.Identity)
+	}
+	if rule.Limit <= 0 {
+		return fmt.Errorf("rate limit rule %q: limit must be positive", rule.Name)
+	}
+	if rule.Window <= 0 {
+		return fmt.Errorf("rate limit rule %q: window must be positive", rule.Name)
+	}
+	return nil
+}
+
+func (rule Rule) Matches(method, route string) bool {
+	return rule.Method == method && rule.Route == route
+}
+
+// IdentityOf returns the value the rule is keyed by, or an empty string when the
+// request does not carry it. Player ids are read from the JSON body, which is put
+// back so the handler can still parse it.
+func (rule Rule) IdentityOf(r *http.Request, trustedHops int) string {
+	switch rule.Identity {
+	case IdentityIP:
+		return ClientIP(r, trustedHops)
+	case IdentityRoom:
+		return mux.Vars(r)["roomId"]
+	case IdentityPlayer:
+		return peekPlayerId(r)
+	}
+	return ""
+}
+
+func (rule Rule) Key(identity string) string {
+	return fmt.Sprintf("ratelimit:{%v:%v}", rule.Name, identity)
+}
+
+// ClientIP returns the address of the client. trustedHops is the number of proxies in
+// front of the server, each one appends the address it got the request from to
+// X-Forwarded-For, so only that many entries from the right can be trusted; anything
+// further left is whatever the client sent. With no trusted hops the header is
+// ignored.
+func ClientIP(r *http.Request, trustedHops int) string {
+	if trustedHops > 0 {
+		entries := []string{}
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, entry := range strings.Split(header, ",") {
+				if entry = strings.TrimSpace(entry); entry != "" {
+					entries = append(entries, entry)
+				}
+			}
+		}
+
+		if len(entries) > 0 {
+			return entries[max(len(entries)-trustedHops, 0)]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func peekPlayerId(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPeekBodySize))
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	payload := struct {
+		PlayerId string `json:"player_id"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.PlayerId
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		forwarded   []string
+		trustedHops int
+		want        string
+	}{
+		{"proxy not trusted", []string{"203.0.113.7"}, 0, "192.0.2.1"},
+		{"no header", nil, 1, "192.0.2.1"},
+		{"single proxy", []string{"203.0.113.7"}, 1, "203.0.113.7"},
+		{"spoofed entries are skipped", []string{"10.0.0.1, 203.0.113.7"}, 1, "203.0.113.7"},
+		{"two proxies", []string{"10.0.0.1, 203.0.113.7, 198.51.100.2"}, 2, "203.0.113.7"},
+		{"repeated headers", []string{"10.0.0.1", "203.0.113.7"}, 1, "203.0.113.7"},
+		{"fewer entries than hops", []string{"203.0.113.7"}, 3, "203.0.113.7"},
+		{"empty entries", []string{" , 203.0.113.7 ,"}, 1, "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = "192.0.2.1:4242"
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			if got := ClientIP(r, tt.trustedHops); got != tt.want {
+				t.Fatalf("ClientIP(%q, %v) = %q, want %q", tt.forwarded, tt.trustedHops, got, tt.want)
+			}
+		})
+	}
+}
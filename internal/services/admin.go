@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+var (
+	ErrPlayerNotInRoom = fmt.Errorf("player is not in this room")
+	ErrKickLastPlayer  = fmt.Errorf("can not kick the last player, delete the room instead")
+)
+
+const (
+	AdminActionListRooms   = "list_rooms"
+	AdminActionInspectRoom = "inspect_room"
+	AdminActionForceFinish = "force_finish"
+	AdminActionDeleteRoom  = "delete_room"
+	AdminActionKickPlayer  = "kick_player"
+	AdminActionExtendTTL   = "extend_ttl"
+	AdminActionStats       = "stats"
+	AdminActionAuditLog    = "audit_log"
+)
+
+type AdminService struct {
+	storage contracts.Storage
+	config  GameConfig
+	clock   domain.Clock
+}
+
+func NewAdminService(storage contracts.Storage, config GameConfig, clock domain.Clock) contracts.IAdminService {
+	return &AdminService{
+		storage: storage,
+		config:  config,
+		clock:   clock,
+	}
+}
+
+func (s *AdminService) ListRooms(ctx context.Context, actor contracts.AdminActor, query contracts.ListRoomsQuery) (*contracts.AdminRoomsResponse, error) {
+	rooms, err := s.storage.AdminRepository.ScanRooms(ctx, query.Status, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.AdminRoomsResponse{
+		Rooms: make([]contracts.AdminRoomSummaryResponse, 0, len(rooms)),
+	}
+	for _, room := range rooms {
+		response.Rooms = append(response.Rooms, contracts.AdminRoomSummaryResponse{
+			RoomId:     room.RoomId,
+			Status:     room.Status,
+			TTLSeconds: int64(room.TTL.Seconds()),
+		})
+	}
+
+	if err := s.audit(ctx, actor, AdminActionListRooms, "", fmt.Sprintf("status=%v limit=%v", query.Status, query.Limit)); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *AdminService) InspectRoom(ctx context.Context, actor contracts.AdminActor, roomId string) (*contracts.AdminRoomResponse, error) {
+	match, ttl, err := s.storage.AdminRepository.GetRoom(ctx, roomId)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, AdminActionInspectRoom, roomId, ""); err != nil {
+		return nil, err
+	}
+
+	playerIds := make(map[string]string, len(match.Players))
+	for playerId, player := range match.Players {
+		playerIds[player.Handle()] = playerId
+	}
+
+	combinations := make(map[string]string, len(match.OpponentsCombinations))
+	for playerId, combination := range match.OpponentsCombinations {
+		combinations[domain.PlayerHandle(playerId)] = combination
+	}
+
+	return &contracts.AdminRoomResponse{
+		MatchStateResponse: *newMatchStateResponse(match, s.clock.Now()),
+		PlayerIds:          playerIds,
+		Combinations:       combinations,
+		TTLSeconds:         int64(ttl.Seconds()),
+	}, nil
+}
+
+// ForceFinish ends the game in progress. Without a winner the round is still recorded
+// in the series, as a round nobody won. The finish then runs the same steps as one
+// reached by playing, so a tournament room passes its result on right away.
+func (s *AdminService) ForceFinish(ctx context.Context, actor contracts.AdminActor, command contracts.ForceFinishCommand) (*contracts.SuccessResponse, error) {
+	if err := s.audit(ctx, actor, AdminActionForceFinish, command.RoomId, fmt.Sprintf("winner=%v", command.Winner)); err != nil {
+		return nil, err
+	}
+
+	var result *domain.Match
+
+	err := s.storage.AdminRepository.UpdateRoom(ctx, command.RoomId, func(match *domain.Match) error {
+		if match.Status == domain.MatchStateFinished {
+			return ErrMatchIsFinished
+		}
+
+		if _, exists := match.Players[command.Winner]; command.Winner != "" && !exists {
+			return ErrPlayerNotInRoom
+		}
+
+		match.IsTurnOf = ""
+		match.RematchOffer = nil
+		match.Finish(command.Winner)
+		result = match
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, err
+	}
+
+	matchFinished(ctx, s.storage, s.config, result)
+
+	return &contracts.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *AdminService) DeleteRoom(ctx context.Context, actor contracts.AdminActor, roomId string) (*contracts.SuccessResponse, error) {
+	if err := s.audit(ctx, actor, AdminActionDeleteRoom, roomId, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.AdminRepository.DeleteRoom(ctx, roomId); err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, err
+	}
+
+	return &contracts.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+// KickPlayer removes a player and sends the room back to the lobby, since the game
+// in progress can not go on without them. The series starts over as well. The audit
+// log names the player by handle, the id is a credential.
+func (s *AdminService) KickPlayer(ctx context.Context, actor contracts.AdminActor, command contracts.KickPlayerCommand) (*contracts.SuccessResponse, error) {
+	if err := s.audit(ctx, actor, AdminActionKickPlayer, command.RoomId, fmt.Sprintf("player_handle=%v", domain.PlayerHandle(command.PlayerId))); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.AdminRepository.UpdateRoom(ctx, command.RoomId, func(match *domain.Match) error {
+		if _, exists := match.Players[command.PlayerId]; !exists {
+			return ErrPlayerNotInRoom
+		}
+
+		if len(match.Players) == 1 {
+			return ErrKickLastPlayer
+		}
+
+		delete(match.Players, command.PlayerId)
+
+		remaining := ""
+		for playerId := range match.Players {
+			remaining = playerId
+		}
+
+		match.OpponentsCombinations = make(domain.MatchOpponentCombinations)
+		match.Commitments = make(domain.MatchCommitments)
+		match.Guesses = make(domain.MatchGuesses)
+		match.Status = domain.MatchStateWaiting
+		match.IsTurnOf = remaining
+		match.Winner = ""
+		match.StartedBy = ""
+		match.RematchOffer = nil
+		match.Series = domain.NewMatchSeries(match.Series.BestOf)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, err
+	}
+
+	return &contracts.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *AdminService) ExtendTTL(ctx context.Context, actor contracts.AdminActor, command contracts.ExtendTTLCommand) (*contracts.AdminRoomSummaryResponse, error) {
+	extra := time.Duration(command.Seconds) * time.Second
+
+	if err := s.audit(ctx, actor, AdminActionExtendTTL, command.RoomId, fmt.Sprintf("extra=%v", extra)); err != nil {
+		return nil, err
+	}
+
+	ttl, err := s.storage.AdminRepository.ExtendRoomTTL(ctx, command.RoomId, extra)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, err
+	}
+
+	status, err := s.storage.MatchesRepository.GetMatchStatusById(ctx, command.RoomId)
+	if err != nil && !errors.Is(err, domain.ErrEmptyResult) {
+		return nil, err
+	}
+
+	return &contracts.AdminRoomSummaryResponse{
+		RoomId:     command.RoomId,
+		Status:     status,
+		TTLSeconds: int64(ttl.Seconds()),
+	}, nil
+}
+
+func (s *AdminService) GetStats(ctx context.Context, actor contracts.AdminActor) (*contracts.AdminStatsResponse, error) {
+	counts, err := s.storage.MatchesRepository.CountRoomsByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.AdminStatsResponse{
+		ByStatus: make(map[domain.MatchStatus]int, len(counts)),
+	}
+	for _, status := range []domain.MatchStatus{domain.MatchStateWaiting, domain.MatchStateFullRoom, domain.MatchStatePlaying, domain.MatchStateFinished} {
+		response.ByStatus[status] = counts[status]
+		response.Total += counts[status]
+	}
+
+	if err := s.audit(ctx, actor, AdminActionStats, "", ""); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *AdminService) GetAuditLog(ctx context.Context, actor contracts.AdminActor, limit int) (*contracts.AuditLogResponse, error) {
+	entries, err := s.storage.AdminRepository.GetAuditLog(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, AdminActionAuditLog, "", ""); err != nil {
+		return nil, err
+	}
+
+	return &contracts.AuditLogResponse{
+		Entries: entries,
+	}, nil
+}
+
+// audit appends to the audit log. Changes are audited before they are made, the log
+// and the room may live on different cluster slots so they can not share a
+// transaction, and a change must never happen without its record; a rejected
+// change still shows up as an attempt.
+func (s *AdminService) audit(ctx context.Context, actor contracts.AdminActor, action, roomId, details string) error {
+	return s.storage.AdminRepository.AppendAudit(ctx, contracts.AuditEntry{
+		At:        s.clock.Now(),
+		Admin:     actor.Name,
+		Action:    action,
+		RoomId:    roomId,
+		Details:   details,
+		RequestId: actor.RequestId,
+	})
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+func TestForceFinishWithoutWinner(t *testing.T) {
+	storage := newRedisStorage(t)
+	matches := NewMatchesService(storage, redisGameConfig, domain.SystemClock{}, domain.CryptoRandom{})
+	admin := NewAdminService(storage, redisGameConfig, domain.SystemClock{})
+	actor := contracts.AdminActor{Name: "ops", RequestId: "req-1"}
+	ctx := context.Background()
+
+	roomId, _, _ := startRedisMatch(t, matches, domain.MatchModeTurns)
+
+	_, err := admin.ForceFinish(ctx, actor, contracts.ForceFinishCommand{RoomId: roomId})
+	assertError(t, err, nil)
+
+	match, err := storage.MatchesRepository.GetAll(ctx, roomId)
+	assertError(t, err, nil)
+	if match.Status != domain.MatchStateFinished || match.Winner != "" {
+		t.Fatalf("expected the room finished without a winner, got %v won by %q", match.Status, match.Winner)
+	}
+	if len(match.Series.Rounds) != 1 || match.Series.Rounds[0].Winner != "" || len(match.Series.Score()) != 0 {
+		t.Fatalf("expected a round nobody won in the series, got %+v", match.Series)
+	}
+
+	entries, err := storage.AdminRepository.GetAuditLog(ctx, 10)
+	assertError(t, err, nil)
+	if len(entries) != 1 || entries[0].Action != AdminActionForceFinish || entries[0].RoomId != roomId || entries[0].Admin != "ops" {
+		t.Fatalf("expected the force finish in the audit log, got %+v", entries)
+	}
+}
+
+func TestAdminChangesAreAuditedFirst(t *testing.T) {
+	storage := newRedisStorage(t)
+	admin := NewAdminService(storage, redisGameConfig, domain.SystemClock{})
+	actor := contracts.AdminActor{Name: "ops"}
+	ctx := context.Background()
+
+	// the record is written even when the change is then rejected
+	_, err := admin.DeleteRoom(ctx, actor, "missing")
+	assertError(t, err, ErrMatchNotFound)
+	_, err = admin.KickPlayer(ctx, actor, contracts.KickPlayerCommand{RoomId: "missing", PlayerId: "p1"})
+	assertError(t, err, ErrMatchNotFound)
+	_, err = admin.ForceFinish(ctx, actor, contracts.ForceFinishCommand{RoomId: "missing"})
+	assertError(t, err, ErrMatchNotFound)
+
+	entries, err := storage.AdminRepository.GetAuditLog(ctx, 10)
+	assertError(t, err, nil)
+
+	actions := []string{}
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	want := []string{AdminActionForceFinish, AdminActionKickPlayer, AdminActionDeleteRoom}
+	if len(actions) != len(want) {
+		t.Fatalf("expected %v in the audit log, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("expected %v in the audit log, got %v", want, actions)
+		}
+	}
+}
+
+func TestKickPlayerThenRejoin(t *testing.T) {
+	storage := newRedisStorage(t)
+	matches := NewMatchesService(storage, redisGameConfig, domain.SystemClock{}, domain.CryptoRandom{})
+	admin := NewAdminService(storage, redisGameConfig, domain.SystemClock{})
+	actor := contracts.AdminActor{Name: "ops"}
+	ctx := context.Background()
+
+	roomId, _, playerIds := startRedisMatch(t, matches, domain.MatchModeTurns)
+
+	_, err := admin.KickPlayer(ctx, actor, contracts.KickPlayerCommand{RoomId: roomId, PlayerId: playerIds[1]})
+	assertError(t, err, nil)
+
+	entries, err := storage.AdminRepository.GetAuditLog(ctx, 1)
+	assertError(t, err, nil)
+	if want := "player_handle=" + domain.PlayerHandle(playerIds[1]); len(entries) != 1 || entries[0].Details != want {
+		t.Fatalf("expected %q in the audit log, got %+v", want, entries)
+	}
+
+	joined, err := matches.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: roomId, Username: "carol"})
+	assertError(t, err, nil)
+	_, err = matches.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: roomId, PlayerId: playerIds[0], Combination: 1234})
+	assertError(t, err, nil)
+	_, err = matches.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: roomId, PlayerId: joined.Player.Id, Combination: 5678})
+	assertError(t, err, nil)
+
+	started, err := matches.StartGame(ctx, roomId)
+	assertError(t, err, nil)
+	if _, exists := started.Commitments[domain.PlayerHandle(playerIds[1])]; exists || len(started.Commitments) != 2 {
+		t.Fatalf("expected the commitments of the players in the room only, got %v", started.Commitments)
+	}
+
+	_, err = admin.ForceFinish(ctx, actor, contracts.ForceFinishCommand{RoomId: roomId})
+	assertError(t, err, nil)
+
+	match, err := matches.GetMatch(ctx, roomId)
+	assertError(t, err, nil)
+	for handle, commitment := range match.Commitments {
+		if !domain.VerifyCommitment(commitment.Commitment, commitment.Secret, commitment.Salt) {
+			t.Fatalf("expected the secret of %v to open its commitment, got %+v", handle, commitment)
+		}
+	}
+}
+
+func TestAdminUsesTheClock(t *testing.T) {
+	storage := newRedisStorage(t)
+	clock := &domain.FixedClock{At: testNow}
+	config := redisGameConfig
+	config.RematchTimeout = time.Minute
+	matches := NewMatchesService(storage, config, clock, domain.CryptoRandom{})
+	admin := NewAdminService(storage, config, clock)
+	actor := contracts.AdminActor{Name: "ops"}
+	ctx := context.Background()
+
+	roomId, _, playerIds := startRedisMatch(t, matches, domain.MatchModeTurns)
+	_, err := admin.ForceFinish(ctx, actor, contracts.ForceFinishCommand{RoomId: roomId, Winner: playerIds[0]})
+	assertError(t, err, nil)
+	_, err = matches.OfferRematch(ctx, contracts.RematchCommand{RoomId: roomId, PlayerId: playerIds[1]})
+	assertError(t, err, nil)
+
+	// the offer is pending on the injected clock, long lapsed on the system one
+	room, err := admin.InspectRoom(ctx, actor, roomId)
+	assertError(t, err, nil)
+	if room.Rematch == nil || room.Rematch.OfferedBy != domain.PlayerHandle(playerIds[1]) {
+		t.Fatalf("expected the pending rematch offer, got %+v", room.Rematch)
+	}
+
+	entries, err := storage.AdminRepository.GetAuditLog(ctx, 10)
+	assertError(t, err, nil)
+	for _, entry := range entries {
+		if !entry.At.Equal(testNow) {
+			t.Fatalf("expected %v audited at %v, got %v", entry.Action, testNow, entry.At)
+		}
+	}
+}
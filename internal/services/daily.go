@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+var (
+	ErrDailyAlreadyPlayed  = fmt.Errorf("you already played today's challenge, come back tomorrow")
+	ErrDailyNotStarted     = fmt.Errorf("you have not started today's challenge")
+	ErrDailyAttemptIsOver  = fmt.Errorf("today's attempt is over, come back tomorrow")
+	ErrDailyPlayerNotFound = fmt.Errorf("player has no daily results")
+	ErrDailyInvalidToken   = fmt.Errorf("the token does not belong to this player")
+)
+
+type DailyService struct {
+	storage contracts.Storage
+	seed    string
+	clock   domain.Clock
+	random  domain.RandomSource
+}
+
+func NewDailyService(storage contracts.Storage, seed string, clock domain.Clock, random domain.RandomSource) contracts.IDailyService {
+	return &DailyService{
+		storage: storage,
+		seed:    seed,
+		clock:   clock,
+		random:  random,
+	}
+}
+
+// StartAttempt issues the player's token on their first start, every later start has
+// to carry it.
+func (s *DailyService) StartAttempt(ctx context.Context, command contracts.StartDailyCommand) (*contracts.DailyAttemptResponse, error) {
+	now := s.clock.Now()
+
+	attempt := &domain.DailyAttempt{
+		Day:       domain.GetDay(now),
+		Username:  normalizeUsername(command.Username),
+		Guesses:   []domain.GuessesHistoryItem{},
+		StartedAt: now,
+	}
+
+	issued := ""
+	err := s.storage.DailyRepository.CreateAttempt(ctx, attempt, func(player *domain.DailyPlayer) error {
+		if player.Token != "" {
+			if !validDailyToken(player, command.Token) {
+				return ErrDailyInvalidToken
+			}
+			return nil
+		}
+
+		token, err := domain.GeneratePlayerId(s.random)
+		if err != nil {
+			return err
+		}
+
+		player.Token = token
+		issued = token
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrDailyAlreadyPlayed
+		}
+		return nil, err
+	}
+
+	response := newDailyAttemptResponse(attempt, s.seed, now)
+	response.Token = issued
+	return response, nil
+}
+
+func (s *DailyService) MakeGuess(ctx context.Context, command contracts.DailyGuessCommand) (*contracts.DailyAttemptResponse, error) {
+	guess := fmt.Sprint(command.Guess)
+
+	if err := domain.ValidateCombination(guess); err != nil {
+		switch err {
+		case domain.ErrInvalidCombination, domain.ErrInvalidUniqueCombination:
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCombination, err)
+		}
+		return nil, err
+	}
+
+	username := normalizeUsername(command.Username)
+
+	player, err := s.getPlayer(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validDailyToken(player, command.Token) {
+		return nil, ErrDailyInvalidToken
+	}
+
+	now := s.clock.Now()
+
+	if !domain.IsDailyPlayable(player.Day, now) {
+		return nil, ErrDailyAttemptIsOver
+	}
+
+	var result *domain.DailyAttempt
+
+	// the guess goes to the attempt the player started, which may belong to the day
+	// before when it arrives within the grace period after midnight
+	err = s.storage.DailyRepository.UpdateAttemptAndStats(ctx, player.Day, username, func(attempt *domain.DailyAttempt, stats *domain.DailyStats) error {
+		if attempt.Finished {
+			return ErrDailyAttemptIsOver
+		}
+
+		match := &domain.Match{}
+		guessItem, err := match.GetNewGuess(guess, domain.GenerateDailyCombination(s.seed, attempt.Day))
+		if err != nil {
+			return ErrInvalidCombination
+		}
+
+		guessItem.Round = len(attempt.Guesses) + 1
+		guessItem.PlayedAt = now
+		attempt.AddGuess(*guessItem)
+
+		if attempt.Finished {
+			stats.Record(attempt)
+		}
+
+		result = attempt
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrDailyNotStarted
+		}
+		return nil, err
+	}
+
+	return newDailyAttemptResponse(result, s.seed, now), nil
+}
+
+// GetAttempt reads the attempt of query.Day, past days included so the player can verify
+// the revealed secret, and falls back to the attempt the player is on.
+func (s *DailyService) GetAttempt(ctx context.Context, query contracts.DailyAttemptQuery) (*contracts.DailyAttemptResponse, error) {
+	username := normalizeUsername(query.Username)
+
+	day := query.Day
+	if day == "" {
+		player, err := s.getPlayer(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		day = player.Day
+	}
+
+	attempt, err := s.storage.DailyRepository.GetAttempt(ctx, day, username)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrDailyNotStarted
+		}
+		return nil, err
+	}
+
+	return newDailyAttemptResponse(attempt, s.seed, s.clock.Now()), nil
+}
+
+func (s *DailyService) GetStats(ctx context.Context, username string) (*contracts.DailyStatsResponse, error) {
+	username = normalizeUsername(username)
+
+	stats, err := s.storage.DailyRepository.GetStats(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.Played == 0 {
+		return nil, ErrDailyPlayerNotFound
+	}
+
+	return &contracts.DailyStatsResponse{
+		Username:      username,
+		Played:        stats.Played,
+		Wins:          stats.Wins,
+		CurrentStreak: stats.StreakOn(domain.GetDay(s.clock.Now())),
+		MaxStreak:     stats.MaxStreak,
+		Distribution:  stats.Distribution,
+	}, nil
+}
+
+// getPlayer returns the player of username once they started an attempt.
+func (s *DailyService) getPlayer(ctx context.Context, username string) (*domain.DailyPlayer, error) {
+	player, err := s.storage.DailyRepository.GetPlayer(ctx, username)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrDailyNotStarted
+		}
+		return nil, err
+	}
+
+	if player.Day == "" {
+		return nil, ErrDailyNotStarted
+	}
+
+	return player, nil
+}
+
+func validDailyToken(player *domain.DailyPlayer, token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(player.Token), []byte(token)) == 1
+}
+
+// newDailyAttemptResponse always carries the commitment to the secret of the day. The
+// secret and its salt are the same for every player of the day, so they are revealed
+// only once no attempt of the day can be played anymore.
+func newDailyAttemptResponse(attempt *domain.DailyAttempt, seed string, now time.Time) *contracts.DailyAttemptResponse {
+	commitment := domain.GenerateDailyCommitment(seed, attempt.Day)
+
+	response := &contracts.DailyAttemptResponse{
+		Day:        attempt.Day,
+		Username:   attempt.Username,
+		Guesses:    attempt.Guesses,
+		Solved:     attempt.Solved,
+		Finished:   attempt.Finished,
+		Remaining:  attempt.Remaining(),
+		MaxGuesses: domain.DAILY_MAX_GUESSES,
+		Commitment: commitment.Digest,
+	}
+
+	if attempt.Finished {
+		response.Share = attempt.ShareSummary()
+	}
+
+	if !domain.IsDailyPlayable(attempt.Day, now) {
+		response.Secret = domain.GenerateDailyCombination(seed, attempt.Day)
+		response.Salt = commitment.Salt
+	}
+
+	return response
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/store"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newRedisDailyService(t *testing.T, clock domain.Clock) contracts.IDailyService {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return NewDailyService(store.NewRedisStorage(rdb, store.Config{}), "seed", clock, domain.NewSeededRandom(1))
+}
+
+// wrongDailyGuess returns a valid guess that is not the secret of day.
+func wrongDailyGuess(day string) int {
+	if domain.GenerateDailyCombination("seed", day) == "1234" {
+		return 5678
+	}
+	return 1234
+}
+
+func TestDailyToken(t *testing.T) {
+	clock := &domain.FixedClock{At: testNow}
+	service := newRedisDailyService(t, clock)
+	ctx := context.Background()
+	guess := wrongDailyGuess(domain.GetDay(clock.Now()))
+
+	started, err := service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "Alice"})
+	assertError(t, err, nil)
+	if started.Token == "" {
+		t.Fatal("expected the first start to issue a token")
+	}
+
+	for _, token := range []string{"", "someone-else"} {
+		_, err = service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: token, Guess: guess})
+		assertError(t, err, ErrDailyInvalidToken)
+	}
+
+	guessed, err := service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: started.Token, Guess: guess})
+	assertError(t, err, nil)
+	if len(guessed.Guesses) != 1 {
+		t.Fatalf("expected the guess to be played, got %+v", guessed.Guesses)
+	}
+
+	clock.Advance(24 * time.Hour)
+
+	_, err = service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "alice"})
+	assertError(t, err, ErrDailyInvalidToken)
+
+	restarted, err := service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "alice", Token: started.Token})
+	assertError(t, err, nil)
+	if restarted.Token != "" {
+		t.Fatalf("expected the token to be issued only once, got %q", restarted.Token)
+	}
+
+	_, err = service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "bob", Token: started.Token, Guess: guess})
+	assertError(t, err, ErrDailyNotStarted)
+}
+
+func TestDailyGuessAfterMidnight(t *testing.T) {
+	clock := &domain.FixedClock{At: time.Date(2024, 3, 9, 23, 59, 30, 0, time.UTC)}
+	service := newRedisDailyService(t, clock)
+	ctx := context.Background()
+
+	started, err := service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "alice"})
+	assertError(t, err, nil)
+
+	clock.Advance(time.Minute)
+
+	guessed, err := service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: started.Token, Guess: wrongDailyGuess("2024-03-09")})
+	assertError(t, err, nil)
+	if guessed.Day != "2024-03-09" || len(guessed.Guesses) != 1 {
+		t.Fatalf("expected the guess on the attempt of 2024-03-09, got %v with %v guesses", guessed.Day, len(guessed.Guesses))
+	}
+
+	attempt, err := service.GetAttempt(ctx, contracts.DailyAttemptQuery{Username: "alice"})
+	assertError(t, err, nil)
+	if attempt.Day != "2024-03-09" {
+		t.Fatalf("expected the attempt being played, got %v", attempt.Day)
+	}
+
+	secret, _ := strconv.Atoi(domain.GenerateDailyCombination("seed", "2024-03-09"))
+	solved, err := service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: started.Token, Guess: secret})
+	assertError(t, err, nil)
+	if !solved.Solved {
+		t.Fatal("expected the secret of 2024-03-09 to solve the attempt")
+	}
+}
+
+func TestDailyRevealsSecretAfterTheDay(t *testing.T) {
+	clock := &domain.FixedClock{At: testNow}
+	service := newRedisDailyService(t, clock)
+	ctx := context.Background()
+	day := domain.GetDay(clock.Now())
+
+	started, err := service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "alice"})
+	assertError(t, err, nil)
+
+	secret, _ := strconv.Atoi(domain.GenerateDailyCombination("seed", day))
+	solved, err := service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: started.Token, Guess: secret})
+	assertError(t, err, nil)
+	if !solved.Finished || solved.Commitment == "" || solved.Share == "" {
+		t.Fatalf("expected a finished attempt with its commitment and share, got %+v", solved)
+	}
+	if solved.Secret != "" || solved.Salt != "" {
+		t.Fatalf("expected the secret to stay hidden during %v, got %q and %q", day, solved.Secret, solved.Salt)
+	}
+
+	attempt, err := service.GetAttempt(ctx, contracts.DailyAttemptQuery{Username: "alice"})
+	assertError(t, err, nil)
+	if attempt.Secret != "" || attempt.Salt != "" {
+		t.Fatalf("expected the secret to stay hidden during %v, got %q and %q", day, attempt.Secret, attempt.Salt)
+	}
+
+	clock.Advance(24 * time.Hour)
+
+	revealed, err := service.GetAttempt(ctx, contracts.DailyAttemptQuery{Username: "alice", Day: day})
+	assertError(t, err, nil)
+	if revealed.Secret != fmt.Sprint(secret) {
+		t.Fatalf("expected the secret %v once the day is over, got %q", secret, revealed.Secret)
+	}
+	if !domain.VerifyCommitment(revealed.Commitment, revealed.Secret, revealed.Salt) {
+		t.Fatalf("expected the revealed secret to match the commitment %v", revealed.Commitment)
+	}
+
+	_, err = service.GetAttempt(ctx, contracts.DailyAttemptQuery{Username: "alice", Day: domain.GetDay(clock.Now())})
+	assertError(t, err, ErrDailyNotStarted)
+}
+
+func TestDailyGuessAfterTheReveal(t *testing.T) {
+	clock := &domain.FixedClock{At: time.Date(2024, 3, 9, 23, 59, 30, 0, time.UTC)}
+	service := newRedisDailyService(t, clock)
+	ctx := context.Background()
+	secret, _ := strconv.Atoi(domain.GenerateDailyCombination("seed", "2024-03-09"))
+	query := contracts.DailyAttemptQuery{Username: "bob", Day: "2024-03-09"}
+
+	alice, err := service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "alice"})
+	assertError(t, err, nil)
+	bob, err := service.StartAttempt(ctx, contracts.StartDailyCommand{Username: "bob"})
+	assertError(t, err, nil)
+	_, err = service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "bob", Token: bob.Token, Guess: secret})
+	assertError(t, err, nil)
+
+	// within the grace period alice can still play, so bob's secret stays hidden
+	clock.Advance(domain.DAILY_GRACE_PERIOD / 2)
+
+	attempt, err := service.GetAttempt(ctx, query)
+	assertError(t, err, nil)
+	if attempt.Secret != "" || attempt.Salt != "" {
+		t.Fatalf("expected the secret to stay hidden during the grace period, got %q and %q", attempt.Secret, attempt.Salt)
+	}
+
+	_, err = service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: alice.Token, Guess: wrongDailyGuess("2024-03-09")})
+	assertError(t, err, nil)
+
+	clock.Advance(domain.DAILY_GRACE_PERIOD)
+
+	revealed, err := service.GetAttempt(ctx, query)
+	assertError(t, err, nil)
+	if revealed.Secret != fmt.Sprint(secret) {
+		t.Fatalf("expected the secret %v once the grace period is over, got %q", secret, revealed.Secret)
+	}
+
+	_, err = service.MakeGuess(ctx, contracts.DailyGuessCommand{Username: "alice", Token: alice.Token, Guess: secret})
+	assertError(t, err, ErrDailyAttemptIsOver)
+
+	_, err = service.GetStats(ctx, "alice")
+	assertError(t, err, ErrDailyPlayerNotFound)
+}
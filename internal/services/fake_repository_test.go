@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+// fakeMatchesRepository keeps matches in memory. Like the Redis repository, every
+// read returns a copy, so the service can not change stored state by accident.
+type fakeMatchesRepository struct {
+	mu       sync.Mutex
+	matches  map[string]*domain.Match
+	expired  map[string]bool
+	chat     map[string][]domain.ChatMessage
+	watchers map[string][]chan struct{}
+	// failures makes the named method return the error instead of doing its work
+	failures map[string]error
+}
+
+func newFakeMatchesRepository() *fakeMatchesRepository {
+	return &fakeMatchesRepository{
+		matches:  map[string]*domain.Match{},
+		expired:  map[string]bool{},
+		chat:     map[string][]domain.ChatMessage{},
+		watchers: map[string][]chan struct{}{},
+		failures: map[string]error{},
+	}
+}
+
+func (f *fakeMatchesRepository) failWith(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[method] = err
+}
+
+// put stores a copy of match, replacing any room with the same id.
+func (f *fakeMatchesRepository) put(match *domain.Match) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.matches[match.RoomId] = cloneMatch(match)
+	f.notify(match.RoomId)
+}
+
+// get returns a copy of the stored room, or nil when it does not exist.
+func (f *fakeMatchesRepository) get(roomId string) *domain.Match {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if match, exists := f.matches[roomId]; exists {
+		return cloneMatch(match)
+	}
+	return nil
+}
+
+func cloneMatch(match *domain.Match) *domain.Match {
+	data, _ := json.Marshal(match)
+	clone := &domain.Match{}
+	json.Unmarshal(data, clone)
+	return clone
+}
+
+// load must be called with the lock held.
+func (f *fakeMatchesRepository) load(method string, roomId string) (*domain.Match, error) {
+	if err := f.failures[method]; err != nil {
+		return nil, err
+	}
+	match, exists := f.matches[roomId]
+	if !exists {
+		return nil, domain.ErrEmptyResult
+	}
+	return match, nil
+}
+
+// notify must be called with the lock held.
+func (f *fakeMatchesRepository) notify(roomId string) {
+	for _, watcher := range f.watchers[roomId] {
+		select {
+		case watcher <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (f *fakeMatchesRepository) CreateMatch(ctx context.Context, command contracts.CreateMatchCommand) (*domain.Match, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.failures["CreateMatch"]; err != nil {
+		return nil, err
+	}
+
+	match := &domain.Match{
+		RoomId:                command.RoomId,
+		Players:               domain.MatchPlayers{command.Player.Id: command.Player},
+		OpponentsCombinations: domain.MatchOpponentCombinations{},
+		Guesses:               domain.MatchGuesses{},
+		Status:                domain.MatchStateWaiting,
+		IsTurnOf:              command.Player.Id,
+		Mode:                  command.Mode,
+		Series:                domain.NewMatchSeries(command.BestOf),
+		Commitments:           domain.MatchCommitments{},
+	}
+	f.matches[match.RoomId] = match
+
+	return cloneMatch(match), nil
+}
+
+func (f *fakeMatchesRepository) CreateFullRoom(ctx context.Context, command contracts.CreateFullRoomCommand) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.failures["CreateFullRoom"]; err != nil {
+		return err
+	}
+
+	if _, exists := f.matches[command.RoomId]; exists {
+		return nil
+	}
+
+	f.matches[command.RoomId] = &domain.Match{
+		RoomId:                command.RoomId,
+		Players:               command.Players,
+		OpponentsCombinations: domain.MatchOpponentCombinations{},
+		Guesses:               domain.MatchGuesses{},
+		Status:                domain.MatchStateFullRoom,
+		Mode:                  command.Mode,
+		Series:                domain.NewMatchSeries(command.BestOf),
+		Commitments:           domain.MatchCommitments{},
+		TournamentId:          command.TournamentId,
+	}
+	delete(f.expired, command.RoomId)
+
+	return nil
+}
+
+func (f *fakeMatchesRepository) GetRoomPlayers(ctx context.Context, roomId string) (domain.MatchPlayers, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("GetRoomPlayers", roomId)
+	if err != nil {
+		return nil, err
+	}
+	return cloneMatch(match).Players, nil
+}
+
+func (f *fakeMatchesRepository) SetPlayersAndFillRoom(ctx context.Context, command contracts.SetPlayersCommand) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("SetPlayersAndFillRoom", command.RoomId)
+	if err != nil {
+		return err
+	}
+	match.Players = command.Players
+	match.Status = domain.MatchStateFullRoom
+	f.notify(command.RoomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) GetMatchStatusById(ctx context.Context, roomId string) (domain.MatchStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("GetMatchStatusById", roomId)
+	if err != nil {
+		return "", err
+	}
+	return match.Status, nil
+}
+
+func (f *fakeMatchesRepository) GetAllButGuesses(ctx context.Context, roomId string) (*domain.Match, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("GetAllButGuesses", roomId)
+	if err != nil {
+		return nil, err
+	}
+	clone := cloneMatch(match)
+	clone.Guesses = nil
+	return clone, nil
+}
+
+func (f *fakeMatchesRepository) ChangeStatusAndTurn(ctx context.Context, roomId string, status domain.MatchStatus, isTurnOf string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("ChangeStatusAndTurn", roomId)
+	if err != nil {
+		return err
+	}
+	match.Status = status
+	match.IsTurnOf = isTurnOf
+	if status == domain.MatchStatePlaying {
+		match.StartedBy = isTurnOf
+	}
+	f.notify(roomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) GetAll(ctx context.Context, roomId string) (*domain.Match, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("GetAll", roomId)
+	if err != nil {
+		return nil, err
+	}
+	return cloneMatch(match), nil
+}
+
+func (f *fakeMatchesRepository) UpdateGuessesAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("UpdateGuessesAtomically", roomId)
+	if err != nil {
+		return err
+	}
+	clone := cloneMatch(match)
+	if err := update(clone); err != nil {
+		return err
+	}
+	f.matches[roomId] = clone
+	f.notify(roomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) UpdatePlayersAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("UpdatePlayersAtomically", roomId)
+	if err != nil {
+		return err
+	}
+	clone := cloneMatch(match)
+	if err := update(clone); err != nil {
+		return err
+	}
+	f.matches[roomId] = clone
+	f.notify(roomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) UpdateCombinationsAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("UpdateCombinationsAtomically", roomId)
+	if err != nil {
+		return err
+	}
+	clone := cloneMatch(match)
+	if err := update(clone); err != nil {
+		return err
+	}
+	f.matches[roomId] = clone
+	f.notify(roomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) Exists(ctx context.Context, roomId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.load("Exists", roomId)
+	return err
+}
+
+func (f *fakeMatchesRepository) Restart(ctx context.Context, roomId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("Restart", roomId)
+	if err != nil {
+		return err
+	}
+	match.OpponentsCombinations = domain.MatchOpponentCombinations{}
+	match.Guesses = domain.MatchGuesses{}
+	match.Status = domain.MatchStateFullRoom
+	match.Winner = ""
+	match.RematchOffer = nil
+	match.Commitments = nil
+	f.notify(roomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) SetRematchOffer(ctx context.Context, roomId string, offer *domain.RematchOffer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	match, err := f.load("SetRematchOffer", roomId)
+	if err != nil {
+		return err
+	}
+	match.RematchOffer = offer
+	f.notify(roomId)
+	return nil
+}
+
+func (f *fakeMatchesRepository) SweepExpiredRooms(ctx context.Context) ([]domain.ExpiredRoom, error) {
+	return []domain.ExpiredRoom{}, nil
+}
+
+func (f *fakeMatchesRepository) IsExpired(ctx context.Context, roomId string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.failures["IsExpired"]; err != nil {
+		return false, err
+	}
+	return f.expired[roomId], nil
+}
+
+// expire drops the room and remembers it, the way a swept room leaves a tombstone.
+func (f *fakeMatchesRepository) expire(roomId string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.matches, roomId)
+	f.expired[roomId] = true
+	f.notify(roomId)
+}
+
+func (f *fakeMatchesRepository) CountRoomsByStatus(ctx context.Context) (map[domain.MatchStatus]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	counts := map[domain.MatchStatus]int{}
+	for _, match := range f.matches {
+		counts[match.Status]++
+	}
+	return counts, nil
+}
+
+func (f *fakeMatchesRepository) WatchRoom(ctx context.Context, roomId string) (<-chan struct{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.failures["WatchRoom"]; err != nil {
+		return nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	f.watchers[roomId] = append(f.watchers[roomId], changes)
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.watchers[roomId] = slices.DeleteFunc(f.watchers[roomId], func(watcher chan struct{}) bool { return watcher == changes })
+		close(changes)
+	}()
+
+	return changes, nil
+}
+
+func (f *fakeMatchesRepository) AppendChatMessage(ctx context.Context, roomId string, message domain.ChatMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.load("AppendChatMessage", roomId); err != nil {
+		return err
+	}
+	f.chat[roomId] = append(f.chat[roomId], message)
+	return nil
+}
+
+func (f *fakeMatchesRepository) GetChatMessages(ctx context.Context, roomId string) ([]domain.ChatMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.failures["GetChatMessages"]; err != nil {
+		return nil, err
+	}
+	return append([]domain.ChatMessage{}, f.chat[roomId]...), nil
+}
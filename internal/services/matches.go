@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"time"
 
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
@@ -14,26 +17,75 @@ var (
 	ErrMatchNotFullRoom       = fmt.Errorf("match is being played already or room is not completed")
 	ErrInvalidCombination     = fmt.Errorf("invalid combination")
 	ErrMatchNotFound          = fmt.Errorf("match not found")
+	ErrMatchExpired           = fmt.Errorf("match expired after being inactive for too long")
 	ErrExpectingCombinations  = fmt.Errorf("can not start game until players set combinations")
 	ErrMatchNotStarted        = fmt.Errorf("match has not started yet or has finished already")
 	ErrMatchIsFinished        = fmt.Errorf("match is finished")
 	ErrNotYourTurn            = fmt.Errorf("this is not your turn")
+	ErrAlreadySolved          = fmt.Errorf("you already cracked the combination, wait for your opponent")
+	ErrSeriesIsOver           = fmt.Errorf("series is over, create a new room to play again")
+	ErrMatchNotFinished       = fmt.Errorf("rematch can only be offered once the match is finished")
+	ErrRematchAlreadyOffered  = fmt.Errorf("you already offered a rematch, wait for your opponent")
+	ErrNoRematchOffer         = fmt.Errorf("there is no pending rematch offer")
+	ErrOwnRematchOffer        = fmt.Errorf("you can not answer your own rematch offer")
 )
 
+const MATCH_WATCH_POLL_INTERVAL = time.Second * 15
+
+type GameConfig struct {
+	DefaultMode       domain.MatchMode
+	DefaultBestOf     int
+	RematchTimeout    time.Duration
+	RaceFinishTimeout time.Duration
+	// OnFinish is called once for every match that finishes, won or not, whichever
+	// flow finished it. It may be nil.
+	OnFinish func(match *domain.Match)
+}
+
 type MatchesService struct {
 	storage contracts.Storage
+	config  GameConfig
+	clock   domain.Clock
+	random  domain.RandomSource
 }
 
-func NewMatchesService(storage contracts.Storage) contracts.IMatchesService {
+func NewMatchesService(storage contracts.Storage, config GameConfig, clock domain.Clock, random domain.RandomSource) contracts.IMatchesService {
 	return &MatchesService{
 		storage: storage,
+		config:  config,
+		clock:   clock,
+		random:  random,
 	}
 }
 
 func (s *MatchesService) CreateRoom(ctx context.Context, command contracts.CreateRoomCommand) (*contracts.CreateRoomResponse, error) {
 
-	playerId := domain.GeneratePlayerId()
-	match, err := s.storage.MatchesRepository.CreateMatch(ctx, domain.Player{Id: playerId, Username: command.Username})
+	mode := command.Mode
+	if mode == "" {
+		mode = s.config.DefaultMode
+	}
+
+	bestOf := command.BestOf
+	if bestOf == 0 {
+		bestOf = s.config.DefaultBestOf
+	}
+
+	roomId, err := domain.GenerateMatchId(s.random)
+	if err != nil {
+		return nil, err
+	}
+
+	playerId, err := domain.GeneratePlayerId(s.random)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.storage.MatchesRepository.CreateMatch(ctx, contracts.CreateMatchCommand{
+		RoomId: roomId,
+		Player: domain.Player{Id: playerId, Username: command.Username},
+		Mode:   mode,
+		BestOf: bestOf,
+	})
 
 	if err != nil {
 		return nil, err
@@ -41,48 +93,47 @@ func (s *MatchesService) CreateRoom(ctx context.Context, command contracts.Creat
 
 	resp := &contracts.CreateRoomResponse{
 		RoomId: match.RoomId,
-		Player: contracts.PlayerResponse{
-			Username: command.Username,
-			Id:       playerId,
-		},
+		Mode:   match.Mode,
+		BestOf: match.Series.BestOf,
+		Player: newPlayerCredentialsResponse(domain.Player{Id: playerId, Username: command.Username}),
 	}
 
 	return resp, nil
 }
 
 func (s *MatchesService) JoinRoom(ctx context.Context, joinRoomCommand contracts.JoinRoomCommand) (*contracts.JoinRoomResponse, error) {
-	players, err := s.storage.MatchesRepository.GetRoomPlayers(ctx, joinRoomCommand.RoomId)
+	playerId, err := domain.GeneratePlayerId(s.random)
 	if err != nil {
-		if err == domain.ErrEmptyResult {
-			return nil, ErrMatchNotFound
-		}
 		return nil, err
 	}
 
-	if len(players) != 1 {
-		return nil, ErrCanNotAddAnotherPlayer
-	}
-
 	newPlayer := domain.Player{
-		Id:       domain.GeneratePlayerId(),
+		Id:       playerId,
 		Username: joinRoomCommand.Username,
 	}
 
-	players[newPlayer.Id] = newPlayer
+	// the check and the write run in one transaction, two players joining at once can
+	// not both take the empty seat
+	err = s.storage.MatchesRepository.UpdatePlayersAtomically(ctx, joinRoomCommand.RoomId, func(match *domain.Match) error {
+		if len(match.Players) != 1 {
+			return ErrCanNotAddAnotherPlayer
+		}
+
+		match.Players[newPlayer.Id] = newPlayer
+		match.Status = domain.MatchStateFullRoom
+		return nil
+	})
 
-	if err = s.storage.MatchesRepository.SetPlayersAndFillRoom(ctx, contracts.SetPlayersCommand{
-		RoomId:  joinRoomCommand.RoomId,
-		Players: players,
-	}); err != nil {
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, joinRoomCommand.RoomId)
+		}
 		return nil, err
 	}
 
 	return &contracts.JoinRoomResponse{
 		RoomId: joinRoomCommand.RoomId,
-		Player: contracts.PlayerResponse{
-			Id:       newPlayer.Id,
-			Username: newPlayer.Username,
-		},
+		Player: newPlayerCredentialsResponse(newPlayer),
 	}, nil
 }
 
@@ -91,41 +142,47 @@ func (s *MatchesService) SetCombination(ctx context.Context, command contracts.S
 	if err := domain.ValidateCombination(strCombination); err != nil {
 		switch err {
 		case domain.ErrInvalidCombination, domain.ErrInvalidUniqueCombination:
-			return nil, fmt.Errorf("%w: "+err.Error(), ErrInvalidCombination)
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCombination, err)
 		}
 		return nil, err
 	}
 
-	status, err := s.storage.MatchesRepository.GetMatchStatusById(ctx, command.RoomId)
-	if err != nil {
-		if err == domain.ErrEmptyResult {
-			return nil, ErrMatchNotFound
+	// both players usually set their combinations at the same time, the read and the
+	// write run in one transaction so neither combination is lost
+	err := s.storage.MatchesRepository.UpdateCombinationsAtomically(ctx, command.RoomId, func(match *domain.Match) error {
+		if _, exists := match.Players[command.PlayerId]; !exists {
+			return ErrPlayerNotInRoom
 		}
-		return nil, err
-	}
 
-	if status != domain.MatchStateFullRoom {
-		return nil, ErrMatchNotFullRoom
-	}
-
-	match, err := s.storage.MatchesRepository.GetPlayersAndCombinations(ctx, command.RoomId)
+		if match.Status != domain.MatchStateFullRoom {
+			return ErrMatchNotFullRoom
+		}
 
-	if err != nil {
-		return nil, err
-	}
+		for key := range match.Players {
+			if key == command.PlayerId {
+				continue
+			}
+			match.OpponentsCombinations[key] = strCombination
+			break
+		}
 
-	for key := range match.Players {
-		if key == command.PlayerId {
-			continue
+		// a new salt every time, setting the same combination again must not give the
+		// same digest
+		commitment, err := domain.NewSecretCommitment(s.random, strCombination)
+		if err != nil {
+			return err
 		}
-		match.OpponentsCombinations[key] = strCombination
-		break
-	}
+		if match.Commitments == nil {
+			match.Commitments = make(domain.MatchCommitments)
+		}
+		match.Commitments[command.PlayerId] = commitment
+		return nil
+	})
 
-	if err := s.storage.MatchesRepository.SetPlayerCombination(ctx, contracts.SetOpponentCombinationsCommand{
-		RoomId:       command.RoomId,
-		Combinations: match.OpponentsCombinations,
-	}); err != nil {
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, command.RoomId)
+		}
 		return nil, err
 	}
 
@@ -140,7 +197,7 @@ func (s *MatchesService) StartGame(ctx context.Context, roomId string) (*contrac
 
 	if err != nil {
 		if errors.Is(err, domain.ErrEmptyResult) {
-			return nil, ErrMatchNotFound
+			return nil, s.notFound(ctx, roomId)
 		}
 		return nil, err
 	}
@@ -153,27 +210,126 @@ func (s *MatchesService) StartGame(ctx context.Context, roomId string) (*contrac
 		return nil, ErrExpectingCombinations
 	}
 
-	isTurnOf, err := match.GetRandomUser()
+	isTurnOf := match.Series.NextStarter(match.Players)
 
-	if err != nil {
-		return nil, ErrMatchNotFullRoom
+	if isTurnOf == "" {
+		isTurnOf, err = match.GetRandomUser(s.random)
+
+		if err != nil {
+			return nil, ErrMatchNotFullRoom
+		}
+	}
+
+	if match.Mode == domain.MatchModeRace {
+		isTurnOf = ""
 	}
 
 	if err := s.storage.MatchesRepository.ChangeStatusAndTurn(ctx, roomId, domain.MatchStatePlaying, isTurnOf); err != nil {
 		return nil, err
 	}
 
+	commitments := make(map[string]string, len(match.Commitments))
+	for playerId, commitment := range match.Commitments {
+		commitments[domain.PlayerHandle(playerId)] = commitment.Digest
+	}
+
 	return &contracts.StartMatchResponse{
-		IsTurnOf: isTurnOf,
+		Mode:        match.Mode,
+		IsTurnOf:    domain.PlayerHandle(isTurnOf),
+		Commitments: commitments,
 	}, nil
 }
 
-func (s *MatchesService) RestartGame(ctx context.Context, roomId string) (*contracts.SuccessResponse, error) {
-	err := s.storage.MatchesRepository.Exists(ctx, roomId)
+func (s *MatchesService) OfferRematch(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error) {
+	match, err := s.getRematchCandidate(ctx, command)
 	if err != nil {
+		return nil, err
+	}
+
+	if match.RematchOffer.IsPending(s.clock.Now()) {
+		if match.RematchOffer.OfferedBy == command.PlayerId {
+			return nil, ErrRematchAlreadyOffered
+		}
+		return s.restart(ctx, command.RoomId)
+	}
+
+	offer := domain.NewRematchOffer(command.PlayerId, s.config.RematchTimeout, s.clock.Now())
+	if err := s.storage.MatchesRepository.SetRematchOffer(ctx, command.RoomId, offer); err != nil {
+		return nil, err
+	}
+
+	return &contracts.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *MatchesService) AcceptRematch(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error) {
+	match, err := s.getRematchCandidate(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if !match.RematchOffer.IsPending(s.clock.Now()) {
+		return nil, ErrNoRematchOffer
+	}
+
+	if match.RematchOffer.OfferedBy == command.PlayerId {
+		return nil, ErrOwnRematchOffer
+	}
+
+	return s.restart(ctx, command.RoomId)
+}
+
+func (s *MatchesService) DeclineRematch(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error) {
+	match, err := s.getRematchCandidate(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if !match.RematchOffer.IsPending(s.clock.Now()) {
+		return nil, ErrNoRematchOffer
+	}
+
+	if match.RematchOffer.OfferedBy == command.PlayerId {
+		return nil, ErrOwnRematchOffer
+	}
+
+	if err := s.storage.MatchesRepository.SetRematchOffer(ctx, command.RoomId, nil); err != nil {
+		return nil, err
+	}
+
+	return &contracts.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *MatchesService) getRematchCandidate(ctx context.Context, command contracts.RematchCommand) (*domain.Match, error) {
+	match, err := s.storage.MatchesRepository.GetAllButGuesses(ctx, command.RoomId)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, command.RoomId)
+		}
+		return nil, err
+	}
+
+	if _, exists := match.Players[command.PlayerId]; !exists {
 		return nil, ErrMatchNotFound
 	}
 
+	if match.Status != domain.MatchStateFinished {
+		return nil, ErrMatchNotFinished
+	}
+
+	// only a decided best-of-N ends the room, best-of-1 rooms keep playing unless
+	// the game decided a tournament pairing
+	if match.Series.IsOver() || (match.TournamentId != "" && match.Series.Winner != "") {
+		return nil, ErrSeriesIsOver
+	}
+
+	return match, nil
+}
+
+func (s *MatchesService) restart(ctx context.Context, roomId string) (*contracts.SuccessResponse, error) {
 	if err := s.storage.MatchesRepository.Restart(ctx, roomId); err != nil {
 		return nil, err
 	}
@@ -183,79 +339,488 @@ func (s *MatchesService) RestartGame(ctx context.Context, roomId string) (*contr
 	}, nil
 }
 
+// MakeGuess reads the room, checks the player can play and records the guess in one
+// transaction, so a player sending several guesses at once only gets the first one
+// played on Turns and a race can not be decided twice.
 func (s *MatchesService) MakeGuess(ctx context.Context, command contracts.MakeGuessCommand) (*contracts.MakeGuessResponse, error) {
 	guess := fmt.Sprint(command.Guess)
 
 	if err := domain.ValidateCombination(guess); err != nil {
 		switch err {
 		case domain.ErrInvalidCombination, domain.ErrInvalidUniqueCombination:
-			return nil, fmt.Errorf("%w: "+err.Error(), ErrInvalidCombination)
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCombination, err)
 		}
 		return nil, err
 	}
 
-	match, err := s.storage.MatchesRepository.GetAll(ctx, command.RoomId)
+	var result *domain.Match
+
+	err := s.storage.MatchesRepository.UpdateGuessesAtomically(ctx, command.RoomId, func(match *domain.Match) error {
+		if _, exists := match.Players[command.PlayerId]; !exists {
+			return ErrMatchNotFound
+		}
+
+		if match.Status != domain.MatchStatePlaying {
+			return ErrMatchNotStarted
+		}
+
+		result = match
+		if match.Mode == domain.MatchModeRace {
+			return s.makeRaceGuess(match, command.PlayerId, guess)
+		}
+		return s.makeTurnsGuess(match, command.PlayerId, guess)
+	})
 
 	if err != nil {
 		if errors.Is(err, domain.ErrEmptyResult) {
-			return nil, ErrMatchNotFound
+			return nil, s.notFound(ctx, command.RoomId)
 		}
 		return nil, err
 	}
 
-	if _, exists := match.Players[command.PlayerId]; !exists {
-		return nil, ErrMatchNotFound
+	// the guess is only played on a match being played, so a finished match was
+	// finished by this guess
+	if result.Status == domain.MatchStateFinished {
+		s.matchFinished(ctx, result)
+	}
+
+	return &contracts.MakeGuessResponse{
+		IsWinner: result.Winner == command.PlayerId,
+		Winner:   domain.PlayerHandle(result.Winner),
+		Guesses:  newGuessesResponse(result.Guesses),
+	}, nil
+}
+
+func (s *MatchesService) makeTurnsGuess(match *domain.Match, playerId, guess string) error {
+	if match.IsTurnOf != playerId {
+		return ErrNotYourTurn
+	}
+
+	if err := s.recordGuess(match, playerId, guess); err != nil {
+		return err
+	}
+
+	guesses := match.Guesses[playerId]
+	if guesses[len(guesses)-1].IsWinnerCombination {
+		match.Finish(playerId)
+	}
+
+	newTurnOf := ""
+	for key := range match.Players {
+		if key != playerId {
+			newTurnOf = key
+		}
+	}
+
+	if newTurnOf == "" {
+		return ErrMatchNotStarted
+	}
+
+	match.IsTurnOf = newTurnOf
+	return nil
+}
+
+func (s *MatchesService) makeRaceGuess(match *domain.Match, playerId, guess string) error {
+	// a guess arriving after the opponent ran out of time to catch up closes the
+	// race instead of being played
+	if winner := match.ResolveRaceWinner(s.clock.Now(), s.config.RaceFinishTimeout); winner != "" {
+		match.Finish(winner)
+		return nil
 	}
 
-	if match.Status != domain.MatchStatePlaying {
-		return nil, ErrMatchNotStarted
+	if match.HasSolved(playerId) {
+		return ErrAlreadySolved
 	}
 
-	if match.IsTurnOf != command.PlayerId {
-		return nil, ErrNotYourTurn
+	if err := s.recordGuess(match, playerId, guess); err != nil {
+		return err
 	}
 
-	opponentCombination, exists := match.OpponentsCombinations[command.PlayerId]
+	if winner := match.ResolveRaceWinner(s.clock.Now(), s.config.RaceFinishTimeout); winner != "" {
+		match.Finish(winner)
+	}
+	return nil
+}
+
+func (s *MatchesService) recordGuess(match *domain.Match, playerId, guess string) error {
+	opponentCombination, exists := match.OpponentsCombinations[playerId]
 
 	if !exists {
-		return nil, ErrMatchNotStarted
+		return ErrMatchNotStarted
 	}
 
 	guessItem, err := match.GetNewGuess(guess, opponentCombination)
 
 	if err != nil {
-		return nil, ErrInvalidCombination
+		return ErrInvalidCombination
+	}
+
+	guessItem.Round = len(match.Guesses[playerId]) + 1
+	guessItem.PlayedAt = s.clock.Now()
+	match.Guesses[playerId] = append(match.Guesses[playerId], *guessItem)
+	return nil
+}
+
+// closeLapsedRace finishes a race whose remaining player let the finish timeout pass,
+// so the result shows up without waiting for another guess.
+func (s *MatchesService) closeLapsedRace(ctx context.Context, roomId string) (*domain.Match, error) {
+	var result *domain.Match
+	finished := false
+
+	err := s.storage.MatchesRepository.UpdateGuessesAtomically(ctx, roomId, func(match *domain.Match) error {
+		finished = false
+		if match.Mode == domain.MatchModeRace && match.Status == domain.MatchStatePlaying {
+			if winner := match.ResolveRaceWinner(s.clock.Now(), s.config.RaceFinishTimeout); winner != "" {
+				match.Finish(winner)
+				finished = true
+			}
+		}
+		result = match
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if _, exists := match.Guesses[command.PlayerId]; !exists {
-		match.Guesses[command.PlayerId] = []domain.GuessesHistoryItem{}
+	// a concurrent read may have closed the race first
+	if finished {
+		s.matchFinished(ctx, result)
 	}
+	return result, nil
+}
+
+func (s *MatchesService) matchFinished(ctx context.Context, match *domain.Match) {
+	matchFinished(ctx, s.storage, s.config, match)
+}
 
-	match.Guesses[command.PlayerId] = append(match.Guesses[command.PlayerId], *guessItem)
+// matchFinished runs once for every match that finishes, after it is stored. The
+// change is made already, so a failure to pass the result on to its tournament is not
+// reported; the next read of the bracket collects the result from the room instead.
+func matchFinished(ctx context.Context, storage contracts.Storage, config GameConfig, match *domain.Match) {
+	_ = recordTournamentResult(ctx, storage, match)
 
-	var newTurnOf string = ""
-	for key := range match.Players {
-		if key != match.IsTurnOf {
-			newTurnOf = key
+	if config.OnFinish != nil {
+		config.OnFinish(match)
+	}
+}
+
+func (s *MatchesService) GetMatch(ctx context.Context, roomId string) (*contracts.MatchStateResponse, error) {
+	match, err := s.storage.MatchesRepository.GetAll(ctx, roomId)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, roomId)
 		}
-		continue
+		return nil, err
 	}
 
-	if newTurnOf == "" {
-		return nil, ErrMatchNotStarted
+	if match.Mode == domain.MatchModeRace && match.Status == domain.MatchStatePlaying &&
+		match.ResolveRaceWinner(s.clock.Now(), s.config.RaceFinishTimeout) != "" {
+		match, err = s.closeLapsedRace(ctx, roomId)
+		if err != nil {
+			if errors.Is(err, domain.ErrEmptyResult) {
+				return nil, s.notFound(ctx, roomId)
+			}
+			return nil, err
+		}
 	}
 
-	if err := s.storage.MatchesRepository.SetNewGuess(ctx, contracts.SetNewGuessCommand{
-		RoomId:   command.RoomId,
-		Guesses:  match.Guesses,
-		IsTurnOf: newTurnOf,
-		IsWinner: guessItem.IsWinnerCombination,
-	}); err != nil {
+	return newMatchStateResponse(match, s.clock.Now()), nil
+}
+
+func (s *MatchesService) SendChatMessage(ctx context.Context, command contracts.ChatMessageCommand) (*contracts.ChatMessageResponse, error) {
+	players, err := s.storage.MatchesRepository.GetRoomPlayers(ctx, command.RoomId)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, command.RoomId)
+		}
 		return nil, err
 	}
 
-	return &contracts.MakeGuessResponse{
-		IsWinner: guessItem.IsWinnerCombination,
-		Guesses:  match.Guesses,
+	player, exists := players[command.PlayerId]
+	if !exists {
+		return nil, ErrPlayerNotInRoom
+	}
+
+	message, err := domain.NewChatMessage(s.random, player.Id, command.Message, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.MatchesRepository.AppendChatMessage(ctx, command.RoomId, message); err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, command.RoomId)
+		}
+		return nil, err
+	}
+
+	return newChatMessageResponse(message, players), nil
+}
+
+func (s *MatchesService) GetChat(ctx context.Context, roomId string) (*contracts.ChatResponse, error) {
+	players, err := s.storage.MatchesRepository.GetRoomPlayers(ctx, roomId)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, s.notFound(ctx, roomId)
+		}
+		return nil, err
+	}
+
+	messages, err := s.storage.MatchesRepository.GetChatMessages(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &contracts.ChatResponse{
+		Messages: make([]contracts.ChatMessageResponse, 0, len(messages)),
+	}
+	for _, message := range messages {
+		res.Messages = append(res.Messages, *newChatMessageResponse(message, players))
+	}
+
+	return res, nil
+}
+
+// VerifyCommitment checks a revealed secret against its commitment. Clients can do
+// the same on their own, see domain.CommitSecret.
+func (s *MatchesService) VerifyCommitment(ctx context.Context, command contracts.VerifyCommitmentCommand) (*contracts.VerifyCommitmentResponse, error) {
+	return &contracts.VerifyCommitmentResponse{
+		Valid: domain.VerifyCommitment(command.Commitment, command.Secret, command.Salt),
 	}, nil
 }
+
+// newChatMessageResponse resolves the username at read time; players kicked from
+// the room keep their messages with an empty username.
+func newChatMessageResponse(message domain.ChatMessage, players domain.MatchPlayers) *contracts.ChatMessageResponse {
+	return &contracts.ChatMessageResponse{
+		Id:       message.Id,
+		Handle:   domain.PlayerHandle(message.PlayerId),
+		Username: players[message.PlayerId].Username,
+		Message:  message.Message,
+		SentAt:   message.SentAt,
+	}
+}
+
+// WatchMatch sends a snapshot of the match and then its state every time it changes.
+// Besides the change signals it polls, so expiry and rematch offers running out are
+// noticed too. The channel is closed when ctx is done or once the match is gone.
+func (s *MatchesService) WatchMatch(ctx context.Context, roomId string) (<-chan contracts.MatchEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// subscribe before reading so no change falls between the snapshot and the stream
+	changes, err := s.storage.MatchesRepository.WatchRoom(ctx, roomId)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	current, err := s.GetMatch(ctx, roomId)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	events := make(chan contracts.MatchEvent)
+	go func() {
+		defer cancel()
+		defer close(events)
+
+		send := func(eventType contracts.MatchEventType, match *contracts.MatchStateResponse) bool {
+			select {
+			case events <- contracts.MatchEvent{Type: eventType, Match: match, At: s.clock.Now()}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if !send(contracts.MatchEventSnapshot, current) {
+			return
+		}
+
+		ticker := time.NewTicker(MATCH_WATCH_POLL_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+			case <-ticker.C:
+			}
+
+			next, err := s.GetMatch(ctx, roomId)
+			switch {
+			case errors.Is(err, ErrMatchExpired):
+				send(contracts.MatchEventMatchExpired, nil)
+				return
+			case errors.Is(err, ErrMatchNotFound):
+				send(contracts.MatchEventMatchClosed, nil)
+				return
+			case err != nil:
+				// storage errors are retried on the next signal or tick
+				continue
+			}
+
+			eventType := matchEventType(current, next)
+			if eventType == "" {
+				continue
+			}
+
+			current = next
+			if !send(eventType, current) {
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// matchEventType names the change between two states of a match. It is empty when
+// nothing public changed, e.g. when a player sets a combination.
+func matchEventType(previous, current *contracts.MatchStateResponse) contracts.MatchEventType {
+	switch {
+	case len(current.Players) > len(previous.Players):
+		return contracts.MatchEventPlayerJoined
+	case len(current.Players) < len(previous.Players):
+		return contracts.MatchEventPlayerLeft
+	case previous.Status == domain.MatchStateFinished && current.Status != domain.MatchStateFinished:
+		return contracts.MatchEventRematchAccepted
+	case previous.Status != domain.MatchStateFinished && current.Status == domain.MatchStateFinished:
+		return contracts.MatchEventMatchFinished
+	case previous.Status != domain.MatchStatePlaying && current.Status == domain.MatchStatePlaying:
+		return contracts.MatchEventMatchStarted
+	case countGuesses(current.Guesses) > countGuesses(previous.Guesses):
+		return contracts.MatchEventGuessMade
+	case previous.Rematch == nil && current.Rematch != nil:
+		return contracts.MatchEventRematchOffered
+	case previous.Rematch != nil && current.Rematch == nil:
+		return contracts.MatchEventRematchCancelled
+	case !reflect.DeepEqual(previous, current):
+		return contracts.MatchEventUpdated
+	}
+	return ""
+}
+
+func countGuesses(guesses domain.MatchGuesses) int {
+	count := 0
+	for _, items := range guesses {
+		count += len(items)
+	}
+	return count
+}
+
+func newMatchStateResponse(match *domain.Match, now time.Time) *contracts.MatchStateResponse {
+	players := make([]contracts.PlayerResponse, 0, len(match.Players))
+	for _, player := range match.Players {
+		players = append(players, contracts.PlayerResponse{
+			Username: player.Username,
+			Handle:   player.Handle(),
+		})
+	}
+
+	// map order is random, sorting keeps consecutive states comparable
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Username != players[j].Username {
+			return players[i].Username < players[j].Username
+		}
+		return players[i].Handle < players[j].Handle
+	})
+
+	rounds := make([]contracts.RoundResultResponse, 0, len(match.Series.Rounds))
+	for _, round := range match.Series.Rounds {
+		rounds = append(rounds, contracts.RoundResultResponse{
+			Round:     round.Round,
+			Winner:    domain.PlayerHandle(round.Winner),
+			Guesses:   round.Guesses,
+			StartedBy: domain.PlayerHandle(round.StartedBy),
+		})
+	}
+
+	score := make(map[string]int, len(match.Players))
+	for _, player := range match.Players {
+		score[player.Handle()] = 0
+	}
+	for playerId, wins := range match.Series.Score() {
+		score[domain.PlayerHandle(playerId)] = wins
+	}
+
+	var rematch *contracts.RematchOfferResponse
+	if match.RematchOffer.IsPending(now) {
+		rematch = &contracts.RematchOfferResponse{
+			OfferedBy: domain.PlayerHandle(match.RematchOffer.OfferedBy),
+			ExpiresAt: match.RematchOffer.ExpiresAt,
+		}
+	}
+
+	return &contracts.MatchStateResponse{
+		RoomId:   match.RoomId,
+		Mode:     match.Mode,
+		Status:   match.Status,
+		IsTurnOf: domain.PlayerHandle(match.IsTurnOf),
+		Winner:   domain.PlayerHandle(match.Winner),
+		Players:  players,
+		Guesses:  newGuessesResponse(match.Guesses),
+		Series: contracts.SeriesResponse{
+			BestOf: match.Series.BestOf,
+			Score:  score,
+			Rounds: rounds,
+			Winner: domain.PlayerHandle(match.Series.Winner),
+		},
+		Rematch:     rematch,
+		Commitments: newCommitmentsResponse(match),
+	}
+}
+
+// newCommitmentsResponse publishes the digests once the match is being played and
+// reveals every secret with its salt once it is finished.
+func newCommitmentsResponse(match *domain.Match) map[string]contracts.CommitmentResponse {
+	if match.Status != domain.MatchStatePlaying && match.Status != domain.MatchStateFinished {
+		return nil
+	}
+
+	commitments := make(map[string]contracts.CommitmentResponse, len(match.Commitments))
+	for playerId, commitment := range match.Commitments {
+		response := contracts.CommitmentResponse{
+			Commitment: commitment.Digest,
+		}
+		if match.Status == domain.MatchStateFinished {
+			response.Secret = match.SecretSetBy(playerId)
+			response.Salt = commitment.Salt
+		}
+		commitments[domain.PlayerHandle(playerId)] = response
+	}
+	return commitments
+}
+
+func newGuessesResponse(guesses domain.MatchGuesses) domain.MatchGuesses {
+	byHandle := make(domain.MatchGuesses, len(guesses))
+	for playerId, items := range guesses {
+		byHandle[domain.PlayerHandle(playerId)] = items
+	}
+	return byHandle
+}
+
+func newPlayerCredentialsResponse(player domain.Player) contracts.PlayerCredentialsResponse {
+	return contracts.PlayerCredentialsResponse{
+		Id:       player.Id,
+		Username: player.Username,
+		Handle:   player.Handle(),
+	}
+}
+
+// notFound tells rooms that never existed apart from rooms that expired, so clients
+// can show the right message.
+func (s *MatchesService) notFound(ctx context.Context, roomId string) error {
+	expired, err := s.storage.MatchesRepository.IsExpired(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return ErrMatchExpired
+	}
+	return ErrMatchNotFound
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/store"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const CONCURRENT_REQUESTS = 16
+
+var redisGameConfig = GameConfig{DefaultMode: domain.MatchModeTurns, DefaultBestOf: 1, RaceFinishTimeout: time.Minute}
+
+func newRedisStorage(t *testing.T) contracts.Storage {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return store.NewRedisStorage(rdb, store.Config{
+		RoomTTL: store.RoomTTLPolicy{
+			Waiting:   time.Hour,
+			Playing:   time.Hour,
+			Finished:  time.Hour,
+			Tombstone: time.Hour,
+		},
+	})
+}
+
+// newRedisMatchesService runs the service on the Redis repository, the fake one
+// serializes every call and would hide races between reads and writes.
+func newRedisMatchesService(t *testing.T) contracts.IMatchesService {
+	t.Helper()
+	return NewMatchesService(newRedisStorage(t), redisGameConfig, domain.SystemClock{}, domain.CryptoRandom{})
+}
+
+// startRedisMatch plays a room up to the first guess; the host guesses 5678 and the
+// guest 1234.
+func startRedisMatch(t *testing.T, service contracts.IMatchesService, mode domain.MatchMode) (string, *contracts.StartMatchResponse, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	room, err := service.CreateRoom(ctx, contracts.CreateRoomCommand{Username: "alice", Mode: mode})
+	assertError(t, err, nil)
+	joined, err := service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: room.RoomId, Username: "bob"})
+	assertError(t, err, nil)
+
+	_, err = service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: room.Player.Id, Combination: 1234})
+	assertError(t, err, nil)
+	_, err = service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: joined.Player.Id, Combination: 5678})
+	assertError(t, err, nil)
+
+	started, err := service.StartGame(ctx, room.RoomId)
+	assertError(t, err, nil)
+
+	return room.RoomId, started, []string{room.Player.Id, joined.Player.Id}
+}
+
+// runConcurrently calls request from n goroutines released at the same time and
+// returns their errors.
+func runConcurrently(n int, request func(i int) error) []error {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, n)
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = request(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+func TestJoinRoomConcurrently(t *testing.T) {
+	service := newRedisMatchesService(t)
+	ctx := context.Background()
+
+	room, err := service.CreateRoom(ctx, contracts.CreateRoomCommand{Username: "alice"})
+	assertError(t, err, nil)
+
+	errs := runConcurrently(CONCURRENT_REQUESTS, func(i int) error {
+		_, err := service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: room.RoomId, Username: "guest"})
+		return err
+	})
+
+	joined := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			joined++
+		case !errors.Is(err, ErrCanNotAddAnotherPlayer):
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if joined != 1 {
+		t.Fatalf("expected exactly one player to join, %v did", joined)
+	}
+
+	match, err := service.GetMatch(ctx, room.RoomId)
+	assertError(t, err, nil)
+	if len(match.Players) != 2 || match.Status != domain.MatchStateFullRoom {
+		t.Fatalf("expected a full room with two players, got %v with %v", match.Status, len(match.Players))
+	}
+}
+
+func TestMakeGuessTurnsConcurrently(t *testing.T) {
+	service := newRedisMatchesService(t)
+	ctx := context.Background()
+	roomId, started, players := startRedisMatch(t, service, domain.MatchModeTurns)
+	starter := playerIdOf(t, started.IsTurnOf, players...)
+
+	errs := runConcurrently(CONCURRENT_REQUESTS, func(i int) error {
+		_, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: roomId, PlayerId: starter, Guess: 9876})
+		return err
+	})
+
+	played := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			played++
+		case !errors.Is(err, ErrNotYourTurn):
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if played != 1 {
+		t.Fatalf("expected exactly one guess to be played, %v were", played)
+	}
+
+	match, err := service.GetMatch(ctx, roomId)
+	assertError(t, err, nil)
+	if len(match.Guesses[started.IsTurnOf]) != 1 || match.IsTurnOf == started.IsTurnOf {
+		t.Fatalf("expected one guess and the turn to pass, got %v guesses with %q on turn", len(match.Guesses[started.IsTurnOf]), match.IsTurnOf)
+	}
+}
+
+func TestMakeGuessRaceConcurrently(t *testing.T) {
+	service := newRedisMatchesService(t)
+	ctx := context.Background()
+	roomId, _, players := startRedisMatch(t, service, domain.MatchModeRace)
+
+	// none of them solves either combination
+	guesses := []int{9876, 9875, 9874, 9873, 9872, 9871, 9870, 9865}
+
+	// both players guess at the same time, round after round
+	for _, guess := range guesses {
+		errs := runConcurrently(len(players), func(i int) error {
+			_, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: roomId, PlayerId: players[i], Guess: guess})
+			return err
+		})
+		for _, err := range errs {
+			assertError(t, err, nil)
+		}
+	}
+
+	match, err := service.GetMatch(ctx, roomId)
+	assertError(t, err, nil)
+	for _, playerId := range players {
+		items := match.Guesses[domain.PlayerHandle(playerId)]
+		if len(items) != len(guesses) {
+			t.Fatalf("expected %v guesses of %v, got %v", len(guesses), playerId, len(items))
+		}
+		for i, item := range items {
+			if item.Round != i+1 {
+				t.Fatalf("expected round %v, got %v", i+1, item.Round)
+			}
+		}
+	}
+}
+
+func TestSetCombinationConcurrently(t *testing.T) {
+	service := newRedisMatchesService(t)
+	ctx := context.Background()
+
+	room, err := service.CreateRoom(ctx, contracts.CreateRoomCommand{Username: "alice"})
+	assertError(t, err, nil)
+	joined, err := service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: room.RoomId, Username: "bob"})
+	assertError(t, err, nil)
+
+	// both players at once, every write succeeds so more callers would only exhaust
+	// the transaction retries
+	players := []string{room.Player.Id, joined.Player.Id}
+	errs := runConcurrently(len(players), func(i int) error {
+		_, err := service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: players[i], Combination: 1234})
+		return err
+	})
+	for _, err := range errs {
+		assertError(t, err, nil)
+	}
+
+	started, err := service.StartGame(ctx, room.RoomId)
+	assertError(t, err, nil)
+	if len(started.Commitments) != 2 {
+		t.Fatalf("expected a commitment from each player, got %v", started.Commitments)
+	}
+}
@@ -0,0 +1,988 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+const TEST_ROOM_ID = "abc1234"
+
+var (
+	errStorage = fmt.Errorf("storage unavailable")
+	testNow    = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func newTestMatchesService() (contracts.IMatchesService, *fakeMatchesRepository) {
+	return newSeededMatchesService(1, &domain.FixedClock{At: testNow})
+}
+
+// newSeededMatchesService plays every match the same way for the same seed and clock.
+func newSeededMatchesService(seed uint64, clock domain.Clock) (contracts.IMatchesService, *fakeMatchesRepository) {
+	repository := newFakeMatchesRepository()
+	service := NewMatchesService(contracts.Storage{MatchesRepository: repository}, GameConfig{
+		DefaultMode:       domain.MatchModeTurns,
+		DefaultBestOf:     1,
+		RematchTimeout:    time.Minute,
+		RaceFinishTimeout: time.Minute,
+	}, clock, domain.NewSeededRandom(seed))
+	return service, repository
+}
+
+// seedMatch stores a room where alice (p1) has to guess 5678 and bob (p2) 1234, with
+// p1 on turn.
+func seedMatch(repository *fakeMatchesRepository, status domain.MatchStatus, mode domain.MatchMode) *domain.Match {
+	match := &domain.Match{
+		RoomId: TEST_ROOM_ID,
+		Players: domain.MatchPlayers{
+			"p1": {Id: "p1", Username: "alice"},
+			"p2": {Id: "p2", Username: "bob"},
+		},
+		OpponentsCombinations: domain.MatchOpponentCombinations{"p1": "5678", "p2": "1234"},
+		Guesses:               domain.MatchGuesses{},
+		Status:                status,
+		IsTurnOf:              "p1",
+		Mode:                  mode,
+		Series:                domain.NewMatchSeries(3),
+	}
+	if status == domain.MatchStateFinished {
+		match.Winner = "p1"
+		match.Series.RecordRound("p1", 3, "p1")
+	}
+	repository.put(match)
+	return match
+}
+
+// playerIdOf returns which of the ids a response names by its handle.
+func playerIdOf(t *testing.T, handle string, playerIds ...string) string {
+	t.Helper()
+	for _, playerId := range playerIds {
+		if domain.PlayerHandle(playerId) == handle {
+			return playerId
+		}
+	}
+	t.Fatalf("handle %q names none of %v", handle, playerIds)
+	return ""
+}
+
+func assertError(t *testing.T, err error, want error) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("expected %v, got %v", want, err)
+	}
+}
+
+func TestCreateRoom(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    contracts.CreateRoomCommand
+		wantMode   domain.MatchMode
+		wantBestOf int
+	}{
+		{"defaults", contracts.CreateRoomCommand{Username: "alice"}, domain.MatchModeTurns, 1},
+		{"explicit", contracts.CreateRoomCommand{Username: "alice", Mode: domain.MatchModeRace, BestOf: 5}, domain.MatchModeRace, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+
+			res, err := service.CreateRoom(context.Background(), tt.command)
+			assertError(t, err, nil)
+
+			if res.Mode != tt.wantMode || res.BestOf != tt.wantBestOf {
+				t.Fatalf("expected %v best of %v, got %v best of %v", tt.wantMode, tt.wantBestOf, res.Mode, res.BestOf)
+			}
+			if res.Player.Username != "alice" || res.Player.Id == "" {
+				t.Fatalf("unexpected player %+v", res.Player)
+			}
+
+			stored := repository.get(res.RoomId)
+			if stored == nil || stored.Status != domain.MatchStateWaiting {
+				t.Fatalf("expected a waiting room to be stored, got %+v", stored)
+			}
+			if _, exists := stored.Players[res.Player.Id]; !exists {
+				t.Fatalf("the creator is not a player of the room")
+			}
+		})
+	}
+}
+
+func TestCreateRoomStorageError(t *testing.T) {
+	service, repository := newTestMatchesService()
+	repository.failWith("CreateMatch", errStorage)
+
+	_, err := service.CreateRoom(context.Background(), contracts.CreateRoomCommand{Username: "alice"})
+	assertError(t, err, errStorage)
+}
+
+func TestJoinRoom(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(repository *fakeMatchesRepository)
+		want  error
+	}{
+		{
+			name: "waiting room",
+			setup: func(repository *fakeMatchesRepository) {
+				match := seedMatch(repository, domain.MatchStateWaiting, domain.MatchModeTurns)
+				delete(match.Players, "p2")
+				repository.put(match)
+			},
+		},
+		{
+			name:  "unknown room",
+			setup: func(repository *fakeMatchesRepository) {},
+			want:  ErrMatchNotFound,
+		},
+		{
+			name: "expired room",
+			setup: func(repository *fakeMatchesRepository) {
+				repository.expire(TEST_ROOM_ID)
+			},
+			want: ErrMatchExpired,
+		},
+		{
+			name: "full room",
+			setup: func(repository *fakeMatchesRepository) {
+				seedMatch(repository, domain.MatchStateFullRoom, domain.MatchModeTurns)
+			},
+			want: ErrCanNotAddAnotherPlayer,
+		},
+		{
+			name: "storage error",
+			setup: func(repository *fakeMatchesRepository) {
+				seedMatch(repository, domain.MatchStateWaiting, domain.MatchModeTurns)
+				repository.failWith("UpdatePlayersAtomically", errStorage)
+			},
+			want: errStorage,
+		},
+		{
+			name: "expiry check error",
+			setup: func(repository *fakeMatchesRepository) {
+				repository.failWith("IsExpired", errStorage)
+			},
+			want: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			tt.setup(repository)
+
+			res, err := service.JoinRoom(context.Background(), contracts.JoinRoomCommand{RoomId: TEST_ROOM_ID, Username: "carol"})
+			assertError(t, err, tt.want)
+			if tt.want != nil {
+				return
+			}
+
+			stored := repository.get(TEST_ROOM_ID)
+			if stored.Status != domain.MatchStateFullRoom || len(stored.Players) != 2 {
+				t.Fatalf("expected a full room with two players, got %v with %v", stored.Status, len(stored.Players))
+			}
+			if stored.Players[res.Player.Id].Username != "carol" {
+				t.Fatalf("the new player was not stored: %+v", stored.Players)
+			}
+		})
+	}
+}
+
+func TestSetCombination(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      domain.MatchStatus
+		combination int
+		player      string
+		failure     string
+		want        error
+		wantDomain  error
+	}{
+		{name: "stored for the opponent", status: domain.MatchStateFullRoom, combination: 4321},
+		{name: "too long", status: domain.MatchStateFullRoom, combination: 12345, want: ErrInvalidCombination, wantDomain: domain.ErrInvalidCombination},
+		{name: "repeated digits", status: domain.MatchStateFullRoom, combination: 1123, want: ErrInvalidCombination, wantDomain: domain.ErrInvalidUniqueCombination},
+		{name: "unknown room", combination: 4321, want: ErrMatchNotFound},
+		{name: "waiting room", status: domain.MatchStateWaiting, combination: 4321, want: ErrMatchNotFullRoom},
+		{name: "already playing", status: domain.MatchStatePlaying, combination: 4321, want: ErrMatchNotFullRoom},
+		{name: "storage error", status: domain.MatchStateFullRoom, combination: 4321, failure: "UpdateCombinationsAtomically", want: errStorage},
+		{name: "not in the room", status: domain.MatchStateFullRoom, combination: 4321, player: "p3", want: ErrPlayerNotInRoom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			if tt.status != "" {
+				seedMatch(repository, tt.status, domain.MatchModeTurns)
+			}
+			if tt.failure != "" {
+				repository.failWith(tt.failure, errStorage)
+			}
+
+			playerId := "p1"
+			if tt.player != "" {
+				playerId = tt.player
+			}
+
+			_, err := service.SetCombination(context.Background(), contracts.SetCombinationCommand{
+				RoomId:      TEST_ROOM_ID,
+				PlayerId:    playerId,
+				Combination: tt.combination,
+			})
+			assertError(t, err, tt.want)
+			if tt.wantDomain != nil && !errors.Is(err, tt.wantDomain) {
+				t.Fatalf("expected the error to wrap %v, got %v", tt.wantDomain, err)
+			}
+			if tt.want != nil {
+				return
+			}
+
+			// p1 sets the combination bob has to guess
+			stored := repository.get(TEST_ROOM_ID)
+			if got := stored.OpponentsCombinations["p2"]; got != "4321" {
+				t.Fatalf("expected p2 to guess 4321, got %q", got)
+			}
+			commitment := stored.Commitments["p1"]
+			if !domain.VerifyCommitment(commitment.Digest, "4321", commitment.Salt) {
+				t.Fatalf("expected p1 to be committed to 4321, got %+v", commitment)
+			}
+		})
+	}
+}
+
+func TestStartGame(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       domain.MatchStatus
+		mode         domain.MatchMode
+		combinations domain.MatchOpponentCombinations
+		rounds       []domain.RoundResult
+		failure      string
+		want         error
+		wantTurn     string
+	}{
+		{name: "turns", status: domain.MatchStateFullRoom, mode: domain.MatchModeTurns},
+		{name: "race has no turns", status: domain.MatchStateFullRoom, mode: domain.MatchModeRace},
+		{
+			name:     "starter alternates",
+			status:   domain.MatchStateFullRoom,
+			mode:     domain.MatchModeTurns,
+			rounds:   []domain.RoundResult{{Round: 1, Winner: "p1", StartedBy: "p1"}},
+			wantTurn: "p2",
+		},
+		{name: "unknown room", want: ErrMatchNotFound},
+		{name: "waiting room", status: domain.MatchStateWaiting, mode: domain.MatchModeTurns, want: ErrMatchNotFullRoom},
+		{name: "already playing", status: domain.MatchStatePlaying, mode: domain.MatchModeTurns, want: ErrMatchNotFullRoom},
+		{
+			name:         "combinations pending",
+			status:       domain.MatchStateFullRoom,
+			mode:         domain.MatchModeTurns,
+			combinations: domain.MatchOpponentCombinations{"p1": "5678"},
+			want:         ErrExpectingCombinations,
+		},
+		{name: "storage error", status: domain.MatchStateFullRoom, mode: domain.MatchModeTurns, failure: "ChangeStatusAndTurn", want: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			if tt.status != "" {
+				match := seedMatch(repository, tt.status, tt.mode)
+				if tt.combinations != nil {
+					match.OpponentsCombinations = tt.combinations
+				}
+				match.Series.Rounds = tt.rounds
+				repository.put(match)
+			}
+			if tt.failure != "" {
+				repository.failWith(tt.failure, errStorage)
+			}
+
+			res, err := service.StartGame(context.Background(), TEST_ROOM_ID)
+			assertError(t, err, tt.want)
+			if tt.want != nil {
+				return
+			}
+
+			stored := repository.get(TEST_ROOM_ID)
+			if stored.Status != domain.MatchStatePlaying {
+				t.Fatalf("expected the match to be playing, got %v", stored.Status)
+			}
+			if res.IsTurnOf != domain.PlayerHandle(stored.IsTurnOf) {
+				t.Fatalf("response turn %q does not match the stored one %q", res.IsTurnOf, stored.IsTurnOf)
+			}
+
+			switch {
+			case tt.mode == domain.MatchModeRace:
+				if res.IsTurnOf != "" {
+					t.Fatalf("race matches have no turns, got %q", res.IsTurnOf)
+				}
+			case tt.wantTurn != "":
+				if stored.IsTurnOf != tt.wantTurn {
+					t.Fatalf("expected %q to start, got %q", tt.wantTurn, stored.IsTurnOf)
+				}
+			default:
+				if _, exists := stored.Players[stored.IsTurnOf]; !exists {
+					t.Fatalf("expected a player to start, got %q", stored.IsTurnOf)
+				}
+			}
+		})
+	}
+}
+
+func TestMakeGuessTurns(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   domain.MatchStatus
+		expired  bool
+		playerId string
+		guess    int
+		failure  string
+		want     error
+	}{
+		{name: "invalid guess", status: domain.MatchStatePlaying, playerId: "p1", guess: 123, want: ErrInvalidCombination},
+		{name: "repeated digits", status: domain.MatchStatePlaying, playerId: "p1", guess: 1124, want: ErrInvalidCombination},
+		{name: "unknown room", playerId: "p1", guess: 1234, want: ErrMatchNotFound},
+		{name: "expired room", expired: true, playerId: "p1", guess: 1234, want: ErrMatchExpired},
+		{name: "not a player", status: domain.MatchStatePlaying, playerId: "p3", guess: 1234, want: ErrMatchNotFound},
+		{name: "not started", status: domain.MatchStateFullRoom, playerId: "p1", guess: 1234, want: ErrMatchNotStarted},
+		{name: "finished", status: domain.MatchStateFinished, playerId: "p1", guess: 1234, want: ErrMatchNotStarted},
+		{name: "not your turn", status: domain.MatchStatePlaying, playerId: "p2", guess: 1234, want: ErrNotYourTurn},
+		{name: "storage error", status: domain.MatchStatePlaying, playerId: "p1", guess: 1234, failure: "UpdateGuessesAtomically", want: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			if tt.status != "" {
+				seedMatch(repository, tt.status, domain.MatchModeTurns)
+			}
+			if tt.expired {
+				repository.expire(TEST_ROOM_ID)
+			}
+			if tt.failure != "" {
+				repository.failWith(tt.failure, errStorage)
+			}
+
+			_, err := service.MakeGuess(context.Background(), contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: tt.playerId, Guess: tt.guess})
+			assertError(t, err, tt.want)
+		})
+	}
+}
+
+func TestMakeGuessTurnsPassesTheTurn(t *testing.T) {
+	service, repository := newTestMatchesService()
+	seedMatch(repository, domain.MatchStatePlaying, domain.MatchModeTurns)
+
+	res, err := service.MakeGuess(context.Background(), contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5687})
+	assertError(t, err, nil)
+
+	if res.IsWinner || res.Winner != "" {
+		t.Fatalf("a wrong guess can not win, got %+v", res)
+	}
+	if len(res.Guesses[domain.PlayerHandle("p1")]) != 1 || res.Guesses[domain.PlayerHandle("p1")][0].Round != 1 {
+		t.Fatalf("expected the first round to be recorded, got %+v", res.Guesses[domain.PlayerHandle("p1")])
+	}
+
+	stored := repository.get(TEST_ROOM_ID)
+	if stored.IsTurnOf != "p2" || stored.Status != domain.MatchStatePlaying {
+		t.Fatalf("expected p2 to be on turn of a playing match, got %q in %v", stored.IsTurnOf, stored.Status)
+	}
+
+	_, err = service.MakeGuess(context.Background(), contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678})
+	assertError(t, err, ErrNotYourTurn)
+}
+
+func TestMakeGuessTurnsWins(t *testing.T) {
+	service, repository := newTestMatchesService()
+	seedMatch(repository, domain.MatchStatePlaying, domain.MatchModeTurns)
+
+	res, err := service.MakeGuess(context.Background(), contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678})
+	assertError(t, err, nil)
+
+	if !res.IsWinner || res.Winner != domain.PlayerHandle("p1") {
+		t.Fatalf("expected p1 to win, got %+v", res)
+	}
+
+	stored := repository.get(TEST_ROOM_ID)
+	if stored.Status != domain.MatchStateFinished || stored.Winner != "p1" {
+		t.Fatalf("expected a finished match won by p1, got %v won by %q", stored.Status, stored.Winner)
+	}
+	if len(stored.Series.Rounds) != 1 || stored.Series.Rounds[0].Winner != "p1" || stored.Series.Rounds[0].Guesses != 1 {
+		t.Fatalf("expected the round to be recorded, got %+v", stored.Series.Rounds)
+	}
+}
+
+func TestMakeGuessRace(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   domain.MatchStatus
+		solved   bool
+		playerId string
+		failure  string
+		want     error
+	}{
+		{name: "not started", status: domain.MatchStateFullRoom, playerId: "p1", want: ErrMatchNotStarted},
+		{name: "not a player", status: domain.MatchStatePlaying, playerId: "p3", want: ErrMatchNotFound},
+		{name: "already solved", status: domain.MatchStatePlaying, solved: true, playerId: "p1", want: ErrAlreadySolved},
+		{name: "storage error", status: domain.MatchStatePlaying, playerId: "p1", failure: "UpdateGuessesAtomically", want: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			match := seedMatch(repository, tt.status, domain.MatchModeRace)
+			if tt.solved {
+				match.Guesses["p1"] = []domain.GuessesHistoryItem{{IsWinnerCombination: true, Round: 1, PlayedAt: testNow}}
+				repository.put(match)
+			}
+			if tt.failure != "" {
+				repository.failWith(tt.failure, errStorage)
+			}
+
+			_, err := service.MakeGuess(context.Background(), contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: tt.playerId, Guess: 1234})
+			assertError(t, err, tt.want)
+		})
+	}
+}
+
+func TestMakeGuessRaceWaitsForTheRound(t *testing.T) {
+	service, repository := newTestMatchesService()
+	seedMatch(repository, domain.MatchStatePlaying, domain.MatchModeRace)
+	ctx := context.Background()
+
+	res, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678})
+	assertError(t, err, nil)
+	if res.Winner != "" {
+		t.Fatalf("the opponent can still tie the round, got winner %q", res.Winner)
+	}
+
+	res, err = service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p2", Guess: 4321})
+	assertError(t, err, nil)
+	if res.Winner != domain.PlayerHandle("p1") || res.IsWinner {
+		t.Fatalf("expected p1 to win once p2 missed the round, got %+v", res)
+	}
+
+	if stored := repository.get(TEST_ROOM_ID); stored.Status != domain.MatchStateFinished {
+		t.Fatalf("expected the match to be finished, got %v", stored.Status)
+	}
+}
+
+func TestMakeGuessRaceClosesAfterTheFinishTimeout(t *testing.T) {
+	clock := &domain.FixedClock{At: testNow}
+	service, repository := newSeededMatchesService(1, clock)
+	seedMatch(repository, domain.MatchStatePlaying, domain.MatchModeRace)
+	ctx := context.Background()
+
+	_, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678})
+	assertError(t, err, nil)
+
+	clock.At = testNow.Add(time.Minute)
+	res, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p2", Guess: 1234})
+	assertError(t, err, nil)
+	if res.Winner != domain.PlayerHandle("p1") || res.IsWinner {
+		t.Fatalf("expected p1 to win once p2 ran out of time, got %+v", res)
+	}
+	if len(res.Guesses[domain.PlayerHandle("p2")]) != 0 {
+		t.Fatalf("a guess after the timeout can not be played, got %+v", res.Guesses[domain.PlayerHandle("p2")])
+	}
+}
+
+func TestGetMatchClosesALapsedRace(t *testing.T) {
+	clock := &domain.FixedClock{At: testNow}
+	service, repository := newSeededMatchesService(1, clock)
+	seedMatch(repository, domain.MatchStatePlaying, domain.MatchModeRace)
+	ctx := context.Background()
+
+	_, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678})
+	assertError(t, err, nil)
+
+	res, err := service.GetMatch(ctx, TEST_ROOM_ID)
+	assertError(t, err, nil)
+	if res.Status != domain.MatchStatePlaying {
+		t.Fatalf("p2 is still in time, got %v", res.Status)
+	}
+
+	clock.At = testNow.Add(time.Minute)
+	res, err = service.GetMatch(ctx, TEST_ROOM_ID)
+	assertError(t, err, nil)
+	if res.Status != domain.MatchStateFinished || res.Winner != domain.PlayerHandle("p1") {
+		t.Fatalf("expected p1 to win the lapsed race, got %v won by %q", res.Status, res.Winner)
+	}
+	if stored := repository.get(TEST_ROOM_ID); stored.Status != domain.MatchStateFinished || len(stored.Series.Rounds) != 1 {
+		t.Fatalf("expected the result to be stored, got %v with %d rounds", stored.Status, len(stored.Series.Rounds))
+	}
+}
+
+func TestOnFinishRunsOncePerMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		mode domain.MatchMode
+		play func(ctx context.Context, service contracts.IMatchesService, clock *domain.FixedClock) error
+	}{
+		{"turns won by a guess", domain.MatchModeTurns, func(ctx context.Context, service contracts.IMatchesService, clock *domain.FixedClock) error {
+			_, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678})
+			return err
+		}},
+		{"race closed by a late guess", domain.MatchModeRace, func(ctx context.Context, service contracts.IMatchesService, clock *domain.FixedClock) error {
+			if _, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678}); err != nil {
+				return err
+			}
+			clock.At = testNow.Add(time.Minute)
+			_, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p2", Guess: 9876})
+			return err
+		}},
+		{"race closed by reading it", domain.MatchModeRace, func(ctx context.Context, service contracts.IMatchesService, clock *domain.FixedClock) error {
+			if _, err := service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1", Guess: 5678}); err != nil {
+				return err
+			}
+			clock.At = testNow.Add(time.Minute)
+			for i := 0; i < 2; i++ {
+				if _, err := service.GetMatch(ctx, TEST_ROOM_ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finished := []*domain.Match{}
+			clock := &domain.FixedClock{At: testNow}
+			repository := newFakeMatchesRepository()
+			service := NewMatchesService(contracts.Storage{MatchesRepository: repository}, GameConfig{
+				RaceFinishTimeout: time.Minute,
+				OnFinish:          func(match *domain.Match) { finished = append(finished, match) },
+			}, clock, domain.NewSeededRandom(1))
+			seedMatch(repository, domain.MatchStatePlaying, tt.mode)
+
+			assertError(t, tt.play(context.Background(), service, clock), nil)
+
+			if len(finished) != 1 {
+				t.Fatalf("expected OnFinish to run once, it ran %v times", len(finished))
+			}
+			if finished[0].Status != domain.MatchStateFinished || finished[0].Winner != "p1" || len(finished[0].Guesses["p1"]) != 1 {
+				t.Fatalf("expected the match won by p1 with its guesses, got %+v", finished[0])
+			}
+		})
+	}
+}
+
+func TestRematch(t *testing.T) {
+	pending := &domain.RematchOffer{OfferedBy: "p1", ExpiresAt: testNow.Add(time.Minute)}
+	lapsed := &domain.RematchOffer{OfferedBy: "p1", ExpiresAt: testNow.Add(-time.Minute)}
+
+	tests := []struct {
+		name       string
+		status     domain.MatchStatus
+		offer      *domain.RematchOffer
+		seriesOver bool
+		bestOf     int
+		action     string
+		playerId   string
+		want       error
+		wantStatus domain.MatchStatus
+		wantOffer  bool
+	}{
+		{name: "offer", status: domain.MatchStateFinished, action: "offer", playerId: "p1", wantStatus: domain.MatchStateFinished, wantOffer: true},
+		{name: "offer twice", status: domain.MatchStateFinished, offer: pending, action: "offer", playerId: "p1", want: ErrRematchAlreadyOffered},
+		{name: "offer back restarts", status: domain.MatchStateFinished, offer: pending, action: "offer", playerId: "p2", wantStatus: domain.MatchStateFullRoom},
+		{name: "offer on a lapsed offer", status: domain.MatchStateFinished, offer: lapsed, action: "offer", playerId: "p2", wantStatus: domain.MatchStateFinished, wantOffer: true},
+		{name: "offer while playing", status: domain.MatchStatePlaying, action: "offer", playerId: "p1", want: ErrMatchNotFinished},
+		{name: "offer by a stranger", status: domain.MatchStateFinished, action: "offer", playerId: "p3", want: ErrMatchNotFound},
+		{name: "offer after the series", status: domain.MatchStateFinished, seriesOver: true, action: "offer", playerId: "p1", want: ErrSeriesIsOver},
+		{name: "offer after a single game", status: domain.MatchStateFinished, bestOf: 1, action: "offer", playerId: "p2", wantStatus: domain.MatchStateFinished, wantOffer: true},
+		{name: "offer in an unknown room", action: "offer", playerId: "p1", want: ErrMatchNotFound},
+		{name: "accept", status: domain.MatchStateFinished, offer: pending, action: "accept", playerId: "p2", wantStatus: domain.MatchStateFullRoom},
+		{name: "accept after a single game", status: domain.MatchStateFinished, bestOf: 1, offer: pending, action: "accept", playerId: "p2", wantStatus: domain.MatchStateFullRoom},
+		{name: "accept without offer", status: domain.MatchStateFinished, action: "accept", playerId: "p2", want: ErrNoRematchOffer},
+		{name: "accept a lapsed offer", status: domain.MatchStateFinished, offer: lapsed, action: "accept", playerId: "p2", want: ErrNoRematchOffer},
+		{name: "accept own offer", status: domain.MatchStateFinished, offer: pending, action: "accept", playerId: "p1", want: ErrOwnRematchOffer},
+		{name: "decline", status: domain.MatchStateFinished, offer: pending, action: "decline", playerId: "p2", wantStatus: domain.MatchStateFinished},
+		{name: "decline without offer", status: domain.MatchStateFinished, action: "decline", playerId: "p2", want: ErrNoRematchOffer},
+		{name: "decline own offer", status: domain.MatchStateFinished, offer: pending, action: "decline", playerId: "p1", want: ErrOwnRematchOffer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			if tt.status != "" {
+				match := seedMatch(repository, tt.status, domain.MatchModeTurns)
+				match.RematchOffer = tt.offer
+				if tt.seriesOver {
+					match.Series.Winner = "p1"
+				}
+				if tt.bestOf != 0 {
+					// the finished game decided the whole best-of-1 series
+					match.Series = domain.NewMatchSeries(tt.bestOf)
+					match.Series.RecordRound("p1", 3, "p1")
+				}
+				repository.put(match)
+			}
+
+			actions := map[string]func(ctx context.Context, command contracts.RematchCommand) (*contracts.SuccessResponse, error){
+				"offer":   service.OfferRematch,
+				"accept":  service.AcceptRematch,
+				"decline": service.DeclineRematch,
+			}
+
+			_, err := actions[tt.action](context.Background(), contracts.RematchCommand{RoomId: TEST_ROOM_ID, PlayerId: tt.playerId})
+			assertError(t, err, tt.want)
+			if tt.want != nil {
+				return
+			}
+
+			stored := repository.get(TEST_ROOM_ID)
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("expected %v, got %v", tt.wantStatus, stored.Status)
+			}
+			if stored.RematchOffer.IsPending(testNow) != tt.wantOffer {
+				t.Fatalf("expected a pending offer %v, got %+v", tt.wantOffer, stored.RematchOffer)
+			}
+			if tt.wantOffer && stored.RematchOffer.OfferedBy != tt.playerId {
+				t.Fatalf("expected the offer to come from %v, got %v", tt.playerId, stored.RematchOffer.OfferedBy)
+			}
+			if tt.wantStatus == domain.MatchStateFullRoom && (stored.Winner != "" || len(stored.Guesses) != 0 || len(stored.OpponentsCombinations) != 0) {
+				t.Fatalf("expected the room to be reset for the next round, got %+v", stored)
+			}
+		})
+	}
+}
+
+func TestRematchOfferLapses(t *testing.T) {
+	clock := &domain.FixedClock{At: testNow}
+	service, repository := newSeededMatchesService(1, clock)
+	seedMatch(repository, domain.MatchStateFinished, domain.MatchModeTurns)
+	ctx := context.Background()
+
+	_, err := service.OfferRematch(ctx, contracts.RematchCommand{RoomId: TEST_ROOM_ID, PlayerId: "p1"})
+	assertError(t, err, nil)
+
+	clock.Advance(time.Minute)
+
+	_, err = service.AcceptRematch(ctx, contracts.RematchCommand{RoomId: TEST_ROOM_ID, PlayerId: "p2"})
+	assertError(t, err, ErrNoRematchOffer)
+}
+
+func TestGetMatch(t *testing.T) {
+	service, repository := newTestMatchesService()
+	match := seedMatch(repository, domain.MatchStateFinished, domain.MatchModeTurns)
+	match.RematchOffer = &domain.RematchOffer{OfferedBy: "p1", ExpiresAt: testNow.Add(-time.Second)}
+	repository.put(match)
+
+	res, err := service.GetMatch(context.Background(), TEST_ROOM_ID)
+	assertError(t, err, nil)
+
+	if len(res.Players) != 2 || res.Players[0].Username != "alice" || res.Players[1].Username != "bob" {
+		t.Fatalf("expected players sorted by username, got %+v", res.Players)
+	}
+	if res.Series.Score[domain.PlayerHandle("p1")] != 1 || res.Series.Score[domain.PlayerHandle("p2")] != 0 {
+		t.Fatalf("expected every player in the score, got %+v", res.Series.Score)
+	}
+	if len(res.Series.Rounds) != 1 || res.Winner != domain.PlayerHandle("p1") {
+		t.Fatalf("unexpected series %+v won by %q", res.Series, res.Winner)
+	}
+	if res.Rematch != nil {
+		t.Fatalf("lapsed offers are not shown, got %+v", res.Rematch)
+	}
+}
+
+func TestGetMatchNotFound(t *testing.T) {
+	tests := []struct {
+		name    string
+		expired bool
+		want    error
+	}{
+		{"never existed", false, ErrMatchNotFound},
+		{"expired", true, ErrMatchExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			if tt.expired {
+				repository.expire(TEST_ROOM_ID)
+			}
+
+			_, err := service.GetMatch(context.Background(), TEST_ROOM_ID)
+			assertError(t, err, tt.want)
+		})
+	}
+}
+
+func TestSendChatMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     bool
+		playerId string
+		failure  string
+		want     error
+	}{
+		{name: "player", seed: true, playerId: "p2"},
+		{name: "not a player", seed: true, playerId: "p3", want: ErrPlayerNotInRoom},
+		{name: "unknown room", playerId: "p1", want: ErrMatchNotFound},
+		{name: "storage error", seed: true, playerId: "p1", failure: "AppendChatMessage", want: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository := newTestMatchesService()
+			if tt.seed {
+				seedMatch(repository, domain.MatchStateFullRoom, domain.MatchModeTurns)
+			}
+			if tt.failure != "" {
+				repository.failWith(tt.failure, errStorage)
+			}
+
+			res, err := service.SendChatMessage(context.Background(), contracts.ChatMessageCommand{RoomId: TEST_ROOM_ID, PlayerId: tt.playerId, Message: "good luck"})
+			assertError(t, err, tt.want)
+			if tt.want != nil {
+				return
+			}
+
+			if res.Username != "bob" || res.Message != "good luck" || res.Id == "" {
+				t.Fatalf("unexpected message %+v", res)
+			}
+		})
+	}
+}
+
+func TestGetChat(t *testing.T) {
+	service, repository := newTestMatchesService()
+	seedMatch(repository, domain.MatchStateFullRoom, domain.MatchModeTurns)
+	ctx := context.Background()
+
+	for _, playerId := range []string{"p1", "p2"} {
+		_, err := service.SendChatMessage(ctx, contracts.ChatMessageCommand{RoomId: TEST_ROOM_ID, PlayerId: playerId, Message: "hi from " + playerId})
+		assertError(t, err, nil)
+	}
+
+	res, err := service.GetChat(ctx, TEST_ROOM_ID)
+	assertError(t, err, nil)
+
+	if len(res.Messages) != 2 || res.Messages[0].Username != "alice" || res.Messages[1].Username != "bob" {
+		t.Fatalf("expected both messages in order, got %+v", res.Messages)
+	}
+
+	_, err = service.GetChat(ctx, "zzz9999")
+	assertError(t, err, ErrMatchNotFound)
+
+	repository.failWith("GetChatMessages", errStorage)
+	_, err = service.GetChat(ctx, TEST_ROOM_ID)
+	assertError(t, err, errStorage)
+}
+
+func TestWatchMatch(t *testing.T) {
+	service, repository := newTestMatchesService()
+	match := seedMatch(repository, domain.MatchStateWaiting, domain.MatchModeTurns)
+	delete(match.Players, "p2")
+	repository.put(match)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := service.WatchMatch(ctx, TEST_ROOM_ID)
+	assertError(t, err, nil)
+
+	next := func() (contracts.MatchEvent, bool) {
+		t.Helper()
+		select {
+		case event, ok := <-events:
+			return event, ok
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a match event")
+			return contracts.MatchEvent{}, false
+		}
+	}
+
+	if event, _ := next(); event.Type != contracts.MatchEventSnapshot || len(event.Match.Players) != 1 {
+		t.Fatalf("expected a snapshot with one player, got %+v", event)
+	}
+
+	_, err = service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: TEST_ROOM_ID, Username: "bob"})
+	assertError(t, err, nil)
+
+	if event, _ := next(); event.Type != contracts.MatchEventPlayerJoined || len(event.Match.Players) != 2 {
+		t.Fatalf("expected a player joined event, got %+v", event)
+	}
+
+	repository.expire(TEST_ROOM_ID)
+
+	if event, _ := next(); event.Type != contracts.MatchEventMatchExpired {
+		t.Fatalf("expected a match expired event, got %+v", event)
+	}
+	if _, ok := next(); ok {
+		t.Fatal("expected the stream to end once the match expired")
+	}
+}
+
+func TestWatchMatchNotFound(t *testing.T) {
+	service, _ := newTestMatchesService()
+
+	_, err := service.WatchMatch(context.Background(), TEST_ROOM_ID)
+	assertError(t, err, ErrMatchNotFound)
+}
+
+// playMatch runs a whole best of one match and returns its final state.
+func playMatch(t *testing.T, service contracts.IMatchesService) *contracts.MatchStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	room, err := service.CreateRoom(ctx, contracts.CreateRoomCommand{Username: "alice"})
+	assertError(t, err, nil)
+	joined, err := service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: room.RoomId, Username: "bob"})
+	assertError(t, err, nil)
+
+	_, err = service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: room.Player.Id, Combination: 1234})
+	assertError(t, err, nil)
+	_, err = service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: joined.Player.Id, Combination: 5678})
+	assertError(t, err, nil)
+
+	_, err = service.SendChatMessage(ctx, contracts.ChatMessageCommand{RoomId: room.RoomId, PlayerId: joined.Player.Id, Message: "good luck"})
+	assertError(t, err, nil)
+
+	started, err := service.StartGame(ctx, room.RoomId)
+	assertError(t, err, nil)
+
+	// whoever starts misses once and then guesses right
+	starter, other := playerIdOf(t, started.IsTurnOf, room.Player.Id, joined.Player.Id), joined.Player.Id
+	solutions := map[string]int{room.Player.Id: 5678, joined.Player.Id: 1234}
+	if starter == joined.Player.Id {
+		other = room.Player.Id
+	}
+	for _, guess := range []contracts.MakeGuessCommand{
+		{PlayerId: starter, Guess: 9012},
+		{PlayerId: other, Guess: 9012},
+		{PlayerId: starter, Guess: solutions[starter]},
+	} {
+		guess.RoomId = room.RoomId
+		_, err := service.MakeGuess(ctx, guess)
+		assertError(t, err, nil)
+	}
+
+	match, err := service.GetMatch(ctx, room.RoomId)
+	assertError(t, err, nil)
+	return match
+}
+
+func TestMatchReplay(t *testing.T) {
+	first, _ := newSeededMatchesService(7, &domain.FixedClock{At: testNow})
+	second, _ := newSeededMatchesService(7, &domain.FixedClock{At: testNow})
+
+	played, replayed := playMatch(t, first), playMatch(t, second)
+	if !reflect.DeepEqual(played, replayed) {
+		t.Fatalf("expected the same seed to replay the match, got %+v and %+v", played, replayed)
+	}
+
+	for _, guesses := range played.Guesses {
+		for _, guess := range guesses {
+			if !guess.PlayedAt.Equal(testNow) {
+				t.Fatalf("expected guesses to be stamped by the clock, got %v", guess.PlayedAt)
+			}
+		}
+	}
+
+	other, _ := newSeededMatchesService(8, &domain.FixedClock{At: testNow})
+	if playMatch(t, other).RoomId == played.RoomId {
+		t.Fatal("expected another seed to generate other ids")
+	}
+}
+
+func TestCommitmentsArePublishedAndRevealed(t *testing.T) {
+	service, _ := newTestMatchesService()
+	ctx := context.Background()
+
+	room, err := service.CreateRoom(ctx, contracts.CreateRoomCommand{Username: "alice"})
+	assertError(t, err, nil)
+	joined, err := service.JoinRoom(ctx, contracts.JoinRoomCommand{RoomId: room.RoomId, Username: "bob"})
+	assertError(t, err, nil)
+
+	secrets := map[string]int{room.Player.Id: 1234, joined.Player.Id: 5678}
+	for playerId, secret := range secrets {
+		_, err := service.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: room.RoomId, PlayerId: playerId, Combination: secret})
+		assertError(t, err, nil)
+	}
+
+	match, err := service.GetMatch(ctx, room.RoomId)
+	assertError(t, err, nil)
+	if match.Commitments != nil {
+		t.Fatalf("expected no commitments before the match starts, got %+v", match.Commitments)
+	}
+
+	started, err := service.StartGame(ctx, room.RoomId)
+	assertError(t, err, nil)
+	if len(started.Commitments) != 2 {
+		t.Fatalf("expected a commitment per player at the start, got %+v", started.Commitments)
+	}
+
+	match, err = service.GetMatch(ctx, room.RoomId)
+	assertError(t, err, nil)
+	for handle, commitment := range match.Commitments {
+		if commitment.Commitment != started.Commitments[handle] || commitment.Secret != "" || commitment.Salt != "" {
+			t.Fatalf("expected only the published commitment while playing, got %+v", commitment)
+		}
+	}
+
+	// the starter guesses the opponent's secret right away
+	starter := playerIdOf(t, started.IsTurnOf, room.Player.Id, joined.Player.Id)
+	opponent := room.Player.Id
+	if starter == room.Player.Id {
+		opponent = joined.Player.Id
+	}
+	_, err = service.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: room.RoomId, PlayerId: starter, Guess: secrets[opponent]})
+	assertError(t, err, nil)
+
+	match, err = service.GetMatch(ctx, room.RoomId)
+	assertError(t, err, nil)
+	if len(match.Commitments) != 2 {
+		t.Fatalf("expected both commitments once finished, got %+v", match.Commitments)
+	}
+	for handle, commitment := range match.Commitments {
+		playerId := playerIdOf(t, handle, room.Player.Id, joined.Player.Id)
+		if commitment.Secret != fmt.Sprint(secrets[playerId]) {
+			t.Fatalf("expected %v to reveal %v, got %q", playerId, secrets[playerId], commitment.Secret)
+		}
+		if commitment.Commitment != started.Commitments[handle] || !domain.VerifyCommitment(commitment.Commitment, commitment.Secret, commitment.Salt) {
+			t.Fatalf("revealed secret does not match the commitment %+v", commitment)
+		}
+	}
+}
+
+func TestVerifyCommitment(t *testing.T) {
+	digest := domain.CommitSecret("1234", "00ff")
+
+	tests := []struct {
+		name    string
+		command contracts.VerifyCommitmentCommand
+		want    bool
+	}{
+		{"valid", contracts.VerifyCommitmentCommand{Commitment: digest, Secret: "1234", Salt: "00ff"}, true},
+		{"upper case digest", contracts.VerifyCommitmentCommand{Commitment: strings.ToUpper(digest), Secret: "1234", Salt: "00ff"}, true},
+		{"other secret", contracts.VerifyCommitmentCommand{Commitment: digest, Secret: "1243", Salt: "00ff"}, false},
+		{"other salt", contracts.VerifyCommitmentCommand{Commitment: digest, Secret: "1234", Salt: "00fe"}, false},
+	}
+
+	service, _ := newTestMatchesService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := service.VerifyCommitment(context.Background(), tt.command)
+			assertError(t, err, nil)
+			if res.Valid != tt.want {
+				t.Fatalf("expected valid %v, got %v", tt.want, res.Valid)
+			}
+		})
+	}
+}
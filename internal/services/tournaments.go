@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+var (
+	ErrTournamentNotFound       = fmt.Errorf("tournament not found")
+	ErrRegistrationClosed       = fmt.Errorf("tournament registration is closed")
+	ErrNotEnoughPlayers         = fmt.Errorf("tournament needs at least 2 players to start")
+	ErrTournamentAlreadyStarted = fmt.Errorf("tournament has started already")
+)
+
+type TournamentsService struct {
+	storage contracts.Storage
+	config  GameConfig
+	random  domain.RandomSource
+}
+
+func NewTournamentsService(storage contracts.Storage, config GameConfig, random domain.RandomSource) contracts.ITournamentsService {
+	return &TournamentsService{
+		storage: storage,
+		config:  config,
+		random:  random,
+	}
+}
+
+func (s *TournamentsService) CreateTournament(ctx context.Context, command contracts.CreateTournamentCommand) (*contracts.CreateTournamentResponse, error) {
+	tournamentId, err := domain.GenerateTournamentId(s.random)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := command.Mode
+	if mode == "" {
+		mode = s.config.DefaultMode
+	}
+
+	bestOf := command.BestOf
+	if bestOf == 0 {
+		bestOf = s.config.DefaultBestOf
+	}
+
+	tournament := &domain.Tournament{
+		Id:     tournamentId,
+		Name:   command.Name,
+		Format: command.Format,
+		Rules: domain.TournamentRules{
+			Mode:   mode,
+			BestOf: bestOf,
+			Rounds: command.Rounds,
+		},
+		Status:  domain.TournamentStateRegistering,
+		Players: []domain.Player{},
+		Rounds:  [][]domain.TournamentPairing{},
+	}
+
+	if err := s.storage.TournamentsRepository.CreateTournament(ctx, tournament); err != nil {
+		return nil, err
+	}
+
+	return &contracts.CreateTournamentResponse{
+		TournamentId: tournament.Id,
+		Name:         tournament.Name,
+		Format:       tournament.Format,
+	}, nil
+}
+
+func (s *TournamentsService) RegisterPlayer(ctx context.Context, command contracts.RegisterTournamentPlayerCommand) (*contracts.RegisterTournamentPlayerResponse, error) {
+	playerId, err := domain.GeneratePlayerId(s.random)
+	if err != nil {
+		return nil, err
+	}
+
+	player := domain.Player{
+		Id:       playerId,
+		Username: command.Username,
+	}
+
+	err = s.storage.TournamentsRepository.UpdateTournament(ctx, command.TournamentId, func(tournament *domain.Tournament) error {
+		if tournament.Status != domain.TournamentStateRegistering {
+			return ErrRegistrationClosed
+		}
+		tournament.Players = append(tournament.Players, player)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+
+	return &contracts.RegisterTournamentPlayerResponse{
+		TournamentId: command.TournamentId,
+		Player:       newPlayerCredentialsResponse(player),
+	}, nil
+}
+
+func (s *TournamentsService) StartTournament(ctx context.Context, tournamentId string) (*contracts.TournamentBracketResponse, error) {
+	var result *domain.Tournament
+
+	err := s.storage.TournamentsRepository.UpdateTournament(ctx, tournamentId, func(tournament *domain.Tournament) error {
+		if tournament.Status != domain.TournamentStateRegistering {
+			return ErrTournamentAlreadyStarted
+		}
+
+		if len(tournament.Players) < 2 {
+			return ErrNotEnoughPlayers
+		}
+
+		tournament.Status = domain.TournamentStateRunning
+		for tournament.Advance() {
+		}
+
+		result = tournament
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+
+	if err := openTournamentRooms(ctx, s.storage, result); err != nil {
+		return nil, err
+	}
+
+	return newTournamentBracketResponse(result), nil
+}
+
+func (s *TournamentsService) GetBracket(ctx context.Context, tournamentId string) (*contracts.TournamentBracketResponse, error) {
+	tournament, err := s.sync(ctx, tournamentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTournamentBracketResponse(tournament), nil
+}
+
+func (s *TournamentsService) GetStandings(ctx context.Context, tournamentId string) (*contracts.TournamentStandingsResponse, error) {
+	tournament, err := s.sync(ctx, tournamentId)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := []contracts.TournamentStandingResponse{}
+	for _, standing := range tournament.Standings() {
+		player, _ := tournament.GetPlayer(standing.PlayerId)
+		standings = append(standings, contracts.TournamentStandingResponse{
+			Handle:     domain.PlayerHandle(standing.PlayerId),
+			Username:   player.Username,
+			Played:     standing.Played,
+			Wins:       standing.Wins,
+			Losses:     standing.Losses,
+			Byes:       standing.Byes,
+			Points:     standing.Points,
+			Eliminated: standing.Eliminated,
+		})
+	}
+
+	return &contracts.TournamentStandingsResponse{
+		TournamentId: tournament.Id,
+		Status:       tournament.Status,
+		Winner:       domain.PlayerHandle(tournament.Winner),
+		Standings:    standings,
+	}, nil
+}
+
+// sync collects the winners the rooms hold but the tournament missed and advances
+// it when the round is complete. Results are recorded as soon as a series is
+// decided, see recordTournamentResult, so this only catches up after a failure;
+// rooms that expired unplayed are opened again.
+func (s *TournamentsService) sync(ctx context.Context, tournamentId string) (*domain.Tournament, error) {
+	var result *domain.Tournament
+
+	err := s.storage.TournamentsRepository.UpdateTournament(ctx, tournamentId, func(tournament *domain.Tournament) error {
+		result = tournament
+
+		if tournament.Status != domain.TournamentStateRunning {
+			return nil
+		}
+
+		for _, pairing := range tournament.CurrentRound() {
+			if pairing.Winner != "" || pairing.RoomId == "" {
+				continue
+			}
+
+			match, err := s.storage.MatchesRepository.GetAllButGuesses(ctx, pairing.RoomId)
+			if err != nil {
+				if errors.Is(err, domain.ErrEmptyResult) {
+					continue
+				}
+				return err
+			}
+
+			tournament.RecordResult(pairing.RoomId, match.Series.Winner)
+		}
+
+		for tournament.Advance() {
+		}
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyResult) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+
+	if err := openTournamentRooms(ctx, s.storage, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// recordTournamentResult stores the winner of a tournament room once its series is
+// decided and opens the rooms of the next round. Rooms expire a few minutes after
+// they finish, waiting for the next read of the bracket could lose the result.
+func recordTournamentResult(ctx context.Context, storage contracts.Storage, match *domain.Match) error {
+	if match.TournamentId == "" || match.Series.Winner == "" {
+		return nil
+	}
+
+	var result *domain.Tournament
+
+	err := storage.TournamentsRepository.UpdateTournament(ctx, match.TournamentId, func(tournament *domain.Tournament) error {
+		result = nil
+		if tournament.Status != domain.TournamentStateRunning || !tournament.RecordResult(match.RoomId, match.Series.Winner) {
+			return nil
+		}
+
+		for tournament.Advance() {
+		}
+
+		result = tournament
+		return nil
+	})
+
+	if err != nil || result == nil {
+		return err
+	}
+
+	return openTournamentRooms(ctx, storage, result)
+}
+
+// openTournamentRooms creates the rooms of the open pairings of the current round.
+// It runs once the tournament is stored, room ids are derived from the pairing so
+// running it again never opens a second room for the same pairing.
+func openTournamentRooms(ctx context.Context, storage contracts.Storage, tournament *domain.Tournament) error {
+	if tournament.Status != domain.TournamentStateRunning {
+		return nil
+	}
+
+	for _, pairing := range tournament.CurrentRound() {
+		if pairing.IsBye() || pairing.Winner != "" {
+			continue
+		}
+
+		playerA, _ := tournament.GetPlayer(pairing.PlayerA)
+		playerB, _ := tournament.GetPlayer(pairing.PlayerB)
+
+		if err := storage.MatchesRepository.CreateFullRoom(ctx, contracts.CreateFullRoomCommand{
+			RoomId:       pairing.RoomId,
+			TournamentId: tournament.Id,
+			Players:      domain.MatchPlayers{playerA.Id: playerA, playerB.Id: playerB},
+			Mode:         tournament.Rules.Mode,
+			BestOf:       tournament.Rules.BestOf,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTournamentBracketResponse(tournament *domain.Tournament) *contracts.TournamentBracketResponse {
+	players := make([]contracts.PlayerResponse, 0, len(tournament.Players))
+	for _, player := range tournament.Players {
+		players = append(players, contracts.PlayerResponse{
+			Username: player.Username,
+			Handle:   player.Handle(),
+		})
+	}
+
+	rounds := make([][]contracts.TournamentPairingResponse, 0, len(tournament.Rounds))
+	for _, round := range tournament.Rounds {
+		pairings := make([]contracts.TournamentPairingResponse, 0, len(round))
+		for _, pairing := range round {
+			pairings = append(pairings, contracts.TournamentPairingResponse{
+				Round:   pairing.Round,
+				Bracket: pairing.Bracket,
+				PlayerA: domain.PlayerHandle(pairing.PlayerA),
+				PlayerB: domain.PlayerHandle(pairing.PlayerB),
+				RoomId:  pairing.RoomId,
+				Winner:  domain.PlayerHandle(pairing.Winner),
+			})
+		}
+		rounds = append(rounds, pairings)
+	}
+
+	return &contracts.TournamentBracketResponse{
+		TournamentId: tournament.Id,
+		Name:         tournament.Name,
+		Format:       tournament.Format,
+		Status:       tournament.Status,
+		Rules: contracts.TournamentRulesResponse{
+			Mode:   tournament.Rules.Mode,
+			BestOf: tournament.Rules.BestOf,
+			Rounds: tournament.Rules.Rounds,
+		},
+		Players: players,
+		Rounds:  rounds,
+		Winner:  domain.PlayerHandle(tournament.Winner),
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+)
+
+// startRedisTournament starts a single elimination tournament between alice and bob
+// and returns the room of their pairing.
+func startRedisTournament(t *testing.T) (contracts.Storage, contracts.ITournamentsService, contracts.IMatchesService, string, string, []contracts.PlayerCredentialsResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	storage := newRedisStorage(t)
+	tournaments := NewTournamentsService(storage, redisGameConfig, domain.CryptoRandom{})
+	matches := NewMatchesService(storage, redisGameConfig, domain.SystemClock{}, domain.CryptoRandom{})
+
+	created, err := tournaments.CreateTournament(ctx, contracts.CreateTournamentCommand{Name: "cup", Format: domain.TournamentFormatSingleElimination})
+	assertError(t, err, nil)
+
+	players := []contracts.PlayerCredentialsResponse{}
+	for _, username := range []string{"alice", "bob"} {
+		registered, err := tournaments.RegisterPlayer(ctx, contracts.RegisterTournamentPlayerCommand{TournamentId: created.TournamentId, Username: username})
+		assertError(t, err, nil)
+		players = append(players, registered.Player)
+	}
+
+	bracket, err := tournaments.StartTournament(ctx, created.TournamentId)
+	assertError(t, err, nil)
+	if len(bracket.Rounds) != 1 || len(bracket.Rounds[0]) != 1 || bracket.Rounds[0][0].RoomId == "" {
+		t.Fatalf("expected a single pairing with a room, got %+v", bracket.Rounds)
+	}
+
+	return storage, tournaments, matches, created.TournamentId, bracket.Rounds[0][0].RoomId, players
+}
+
+func TestStartTournamentOpensRooms(t *testing.T) {
+	storage, tournaments, matches, tournamentId, roomId, _ := startRedisTournament(t)
+	ctx := context.Background()
+
+	match, err := matches.GetMatch(ctx, roomId)
+	assertError(t, err, nil)
+	if match.Status != domain.MatchStateFullRoom || len(match.Players) != 2 {
+		t.Fatalf("expected a full room for the pairing, got %+v", match)
+	}
+
+	// a room lost before it was played is opened again with the same id
+	assertError(t, storage.AdminRepository.DeleteRoom(ctx, roomId), nil)
+
+	bracket, err := tournaments.GetBracket(ctx, tournamentId)
+	assertError(t, err, nil)
+	if bracket.Rounds[0][0].RoomId != roomId {
+		t.Fatalf("expected the pairing to keep room %q, got %q", roomId, bracket.Rounds[0][0].RoomId)
+	}
+
+	_, err = matches.GetMatch(ctx, roomId)
+	assertError(t, err, nil)
+}
+
+func TestTournamentRecordsResultOnFinish(t *testing.T) {
+	storage, tournaments, matches, tournamentId, roomId, players := startRedisTournament(t)
+	ctx := context.Background()
+
+	combinations := map[string]int{players[0].Id: 1234, players[1].Id: 5678}
+	for playerId, combination := range combinations {
+		_, err := matches.SetCombination(ctx, contracts.SetCombinationCommand{RoomId: roomId, PlayerId: playerId, Combination: combination})
+		assertError(t, err, nil)
+	}
+
+	started, err := matches.StartGame(ctx, roomId)
+	assertError(t, err, nil)
+
+	winner, opponent := players[0], players[1]
+	if started.IsTurnOf != winner.Handle {
+		winner, opponent = opponent, winner
+	}
+
+	guessed, err := matches.MakeGuess(ctx, contracts.MakeGuessCommand{RoomId: roomId, PlayerId: winner.Id, Guess: combinations[opponent.Id]})
+	assertError(t, err, nil)
+	if !guessed.IsWinner {
+		t.Fatalf("expected %v to win the room", winner.Username)
+	}
+
+	_, err = matches.OfferRematch(ctx, contracts.RematchCommand{RoomId: roomId, PlayerId: opponent.Id})
+	assertError(t, err, ErrSeriesIsOver)
+
+	// the result must not depend on the room still being there
+	assertError(t, storage.AdminRepository.DeleteRoom(ctx, roomId), nil)
+
+	bracket, err := tournaments.GetBracket(ctx, tournamentId)
+	assertError(t, err, nil)
+	if bracket.Status != domain.TournamentStateFinished || bracket.Winner != winner.Handle {
+		t.Fatalf("expected %v to win the tournament, got %v won by %q", winner.Handle, bracket.Status, bracket.Winner)
+	}
+}
+
+func TestForceFinishRecordsTournamentResult(t *testing.T) {
+	storage, _, _, tournamentId, roomId, players := startRedisTournament(t)
+	admin := NewAdminService(storage, redisGameConfig, domain.SystemClock{})
+	ctx := context.Background()
+
+	_, err := admin.ForceFinish(ctx, contracts.AdminActor{Name: "ops"}, contracts.ForceFinishCommand{RoomId: roomId, Winner: players[1].Id})
+	assertError(t, err, nil)
+
+	tournament, err := storage.TournamentsRepository.GetTournament(ctx, tournamentId)
+	assertError(t, err, nil)
+	if tournament.Status != domain.TournamentStateFinished || tournament.Winner != players[1].Id {
+		t.Fatalf("expected %v to win the tournament, got %v won by %q", players[1].Username, tournament.Status, tournament.Winner)
+	}
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ADMIN_AUDIT_KEY      = "admin:audit"
+	ADMIN_AUDIT_MAX_SIZE = 10000
+	ROOM_KEYS_PATTERN    = "room:{*}"
+)
+
+type AdminRepository struct {
+	rdb     redis.UniversalClient
+	matches *MatchesRepository
+}
+
+func newAdminRepository(rdb redis.UniversalClient, matches *MatchesRepository) *AdminRepository {
+	return &AdminRepository{
+		rdb:     rdb,
+		matches: matches,
+	}
+}
+
+// ScanRooms walks the room keys with SCAN, on every master when running against a
+// cluster, and stops once limit rooms with the requested status were found.
+func (r *AdminRepository) ScanRooms(ctx context.Context, status domain.MatchStatus, limit int) ([]contracts.RoomSummary, error) {
+	var mu sync.Mutex
+	rooms := []contracts.RoomSummary{}
+
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		iter := client.ScanType(ctx, 0, ROOM_KEYS_PATTERN, SWEEP_BATCH_SIZE, "hash").Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+
+			var statusCmd *redis.StringCmd
+			var ttlCmd *redis.DurationCmd
+			if _, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				statusCmd = pipe.HGet(ctx, key, "Status")
+				ttlCmd = pipe.PTTL(ctx, key)
+				return nil
+			}); err != nil && err != redis.Nil {
+				return err
+			}
+
+			roomStatus := domain.MatchStatus(statusCmd.Val())
+			if status != "" && roomStatus != status {
+				continue
+			}
+
+			mu.Lock()
+			if len(rooms) >= limit {
+				mu.Unlock()
+				return nil
+			}
+			rooms = append(rooms, contracts.RoomSummary{
+				RoomId: getRoomIdByKey(key),
+				Status: roomStatus,
+				TTL:    ttlCmd.Val(),
+			})
+			mu.Unlock()
+		}
+		return iter.Err()
+	}
+
+	if cluster, ok := r.rdb.(*redis.ClusterClient); ok {
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scan(ctx, client)
+		})
+		return rooms, err
+	}
+
+	return rooms, scan(ctx, r.rdb)
+}
+
+func (r *AdminRepository) GetRoom(ctx context.Context, roomId string) (*domain.Match, time.Duration, error) {
+	match, err := r.matches.GetAll(ctx, roomId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ttl, err := r.rdb.PTTL(ctx, getKeyById(roomId)).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return match, ttl, nil
+}
+
+// UpdateRoom rewrites the whole room inside a WATCH transaction so admin changes
+// never interleave with a move being played.
+func (r *AdminRepository) UpdateRoom(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	key := getKeyById(roomId)
+
+	var status domain.MatchStatus
+
+	txf := func(tx *redis.Tx) error {
+		results, err := tx.HMGet(ctx, key, matchFields...).Result()
+		if err != nil {
+			return err
+		}
+
+		match, err := decodeMatch(roomId, results)
+		if err != nil {
+			return err
+		}
+
+		if err := update(match); err != nil {
+			return err
+		}
+
+		status = match.Status
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.HSet(ctx, key, encodeMatch(match)).Err()
+		})
+		return err
+	}
+
+	for i := 0; i < MAX_TRANSACTION_RETRIES; i++ {
+		err := r.rdb.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return r.matches.trackRoom(ctx, roomId, status)
+	}
+
+	return redis.TxFailedErr
+}
+
+func (r *AdminRepository) DeleteRoom(ctx context.Context, roomId string) error {
+	deleted, err := r.rdb.Del(ctx, getKeyById(roomId)).Result()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrEmptyResult
+	}
+
+	if err := r.rdb.Del(ctx, getChatKeyById(roomId)).Err(); err != nil {
+		return err
+	}
+
+	if err := r.rdb.HDel(ctx, ROOMS_STATUS_KEY, roomId).Err(); err != nil {
+		return err
+	}
+
+	if err := r.rdb.ZRem(ctx, ROOMS_EXPIRY_KEY, roomId).Err(); err != nil {
+		return err
+	}
+
+	return r.matches.notifyRoom(ctx, roomId, "deleted")
+}
+
+func (r *AdminRepository) ExtendRoomTTL(ctx context.Context, roomId string, extra time.Duration) (time.Duration, error) {
+	key := getKeyById(roomId)
+
+	ttl, err := r.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// -2 means the key does not exist, -1 that it has no expiry
+	if ttl == -2 {
+		return 0, domain.ErrEmptyResult
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	ttl += extra
+
+	if err := r.rdb.PExpire(ctx, key, ttl).Err(); err != nil {
+		return 0, err
+	}
+
+	if err := r.rdb.PExpire(ctx, getChatKeyById(roomId), ttl).Err(); err != nil {
+		return 0, err
+	}
+
+	expiresAt := r.matches.clock.Now().Add(ttl).Unix()
+	if err := r.rdb.ZAdd(ctx, ROOMS_EXPIRY_KEY, redis.Z{Score: float64(expiresAt), Member: roomId}).Err(); err != nil {
+		return 0, err
+	}
+
+	return ttl, nil
+}
+
+func (r *AdminRepository) AppendAudit(ctx context.Context, entry contracts.AuditEntry) error {
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, ADMIN_AUDIT_KEY, string(entryJSON))
+		pipe.LTrim(ctx, ADMIN_AUDIT_KEY, 0, ADMIN_AUDIT_MAX_SIZE-1)
+		return nil
+	})
+	return err
+}
+
+func (r *AdminRepository) GetAuditLog(ctx context.Context, limit int) ([]contracts.AuditEntry, error) {
+	results, err := r.rdb.LRange(ctx, ADMIN_AUDIT_KEY, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]contracts.AuditEntry, 0, len(results))
+	for _, result := range results {
+		entry := contracts.AuditEntry{}
+		if err := json.Unmarshal([]byte(result), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func getRoomIdByKey(key string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, "room:{"), "}")
+}
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	DAILY_ATTEMPT_EXP = time.Hour * 48
+	DAILY_STATS_EXP   = time.Hour * 24 * 400
+)
+
+type DailyRepository struct {
+	rdb redis.UniversalClient
+}
+
+func newDailyRepository(rdb redis.UniversalClient) *DailyRepository {
+	return &DailyRepository{
+		rdb: rdb,
+	}
+}
+
+func (r *DailyRepository) GetPlayer(ctx context.Context, username string) (*domain.DailyPlayer, error) {
+	result, err := r.rdb.HGetAll(ctx, getDailyPlayerKey(username)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, domain.ErrEmptyResult
+	}
+
+	return decodeDailyPlayer(result), nil
+}
+
+// CreateAttempt stores the attempt and points its player at the attempt's day. The
+// player is handed to claim first, an empty one on the first start, and nothing is
+// stored when claim fails.
+func (r *DailyRepository) CreateAttempt(ctx context.Context, attempt *domain.DailyAttempt, claim func(player *domain.DailyPlayer) error) error {
+	key := getDailyAttemptKey(attempt.Day, attempt.Username)
+	playerKey := getDailyPlayerKey(attempt.Username)
+
+	plainAttempt, err := json.Marshal(attempt)
+	if err != nil {
+		return err
+	}
+
+	txf := func(tx *redis.Tx) error {
+		exists, err := tx.Exists(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+
+		if exists > 0 {
+			return domain.ErrAlreadyExists
+		}
+
+		fields, err := tx.HGetAll(ctx, playerKey).Result()
+		if err != nil {
+			return err
+		}
+
+		player := decodeDailyPlayer(fields)
+		if err := claim(player); err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, plainAttempt, DAILY_ATTEMPT_EXP)
+			pipe.HSet(ctx, playerKey, "Token", player.Token, "Day", attempt.Day)
+			pipe.Expire(ctx, playerKey, DAILY_STATS_EXP)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < MAX_TRANSACTION_RETRIES; i++ {
+		err := r.rdb.Watch(ctx, txf, key, playerKey)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+
+	return redis.TxFailedErr
+}
+
+func (r *DailyRepository) GetAttempt(ctx context.Context, day, username string) (*domain.DailyAttempt, error) {
+	key := getDailyAttemptKey(day, username)
+
+	result, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrEmptyResult
+		}
+		return nil, err
+	}
+
+	attempt := &domain.DailyAttempt{}
+	if err := json.Unmarshal([]byte(result), attempt); err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+func (r *DailyRepository) GetStats(ctx context.Context, username string) (*domain.DailyStats, error) {
+	key := getDailyStatsKey(username)
+
+	result, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return domain.NewDailyStats(), nil
+		}
+		return nil, err
+	}
+
+	stats := domain.NewDailyStats()
+	if err := json.Unmarshal([]byte(result), stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *DailyRepository) UpdateAttemptAndStats(ctx context.Context, day, username string, update func(attempt *domain.DailyAttempt, stats *domain.DailyStats) error) error {
+	attemptKey := getDailyAttemptKey(day, username)
+	statsKey := getDailyStatsKey(username)
+
+	txf := func(tx *redis.Tx) error {
+		results, err := tx.MGet(ctx, attemptKey, statsKey).Result()
+		if err != nil {
+			return err
+		}
+
+		plainAttempt, ok := results[0].(string)
+		if !ok {
+			return domain.ErrEmptyResult
+		}
+
+		attempt := &domain.DailyAttempt{}
+		if err := json.Unmarshal([]byte(plainAttempt), attempt); err != nil {
+			return err
+		}
+
+		stats := domain.NewDailyStats()
+		if plainStats, ok := results[1].(string); ok {
+			if err := json.Unmarshal([]byte(plainStats), stats); err != nil {
+				return err
+			}
+		}
+
+		if err := update(attempt, stats); err != nil {
+			return err
+		}
+
+		attemptJSON, _ := json.Marshal(attempt)
+		statsJSON, _ := json.Marshal(stats)
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, attemptKey, attemptJSON, DAILY_ATTEMPT_EXP)
+			pipe.Set(ctx, statsKey, statsJSON, DAILY_STATS_EXP)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < MAX_TRANSACTION_RETRIES; i++ {
+		err := r.rdb.Watch(ctx, txf, attemptKey, statsKey)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+
+	return redis.TxFailedErr
+}
+
+func getDailyAttemptKey(day, username string) string {
+	return fmt.Sprintf("daily:{%v}:%v", username, day)
+}
+
+func decodeDailyPlayer(fields map[string]string) *domain.DailyPlayer {
+	return &domain.DailyPlayer{
+		Token: fields["Token"],
+		Day:   fields["Day"],
+	}
+}
+
+func getDailyPlayerKey(username string) string {
+	return fmt.Sprintf("daily:{%v}:player", username)
+}
+
+func getDailyStatsKey(username string) string {
+	return fmt.Sprintf("daily:{%v}:stats", username)
+}
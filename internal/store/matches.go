@@ -3,8 +3,9 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
+	"strconv"
 
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
@@ -13,32 +14,43 @@ import (
 )
 
 const (
-	CREATE_OR_UPDATE_MATCH_EXP = time.Hour * 1
+	MAX_TRANSACTION_RETRIES = 10
+	ROOMS_STATUS_KEY        = "rooms:status"
+	ROOMS_EXPIRY_KEY        = "rooms:expiry"
+	ROOMS_EXPIRED_CHANNEL   = "rooms:expired"
+	SWEEP_BATCH_SIZE        = 500
+	CHAT_HISTORY_SIZE       = 50
 )
 
+var matchFields = []string{"Players", "OpponentsCombinations", "Guesses", "Status", "IsTurnOf", "Mode", "Winner", "Series", "StartedBy", "RematchOffer", "Commitments", "TournamentId"}
+
 type MatchesRepository struct {
-	rdb *redis.Client
+	rdb       redis.UniversalClient
+	ttlPolicy RoomTTLPolicy
+	clock     domain.Clock
 }
 
-func newMatchesRepository(rdb *redis.Client) *MatchesRepository {
+func newMatchesRepository(rdb redis.UniversalClient, ttlPolicy RoomTTLPolicy, clock domain.Clock) *MatchesRepository {
 	return &MatchesRepository{
-		rdb: rdb,
+		rdb:       rdb,
+		ttlPolicy: ttlPolicy,
+		clock:     clock,
 	}
 }
 
-func (r *MatchesRepository) CreateMatch(ctx context.Context, player domain.Player) (*domain.Match, error) {
-	roomId, err := domain.GenerateMatchId()
-	if err != nil {
-		return nil, err
-	}
+func (r *MatchesRepository) CreateMatch(ctx context.Context, command contracts.CreateMatchCommand) (*domain.Match, error) {
+	player := command.Player
 
 	match := &domain.Match{
-		RoomId:                roomId,
+		RoomId:                command.RoomId,
 		Players:               make(domain.MatchPlayers),
 		OpponentsCombinations: make(domain.MatchOpponentCombinations),
 		Guesses:               make(domain.MatchGuesses),
 		Status:                domain.MatchStateWaiting,
 		IsTurnOf:              player.Id,
+		Mode:                  command.Mode,
+		Series:                domain.NewMatchSeries(command.BestOf),
+		Commitments:           make(domain.MatchCommitments),
 	}
 
 	match.Players[player.Id] = player
@@ -46,6 +58,8 @@ func (r *MatchesRepository) CreateMatch(ctx context.Context, player domain.Playe
 	playersJSON, _ := json.Marshal(match.Players)
 	opponentsJSON, _ := json.Marshal(match.OpponentsCombinations)
 	guessesJSON, _ := json.Marshal(match.Guesses)
+	seriesJSON, _ := json.Marshal(match.Series)
+	commitmentsJSON, _ := json.Marshal(match.Commitments)
 
 	payload := map[string]interface{}{
 		"Players":               string(playersJSON),
@@ -53,21 +67,69 @@ func (r *MatchesRepository) CreateMatch(ctx context.Context, player domain.Playe
 		"Guesses":               string(guessesJSON),
 		"Status":                string(match.Status),
 		"IsTurnOf":              match.IsTurnOf,
+		"Mode":                  string(match.Mode),
+		"Winner":                match.Winner,
+		"Series":                string(seriesJSON),
+		"Commitments":           string(commitmentsJSON),
 	}
 
-	key := getKeyById(roomId)
+	key := getKeyById(match.RoomId)
 
 	if err := r.rdb.HSet(ctx, key, payload).Err(); err != nil {
 		return nil, err
 	}
 
-	if err := r.rdb.Expire(ctx, key, CREATE_OR_UPDATE_MATCH_EXP).Err(); err != nil {
+	if err := r.trackRoom(ctx, match.RoomId, match.Status); err != nil {
 		return nil, err
 	}
 
 	return match, nil
 }
 
+// CreateFullRoom opens a room with both players seated. It does nothing when the
+// room exists already, so it can be retried with the same room id.
+func (r *MatchesRepository) CreateFullRoom(ctx context.Context, command contracts.CreateFullRoomCommand) error {
+	key := getKeyById(command.RoomId)
+
+	payload := encodeMatch(&domain.Match{
+		Players:               command.Players,
+		OpponentsCombinations: make(domain.MatchOpponentCombinations),
+		Guesses:               make(domain.MatchGuesses),
+		Status:                domain.MatchStateFullRoom,
+		Mode:                  command.Mode,
+		Series:                domain.NewMatchSeries(command.BestOf),
+		Commitments:           make(domain.MatchCommitments),
+		TournamentId:          command.TournamentId,
+	})
+
+	created := false
+	txf := func(tx *redis.Tx) error {
+		exists, err := tx.Exists(ctx, key).Result()
+		if err != nil || exists > 0 {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.HSet(ctx, key, payload).Err()
+		})
+		created = err == nil
+		return err
+	}
+
+	for i := 0; i < MAX_TRANSACTION_RETRIES; i++ {
+		err := r.rdb.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil || !created {
+			return err
+		}
+		return r.trackRoom(ctx, command.RoomId, domain.MatchStateFullRoom)
+	}
+
+	return redis.TxFailedErr
+}
+
 func (r *MatchesRepository) SetPlayersAndFillRoom(ctx context.Context, command contracts.SetPlayersCommand) error {
 	key := getKeyById(command.RoomId)
 
@@ -78,7 +140,11 @@ func (r *MatchesRepository) SetPlayersAndFillRoom(ctx context.Context, command c
 		"Status":  string(domain.MatchStateFullRoom),
 	}
 
-	return r.rdb.HSet(ctx, key, payload).Err()
+	if err := r.rdb.HSet(ctx, key, payload).Err(); err != nil {
+		return err
+	}
+
+	return r.trackRoom(ctx, command.RoomId, domain.MatchStateFullRoom)
 }
 
 func (r *MatchesRepository) GetRoomPlayers(ctx context.Context, roomId string) (domain.MatchPlayers, error) {
@@ -117,109 +183,499 @@ func (r *MatchesRepository) GetMatchStatusById(ctx context.Context, roomId strin
 	return matchStatus, nil
 }
 
-func (r *MatchesRepository) GetPlayersAndCombinations(ctx context.Context, roomId string) (*domain.Match, error) {
+func (r *MatchesRepository) GetAllButGuesses(ctx context.Context, roomId string) (*domain.Match, error) {
 	key := getKeyById(roomId)
-	results, err := r.rdb.HMGet(ctx, key, "Players", "OpponentsCombinations").Result()
+	results, err := r.rdb.HMGet(ctx, key, "Players", "OpponentsCombinations", "Status", "IsTurnOf", "Mode", "Series", "StartedBy", "RematchOffer", "Commitments", "TournamentId").Result()
 
 	if err != nil {
 		return nil, err
 	}
 
-	if results[0] == nil || results[1] == nil {
+	if utils.IsSliceWithNilValues(results[:4]) {
 		return nil, domain.ErrEmptyResult
 	}
 
 	var players domain.MatchPlayers
 
-	err = json.Unmarshal([]byte(results[0].(string)), &players)
-	if err != nil {
+	if err := json.Unmarshal([]byte(results[0].(string)), &players); err != nil {
 		return nil, err
 	}
 
 	var combinations domain.MatchOpponentCombinations
-
-	err = json.Unmarshal([]byte(results[1].(string)), &combinations)
-	if err != nil {
+	if err := json.Unmarshal([]byte(results[1].(string)), &combinations); err != nil {
 		return nil, err
 	}
 
+	status := domain.MatchStatus(results[2].(string))
+
+	isTurnOf := results[3].(string)
+
 	match := &domain.Match{
 		Players:               players,
 		OpponentsCombinations: combinations,
+		Status:                status,
+		IsTurnOf:              isTurnOf,
+		Mode:                  parseMode(results[4]),
+	}
+
+	if match.Series, err = parseSeries(results[5]); err != nil {
+		return nil, err
+	}
+
+	match.StartedBy, _ = results[6].(string)
+
+	if match.RematchOffer, err = parseRematchOffer(results[7]); err != nil {
+		return nil, err
 	}
 
+	if match.Commitments, err = parseCommitments(results[8]); err != nil {
+		return nil, err
+	}
+
+	match.TournamentId, _ = results[9].(string)
+
 	return match, nil
 }
 
-func (r *MatchesRepository) SetPlayerCombination(ctx context.Context, command contracts.SetOpponentCombinationsCommand) error {
-	key := getKeyById(command.RoomId)
+func (r *MatchesRepository) ChangeStatusAndTurn(ctx context.Context, roomId string, status domain.MatchStatus, isTurnOf string) error {
+	key := getKeyById(roomId)
+
+	payload := map[string]interface{}{
+		"Status":   string(status),
+		"IsTurnOf": isTurnOf,
+	}
+
+	if status == domain.MatchStatePlaying {
+		payload["StartedBy"] = isTurnOf
+	}
+
+	if err := r.rdb.HSet(ctx, key, payload).Err(); err != nil {
+		return err
+	}
+
+	return r.trackRoom(ctx, roomId, status)
+}
+
+func (r *MatchesRepository) GetAll(ctx context.Context, roomId string) (*domain.Match, error) {
+	key := getKeyById(roomId)
+	results, err := r.rdb.HMGet(ctx, key, matchFields...).Result()
+
+	if err != nil {
+		return nil, err
+	}
 
-	opponentsJSON, _ := json.Marshal(command.Combinations)
+	return decodeMatch(roomId, results)
+}
+
+func (r *MatchesRepository) UpdateGuessesAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	return r.updateAtomically(ctx, roomId, update, func(match *domain.Match) map[string]interface{} {
+		guessesJSON, _ := json.Marshal(match.Guesses)
+		seriesJSON, _ := json.Marshal(match.Series)
+
+		return map[string]interface{}{
+			"Guesses":  string(guessesJSON),
+			"Status":   string(match.Status),
+			"IsTurnOf": match.IsTurnOf,
+			"Winner":   match.Winner,
+			"Series":   string(seriesJSON),
+		}
+	})
+}
+
+// UpdatePlayersAtomically saves the players and status left by update. It is retried
+// when the room changes in between, so two players can not take the same seat.
+func (r *MatchesRepository) UpdatePlayersAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	return r.updateAtomically(ctx, roomId, update, func(match *domain.Match) map[string]interface{} {
+		playersJSON, _ := json.Marshal(match.Players)
+
+		return map[string]interface{}{
+			"Players": string(playersJSON),
+			"Status":  string(match.Status),
+		}
+	})
+}
+
+// UpdateCombinationsAtomically saves the combinations and commitments left by update.
+// It is retried when the room changes in between, so two players setting their
+// combinations at once both get them stored.
+func (r *MatchesRepository) UpdateCombinationsAtomically(ctx context.Context, roomId string, update func(match *domain.Match) error) error {
+	return r.updateAtomically(ctx, roomId, update, func(match *domain.Match) map[string]interface{} {
+		opponentsJSON, _ := json.Marshal(match.OpponentsCombinations)
+		commitmentsJSON, _ := json.Marshal(match.Commitments)
+
+		return map[string]interface{}{
+			"OpponentsCombinations": string(opponentsJSON),
+			"Commitments":           string(commitmentsJSON),
+		}
+	})
+}
+
+// updateAtomically runs update on the latest state of the room inside a WATCH
+// transaction and writes the fields returned by payload, retrying on conflicts.
+func (r *MatchesRepository) updateAtomically(
+	ctx context.Context,
+	roomId string,
+	update func(match *domain.Match) error,
+	payload func(match *domain.Match) map[string]interface{},
+) error {
+	key := getKeyById(roomId)
+
+	var status domain.MatchStatus
+
+	txf := func(tx *redis.Tx) error {
+		results, err := tx.HMGet(ctx, key, matchFields...).Result()
+		if err != nil {
+			return err
+		}
+
+		match, err := decodeMatch(roomId, results)
+		if err != nil {
+			return err
+		}
+
+		if err := update(match); err != nil {
+			return err
+		}
+
+		status = match.Status
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.HSet(ctx, key, payload(match)).Err()
+		})
+		return err
+	}
+
+	for i := 0; i < MAX_TRANSACTION_RETRIES; i++ {
+		err := r.rdb.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return r.trackRoom(ctx, roomId, status)
+	}
+
+	return redis.TxFailedErr
+}
+
+func (r *MatchesRepository) Exists(ctx context.Context, roomId string) error {
+	key := getKeyById(roomId)
+	return r.rdb.Exists(ctx, key).Err()
+}
+
+func (r *MatchesRepository) Restart(ctx context.Context, roomId string) error {
+	key := getKeyById(roomId)
+
+	match := &domain.Match{
+		OpponentsCombinations: make(domain.MatchOpponentCombinations),
+		Guesses:               make(domain.MatchGuesses),
+		Status:                domain.MatchStateFullRoom,
+	}
+
+	opponentsJSON, _ := json.Marshal(match.OpponentsCombinations)
+	guessesJSON, _ := json.Marshal(match.Guesses)
 
 	payload := map[string]interface{}{
 		"OpponentsCombinations": string(opponentsJSON),
+		"Guesses":               string(guessesJSON),
+		"Status":                string(match.Status),
+		"Winner":                "",
+		"RematchOffer":          "",
+		"Commitments":           "",
+	}
+
+	if err := r.rdb.HSet(ctx, key, payload).Err(); err != nil {
+		return err
 	}
 
-	return r.rdb.HSet(ctx, key, payload).Err()
+	return r.trackRoom(ctx, roomId, match.Status)
 }
 
-func (r *MatchesRepository) GetAllButGuesses(ctx context.Context, roomId string) (*domain.Match, error) {
+func (r *MatchesRepository) SetRematchOffer(ctx context.Context, roomId string, offer *domain.RematchOffer) error {
 	key := getKeyById(roomId)
-	results, err := r.rdb.HMGet(ctx, key, "Players", "OpponentsCombinations", "Status", "IsTurnOf").Result()
 
+	plainOffer := ""
+	if offer != nil {
+		offerJSON, _ := json.Marshal(offer)
+		plainOffer = string(offerJSON)
+	}
+
+	if err := r.rdb.HSet(ctx, key, "RematchOffer", plainOffer).Err(); err != nil {
+		return err
+	}
+
+	return r.trackRoom(ctx, roomId, domain.MatchStateFinished)
+}
+
+// IsExpired reports whether the room existed and expired, either already swept and
+// remembered by its tombstone or gone but still waiting for the next sweep.
+func (r *MatchesRepository) IsExpired(ctx context.Context, roomId string) (bool, error) {
+	exists, err := r.rdb.Exists(ctx, getTombstoneKeyById(roomId)).Result()
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+	if exists > 0 {
+		return true, nil
 	}
 
-	if utils.IsSliceWithNilValues(results) {
-		return nil, domain.ErrEmptyResult
+	if err := r.rdb.ZScore(ctx, ROOMS_EXPIRY_KEY, roomId).Err(); err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+		return false, err
 	}
 
-	var players domain.MatchPlayers
+	exists, err = r.rdb.Exists(ctx, getKeyById(roomId)).Result()
+	if err != nil {
+		return false, err
+	}
+	return exists == 0, nil
+}
 
-	if err := json.Unmarshal([]byte(results[0].(string)), &players); err != nil {
+// SweepExpiredRooms returns the rooms whose key expired since the last sweep along
+// with the last status they reached. Rooms are removed from the index with ZREM, so
+// when several instances sweep at once each room is reported only once.
+func (r *MatchesRepository) SweepExpiredRooms(ctx context.Context) ([]domain.ExpiredRoom, error) {
+	now := r.clock.Now()
+
+	roomIds, err := r.rdb.ZRangeByScore(ctx, ROOMS_EXPIRY_KEY, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: SWEEP_BATCH_SIZE,
+	}).Result()
+	if err != nil {
 		return nil, err
 	}
 
-	var combinations domain.MatchOpponentCombinations
-	if err := json.Unmarshal([]byte(results[1].(string)), &combinations); err != nil {
+	expired := []domain.ExpiredRoom{}
+
+	for _, roomId := range roomIds {
+		ttl, err := r.rdb.PTTL(ctx, getKeyById(roomId)).Result()
+		if err != nil {
+			return expired, err
+		}
+
+		if ttl > 0 || ttl == -1 {
+			if ttl == -1 {
+				status, err := r.rdb.HGet(ctx, ROOMS_STATUS_KEY, roomId).Result()
+				if err != nil && err != redis.Nil {
+					return expired, err
+				}
+				ttl = r.ttlPolicy.For(domain.MatchStatus(status))
+				if err := r.rdb.Expire(ctx, getKeyById(roomId), ttl).Err(); err != nil {
+					return expired, err
+				}
+			}
+			if err := r.rdb.ZAdd(ctx, ROOMS_EXPIRY_KEY, redis.Z{Score: float64(now.Add(ttl).Unix()), Member: roomId}).Err(); err != nil {
+				return expired, err
+			}
+			continue
+		}
+
+		removed, err := r.rdb.ZRem(ctx, ROOMS_EXPIRY_KEY, roomId).Result()
+		if err != nil {
+			return expired, err
+		}
+
+		if removed == 0 {
+			continue
+		}
+
+		status, err := r.rdb.HGet(ctx, ROOMS_STATUS_KEY, roomId).Result()
+		if err != nil && err != redis.Nil {
+			return expired, err
+		}
+
+		if err := r.rdb.HDel(ctx, ROOMS_STATUS_KEY, roomId).Err(); err != nil {
+			return expired, err
+		}
+
+		room := domain.ExpiredRoom{
+			RoomId:     roomId,
+			LastStatus: domain.MatchStatus(status),
+			ExpiredAt:  now,
+		}
+
+		if err := r.rdb.Set(ctx, getTombstoneKeyById(roomId), status, r.ttlPolicy.Tombstone).Err(); err != nil {
+			return expired, err
+		}
+
+		eventJSON, _ := json.Marshal(contracts.RoomExpiredEvent{
+			RoomId:     room.RoomId,
+			LastStatus: room.LastStatus,
+			ExpiredAt:  room.ExpiredAt,
+		})
+		if err := r.rdb.Publish(ctx, ROOMS_EXPIRED_CHANNEL, string(eventJSON)).Err(); err != nil {
+			return expired, err
+		}
+
+		if err := r.notifyRoom(ctx, roomId, "expired"); err != nil {
+			return expired, err
+		}
+
+		expired = append(expired, room)
+	}
+
+	return expired, nil
+}
+
+func (r *MatchesRepository) CountRoomsByStatus(ctx context.Context) (map[domain.MatchStatus]int, error) {
+	statuses, err := r.rdb.HVals(ctx, ROOMS_STATUS_KEY).Result()
+	if err != nil {
 		return nil, err
 	}
 
-	status := domain.MatchStatus(results[2].(string))
+	counts := make(map[domain.MatchStatus]int)
+	for _, status := range statuses {
+		counts[domain.MatchStatus(status)]++
+	}
 
-	isTurnOf := results[3].(string)
+	return counts, nil
+}
 
-	match := &domain.Match{
-		Players:               players,
-		OpponentsCombinations: combinations,
-		Status:                status,
-		IsTurnOf:              isTurnOf,
+// trackRoom runs after every mutation: it slides the room TTL according to the
+// status it reached and keeps the status and expiry indexes in sync.
+func (r *MatchesRepository) trackRoom(ctx context.Context, roomId string, status domain.MatchStatus) error {
+	ttl := r.ttlPolicy.For(status)
+
+	if err := r.rdb.Expire(ctx, getKeyById(roomId), ttl).Err(); err != nil {
+		return err
 	}
 
-	return match, nil
+	if err := r.rdb.Expire(ctx, getChatKeyById(roomId), ttl).Err(); err != nil {
+		return err
+	}
+
+	if err := r.rdb.HSet(ctx, ROOMS_STATUS_KEY, roomId, string(status)).Err(); err != nil {
+		return err
+	}
+
+	expiresAt := r.clock.Now().Add(ttl).Unix()
+	if err := r.rdb.ZAdd(ctx, ROOMS_EXPIRY_KEY, redis.Z{Score: float64(expiresAt), Member: roomId}).Err(); err != nil {
+		return err
+	}
+
+	return r.notifyRoom(ctx, roomId, string(status))
 }
 
-func (r *MatchesRepository) ChangeStatusAndTurn(ctx context.Context, roomId string, status domain.MatchStatus, isTurnOf string) error {
-	key := getKeyById(roomId)
+// notifyRoom wakes up the watchers of a room. The message is informative only,
+// watchers always read the latest state.
+func (r *MatchesRepository) notifyRoom(ctx context.Context, roomId string, message string) error {
+	return r.rdb.Publish(ctx, getEventsChannelById(roomId), message).Err()
+}
 
-	payload := map[string]interface{}{
-		"Status":   string(status),
-		"IsTurnOf": isTurnOf,
+// WatchRoom signals every change made to the room until ctx is done. Signals are
+// coalesced, so a slow reader gets one signal for several changes. The subscription is
+// confirmed before returning, so changes made afterwards are never missed.
+func (r *MatchesRepository) WatchRoom(ctx context.Context, roomId string) (<-chan struct{}, error) {
+	pubsub := r.rdb.Subscribe(ctx, getEventsChannelById(roomId))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
 	}
 
-	return r.rdb.HSet(ctx, key, payload).Err()
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return changes, nil
 }
 
-func (r *MatchesRepository) GetAll(ctx context.Context, roomId string) (*domain.Match, error) {
-	key := getKeyById(roomId)
-	results, err := r.rdb.HMGet(ctx, key, "Players", "OpponentsCombinations", "Guesses", "Status", "IsTurnOf").Result()
+// AppendChatMessage keeps the last CHAT_HISTORY_SIZE messages of a room. The list
+// takes the TTL of the room so both expire together.
+func (r *MatchesRepository) AppendChatMessage(ctx context.Context, roomId string, message domain.ChatMessage) error {
+	ttl, err := r.rdb.PTTL(ctx, getKeyById(roomId)).Result()
+	if err != nil {
+		return err
+	}
+	// -2 means the room does not exist
+	if ttl == -2 {
+		return domain.ErrEmptyResult
+	}
+
+	messageJSON, _ := json.Marshal(message)
+	key := getChatKeyById(roomId)
+
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, string(messageJSON))
+		pipe.LTrim(ctx, key, -CHAT_HISTORY_SIZE, -1)
+		if ttl > 0 {
+			pipe.PExpire(ctx, key, ttl)
+		}
+		return nil
+	})
+	return err
+}
 
+func (r *MatchesRepository) GetChatMessages(ctx context.Context, roomId string) ([]domain.ChatMessage, error) {
+	results, err := r.rdb.LRange(ctx, getChatKeyById(roomId), 0, -1).Result()
 	if err != nil {
 		return nil, err
 	}
 
-	if utils.IsSliceWithNilValues(results) {
+	messages := make([]domain.ChatMessage, 0, len(results))
+	for _, result := range results {
+		message := domain.ChatMessage{}
+		if err := json.Unmarshal([]byte(result), &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func encodeMatch(match *domain.Match) map[string]interface{} {
+	playersJSON, _ := json.Marshal(match.Players)
+	opponentsJSON, _ := json.Marshal(match.OpponentsCombinations)
+	guessesJSON, _ := json.Marshal(match.Guesses)
+	seriesJSON, _ := json.Marshal(match.Series)
+
+	plainOffer := ""
+	if match.RematchOffer != nil {
+		offerJSON, _ := json.Marshal(match.RematchOffer)
+		plainOffer = string(offerJSON)
+	}
+
+	commitmentsJSON, _ := json.Marshal(match.Commitments)
+
+	return map[string]interface{}{
+		"Players":               string(playersJSON),
+		"OpponentsCombinations": string(opponentsJSON),
+		"Guesses":               string(guessesJSON),
+		"Status":                string(match.Status),
+		"IsTurnOf":              match.IsTurnOf,
+		"Mode":                  string(match.Mode),
+		"Winner":                match.Winner,
+		"Series":                string(seriesJSON),
+		"StartedBy":             match.StartedBy,
+		"RematchOffer":          plainOffer,
+		"Commitments":           string(commitmentsJSON),
+		"TournamentId":          match.TournamentId,
+	}
+}
+
+func decodeMatch(roomId string, results []interface{}) (*domain.Match, error) {
+	if utils.IsSliceWithNilValues(results[:5]) {
 		return nil, domain.ErrEmptyResult
 	}
 
@@ -241,68 +697,110 @@ func (r *MatchesRepository) GetAll(ctx context.Context, roomId string) (*domain.
 
 	status := domain.MatchStatus(results[3].(string))
 
-	isTurnOf := results[4].(string)
+	isTurnOf, _ := results[4].(string)
+
+	winner, _ := results[6].(string)
+
+	series, err := parseSeries(results[7])
+	if err != nil {
+		return nil, err
+	}
+
+	startedBy, _ := results[8].(string)
+
+	rematchOffer, err := parseRematchOffer(results[9])
+	if err != nil {
+		return nil, err
+	}
+
+	commitments, err := parseCommitments(results[10])
+	if err != nil {
+		return nil, err
+	}
+
+	tournamentId, _ := results[11].(string)
 
 	match := &domain.Match{
+		RoomId:                roomId,
 		Players:               players,
 		OpponentsCombinations: combinations,
 		Status:                status,
 		IsTurnOf:              isTurnOf,
 		Guesses:               guesses,
+		Mode:                  parseMode(results[5]),
+		Winner:                winner,
+		Series:                series,
+		StartedBy:             startedBy,
+		RematchOffer:          rematchOffer,
+		Commitments:           commitments,
+		TournamentId:          tournamentId,
 	}
 
 	return match, nil
 }
 
-func (r *MatchesRepository) SetNewGuess(ctx context.Context, command contracts.SetNewGuessCommand) error {
-	key := getKeyById(command.RoomId)
-
-	guessesJSON, _ := json.Marshal(command.Guesses)
-
-	payload := map[string]interface{}{
-		"Guesses":  string(guessesJSON),
-		"IsTurnOf": command.IsTurnOf,
+func parseMode(value interface{}) domain.MatchMode {
+	if mode, ok := value.(string); ok && mode != "" {
+		return domain.MatchMode(mode)
 	}
+	return domain.MatchModeTurns
+}
 
-	if command.IsWinner {
-		payload["Status"] = string(domain.MatchStateFinished)
+func parseSeries(value interface{}) (domain.MatchSeries, error) {
+	plain, ok := value.(string)
+	if !ok || plain == "" {
+		return domain.NewMatchSeries(1), nil
 	}
 
-	return r.rdb.HSet(ctx, key, payload).Err()
+	var series domain.MatchSeries
+	if err := json.Unmarshal([]byte(plain), &series); err != nil {
+		return series, err
+	}
+	return series, nil
 }
 
-func (r *MatchesRepository) Exists(ctx context.Context, roomId string) error {
-	key := getKeyById(roomId)
-	return r.rdb.Exists(ctx, key).Err()
+func parseRematchOffer(value interface{}) (*domain.RematchOffer, error) {
+	plain, ok := value.(string)
+	if !ok || plain == "" {
+		return nil, nil
+	}
+
+	offer := &domain.RematchOffer{}
+	if err := json.Unmarshal([]byte(plain), offer); err != nil {
+		return nil, err
+	}
+	return offer, nil
 }
 
-func (r *MatchesRepository) Restart(ctx context.Context, roomId string) error {
-	key := getKeyById(roomId)
+// parseCommitments tolerates rooms created before commitments were stored.
+func parseCommitments(value interface{}) (domain.MatchCommitments, error) {
+	commitments := make(domain.MatchCommitments)
 
-	match := &domain.Match{
-		OpponentsCombinations: make(domain.MatchOpponentCombinations),
-		Guesses:               make(domain.MatchGuesses),
-		Status:                domain.MatchStateFullRoom,
+	plain, ok := value.(string)
+	if !ok || plain == "" {
+		return commitments, nil
 	}
 
-	opponentsJSON, _ := json.Marshal(match.OpponentsCombinations)
-	guessesJSON, _ := json.Marshal(match.Guesses)
-
-	payload := map[string]interface{}{
-		"OpponentsCombinations": string(opponentsJSON),
-		"Guesses":               string(guessesJSON),
-		"Status":                string(match.Status),
+	if err := json.Unmarshal([]byte(plain), &commitments); err != nil {
+		return nil, err
 	}
+	return commitments, nil
+}
 
-	resErr := r.rdb.HSet(ctx, key, payload).Err()
+// The id is wrapped in a hash tag so every key that belongs to a room hashes to the
+// same cluster slot and can take part in the same transaction.
+func getKeyById(roomId string) string {
+	return fmt.Sprintf("room:{%v}", roomId)
+}
 
-	if err := r.rdb.Expire(ctx, key, CREATE_OR_UPDATE_MATCH_EXP).Err(); err != nil {
-		return err
-	}
+func getTombstoneKeyById(roomId string) string {
+	return fmt.Sprintf("room:{%v}:expired", roomId)
+}
 
-	return resErr
+func getChatKeyById(roomId string) string {
+	return fmt.Sprintf("room:{%v}:chat", roomId)
 }
 
-func getKeyById(roomId string) string {
-	return fmt.Sprintf("room:%v", roomId)
+func getEventsChannelById(roomId string) string {
+	return fmt.Sprintf("room:{%v}:events", roomId)
 }
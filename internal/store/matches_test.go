@@ -0,0 +1,489 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var testTTLPolicy = RoomTTLPolicy{
+	Waiting:   10 * time.Minute,
+	Playing:   time.Hour,
+	Finished:  5 * time.Minute,
+	Tombstone: 24 * time.Hour,
+}
+
+var testRandom = domain.NewSeededRandom(1)
+
+func newTestMatchesRepository(t *testing.T) (*MatchesRepository, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return newMatchesRepository(rdb, testTTLPolicy, domain.SystemClock{}), server
+}
+
+// createFullRoom creates a room with two players, alice (p1) hosting and bob (p2).
+func createFullRoom(t *testing.T, repository *MatchesRepository) string {
+	t.Helper()
+	ctx := context.Background()
+
+	roomId, err := domain.GenerateMatchId(testRandom)
+	if err != nil {
+		t.Fatalf("generating room id: %v", err)
+	}
+
+	match, err := repository.CreateMatch(ctx, contracts.CreateMatchCommand{
+		RoomId: roomId,
+		Player: domain.Player{Id: "p1", Username: "alice"},
+		Mode:   domain.MatchModeTurns,
+		BestOf: 3,
+	})
+	if err != nil {
+		t.Fatalf("creating match: %v", err)
+	}
+
+	players := match.Players
+	players["p2"] = domain.Player{Id: "p2", Username: "bob"}
+	if err := repository.SetPlayersAndFillRoom(ctx, contracts.SetPlayersCommand{RoomId: match.RoomId, Players: players}); err != nil {
+		t.Fatalf("filling room: %v", err)
+	}
+
+	return match.RoomId
+}
+
+func assertRoomTracked(t *testing.T, server *miniredis.Miniredis, roomId string, status domain.MatchStatus) {
+	t.Helper()
+
+	if ttl := server.TTL(getKeyById(roomId)); ttl != testTTLPolicy.For(status) {
+		t.Fatalf("expected the room to live %v while %v, got %v", testTTLPolicy.For(status), status, ttl)
+	}
+	if tracked := server.HGet(ROOMS_STATUS_KEY, roomId); tracked != string(status) {
+		t.Fatalf("expected the status index to hold %v, got %q", status, tracked)
+	}
+	if _, err := server.ZScore(ROOMS_EXPIRY_KEY, roomId); err != nil {
+		t.Fatalf("expected the room in the expiry index: %v", err)
+	}
+}
+
+func TestCreateMatch(t *testing.T) {
+	repository, server := newTestMatchesRepository(t)
+	ctx := context.Background()
+
+	created, err := repository.CreateMatch(ctx, contracts.CreateMatchCommand{
+		RoomId: "abc1234",
+		Player: domain.Player{Id: "p1", Username: "alice"},
+		Mode:   domain.MatchModeRace,
+		BestOf: 5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.RoomId != "abc1234" {
+		t.Fatalf("expected the room id of the command, got %q", created.RoomId)
+	}
+
+	match, err := repository.GetAll(ctx, created.RoomId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if match.Status != domain.MatchStateWaiting || match.Mode != domain.MatchModeRace || match.Series.BestOf != 5 {
+		t.Fatalf("unexpected match %+v", match)
+	}
+	if match.Players["p1"].Username != "alice" || match.IsTurnOf != "p1" {
+		t.Fatalf("expected alice to host the room, got %+v", match.Players)
+	}
+
+	assertRoomTracked(t, server, created.RoomId, domain.MatchStateWaiting)
+}
+
+func TestCreateFullRoom(t *testing.T) {
+	repository, server := newTestMatchesRepository(t)
+	ctx := context.Background()
+
+	command := contracts.CreateFullRoomCommand{
+		RoomId:       "abc1234",
+		TournamentId: "tourney",
+		Players: domain.MatchPlayers{
+			"p1": {Id: "p1", Username: "alice"},
+			"p2": {Id: "p2", Username: "bob"},
+		},
+		Mode:   domain.MatchModeTurns,
+		BestOf: 3,
+	}
+	if err := repository.CreateFullRoom(ctx, command); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertRoomTracked(t, server, command.RoomId, domain.MatchStateFullRoom)
+
+	if err := repository.ChangeStatusAndTurn(ctx, command.RoomId, domain.MatchStatePlaying, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// opening the room again must leave the game being played alone
+	command.Players = domain.MatchPlayers{"p3": {Id: "p3", Username: "carol"}}
+	if err := repository.CreateFullRoom(ctx, command); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	match, err := repository.GetAll(ctx, command.RoomId)
+	if err != nil || match.Status != domain.MatchStatePlaying || len(match.Players) != 2 || match.TournamentId != "tourney" || match.Series.BestOf != 3 {
+		t.Fatalf("unexpected match %+v, %v", match, err)
+	}
+
+	match, err = repository.GetAllButGuesses(ctx, command.RoomId)
+	if err != nil || match.TournamentId != "tourney" {
+		t.Fatalf("expected the room to keep its tournament, got %+v, %v", match, err)
+	}
+}
+
+func TestMissingRoom(t *testing.T) {
+	repository, _ := newTestMatchesRepository(t)
+	ctx := context.Background()
+
+	reads := map[string]func() error{
+		"GetRoomPlayers": func() error {
+			_, err := repository.GetRoomPlayers(ctx, "missing")
+			return err
+		},
+		"GetMatchStatusById": func() error {
+			_, err := repository.GetMatchStatusById(ctx, "missing")
+			return err
+		},
+		"GetAllButGuesses": func() error {
+			_, err := repository.GetAllButGuesses(ctx, "missing")
+			return err
+		},
+		"GetAll": func() error {
+			_, err := repository.GetAll(ctx, "missing")
+			return err
+		},
+		"UpdateGuessesAtomically": func() error {
+			return repository.UpdateGuessesAtomically(ctx, "missing", func(match *domain.Match) error { return nil })
+		},
+		"UpdatePlayersAtomically": func() error {
+			return repository.UpdatePlayersAtomically(ctx, "missing", func(match *domain.Match) error { return nil })
+		},
+		"UpdateCombinationsAtomically": func() error {
+			return repository.UpdateCombinationsAtomically(ctx, "missing", func(match *domain.Match) error { return nil })
+		},
+		"AppendChatMessage": func() error {
+			return repository.AppendChatMessage(ctx, "missing", domain.ChatMessage{Id: "m1", PlayerId: "p1", Message: "hi"})
+		},
+	}
+
+	for name, read := range reads {
+		t.Run(name, func(t *testing.T) {
+			if err := read(); !errors.Is(err, domain.ErrEmptyResult) {
+				t.Fatalf("expected %v, got %v", domain.ErrEmptyResult, err)
+			}
+		})
+	}
+}
+
+func TestRoomLifecycle(t *testing.T) {
+	repository, server := newTestMatchesRepository(t)
+	ctx := context.Background()
+	roomId := createFullRoom(t, repository)
+
+	status, err := repository.GetMatchStatusById(ctx, roomId)
+	if err != nil || status != domain.MatchStateFullRoom {
+		t.Fatalf("expected a full room, got %v, %v", status, err)
+	}
+	assertRoomTracked(t, server, roomId, domain.MatchStateFullRoom)
+
+	combinations := domain.MatchOpponentCombinations{"p1": "5678", "p2": "1234"}
+	commitments := domain.MatchCommitments{"p1": {Digest: domain.CommitSecret("1234", "00"), Salt: "00"}}
+	err = repository.UpdateCombinationsAtomically(ctx, roomId, func(match *domain.Match) error {
+		match.OpponentsCombinations = combinations
+		match.Commitments = commitments
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stored, err := repository.GetAll(ctx, roomId)
+	if err != nil || len(stored.Players) != 2 || stored.OpponentsCombinations["p2"] != "1234" || stored.Commitments["p1"] != commitments["p1"] {
+		t.Fatalf("unexpected players and combinations %+v, %v", stored, err)
+	}
+
+	if err := repository.ChangeStatusAndTurn(ctx, roomId, domain.MatchStatePlaying, "p2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertRoomTracked(t, server, roomId, domain.MatchStatePlaying)
+
+	match, err := repository.GetAllButGuesses(ctx, roomId)
+	if err != nil || match.IsTurnOf != "p2" || match.StartedBy != "p2" || match.Status != domain.MatchStatePlaying || len(match.Commitments) != 1 {
+		t.Fatalf("unexpected match %+v, %v", match, err)
+	}
+
+	err = repository.UpdateGuessesAtomically(ctx, roomId, func(match *domain.Match) error {
+		item, err := match.GetNewGuess("1234", match.OpponentsCombinations["p2"])
+		if err != nil {
+			return err
+		}
+		match.Guesses["p2"] = append(match.Guesses["p2"], *item)
+		match.Finish("p2")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertRoomTracked(t, server, roomId, domain.MatchStateFinished)
+
+	match, err = repository.GetAll(ctx, roomId)
+	if err != nil || match.Winner != "p2" || len(match.Guesses["p2"]) != 1 || len(match.Series.Rounds) != 1 {
+		t.Fatalf("expected p2 to win the first round, got %+v, %v", match, err)
+	}
+
+	offer := domain.NewRematchOffer("p1", time.Minute, time.Now())
+	if err := repository.SetRematchOffer(ctx, roomId, offer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	match, err = repository.GetAllButGuesses(ctx, roomId)
+	if err != nil || !match.RematchOffer.IsPending(time.Now()) || match.RematchOffer.OfferedBy != "p1" {
+		t.Fatalf("expected a pending offer from p1, got %+v, %v", match.RematchOffer, err)
+	}
+
+	if err := repository.Restart(ctx, roomId); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertRoomTracked(t, server, roomId, domain.MatchStateFullRoom)
+
+	match, err = repository.GetAll(ctx, roomId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if match.Winner != "" || match.RematchOffer != nil || len(match.Guesses) != 0 || len(match.OpponentsCombinations) != 0 || len(match.Commitments) != 0 {
+		t.Fatalf("expected the round to be reset, got %+v", match)
+	}
+	if len(match.Series.Rounds) != 1 {
+		t.Fatalf("the series must survive a restart, got %+v", match.Series)
+	}
+
+	counts, err := repository.CountRoomsByStatus(ctx)
+	if err != nil || counts[domain.MatchStateFullRoom] != 1 {
+		t.Fatalf("expected one full room, got %v, %v", counts, err)
+	}
+}
+
+func TestUpdateAtomicallyDiscardsFailedUpdates(t *testing.T) {
+	repository, _ := newTestMatchesRepository(t)
+	ctx := context.Background()
+	roomId := createFullRoom(t, repository)
+
+	errRejected := fmt.Errorf("rejected")
+	err := repository.UpdatePlayersAtomically(ctx, roomId, func(match *domain.Match) error {
+		match.Players["p3"] = domain.Player{Id: "p3"}
+		return errRejected
+	})
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("expected the update error, got %v", err)
+	}
+
+	players, err := repository.GetRoomPlayers(ctx, roomId)
+	if err != nil || len(players) != 2 {
+		t.Fatalf("expected the players to stay untouched, got %v, %v", players, err)
+	}
+}
+
+func TestUpdateGuessesAtomicallyConcurrently(t *testing.T) {
+	repository, _ := newTestMatchesRepository(t)
+	ctx := context.Background()
+	roomId := createFullRoom(t, repository)
+
+	// every write and TTL refresh makes the other writers retry, half the retry
+	// budget keeps the test deterministic
+	writers := MAX_TRANSACTION_RETRIES / 2
+
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = repository.UpdateGuessesAtomically(ctx, roomId, func(match *domain.Match) error {
+				match.Guesses["p1"] = append(match.Guesses["p1"], domain.GuessesHistoryItem{Round: len(match.Guesses["p1"]) + 1})
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	match, err := repository.GetAll(ctx, roomId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(match.Guesses["p1"]) != writers {
+		t.Fatalf("expected %v guesses, got %v: updates were lost", writers, len(match.Guesses["p1"]))
+	}
+	for i, item := range match.Guesses["p1"] {
+		if item.Round != i+1 {
+			t.Fatalf("expected round %v, got %v", i+1, item.Round)
+		}
+	}
+}
+
+func TestChatMessages(t *testing.T) {
+	repository, server := newTestMatchesRepository(t)
+	ctx := context.Background()
+	roomId := createFullRoom(t, repository)
+
+	for i := 0; i < CHAT_HISTORY_SIZE+5; i++ {
+		if err := repository.AppendChatMessage(ctx, roomId, domain.ChatMessage{Id: fmt.Sprint(i), PlayerId: "p1", Message: fmt.Sprint(i), SentAt: time.Now()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	messages, err := repository.GetChatMessages(ctx, roomId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != CHAT_HISTORY_SIZE || messages[0].Message != "5" || messages[len(messages)-1].Message != fmt.Sprint(CHAT_HISTORY_SIZE+4) {
+		t.Fatalf("expected the last %v messages, got %v from %q", CHAT_HISTORY_SIZE, len(messages), messages[0].Message)
+	}
+
+	if ttl := server.TTL(getChatKeyById(roomId)); ttl != testTTLPolicy.Waiting {
+		t.Fatalf("expected the chat to expire with the room, got %v", ttl)
+	}
+
+	messages, err = repository.GetChatMessages(ctx, "missing")
+	if err != nil || len(messages) != 0 {
+		t.Fatalf("expected no messages for an unknown room, got %v, %v", messages, err)
+	}
+}
+
+func TestWatchRoom(t *testing.T) {
+	repository, _ := newTestMatchesRepository(t)
+	roomId := createFullRoom(t, repository)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := repository.WatchRoom(ctx, roomId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repository.ChangeStatusAndTurn(context.Background(), roomId, domain.MatchStatePlaying, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the change signal")
+	}
+
+	cancel()
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the channel to be closed once ctx is done")
+		}
+	}
+}
+
+func TestSweepExpiredRooms(t *testing.T) {
+	repository, server := newTestMatchesRepository(t)
+	ctx := context.Background()
+	expiredId := createFullRoom(t, repository)
+	aliveId := createFullRoom(t, repository)
+
+	// both are due in the index, only one of the keys is actually gone
+	past := float64(time.Now().Add(-time.Minute).Unix())
+	server.ZAdd(ROOMS_EXPIRY_KEY, past, expiredId)
+	server.ZAdd(ROOMS_EXPIRY_KEY, past, aliveId)
+	server.Del(getKeyById(expiredId))
+
+	expired, err := repository.IsExpired(ctx, expiredId)
+	if err != nil || !expired {
+		t.Fatalf("a room gone before the sweep is expired, got %v, %v", expired, err)
+	}
+
+	rooms, err := repository.SweepExpiredRooms(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rooms) != 1 || rooms[0].RoomId != expiredId || rooms[0].LastStatus != domain.MatchStateFullRoom {
+		t.Fatalf("expected only %v to be swept, got %+v", expiredId, rooms)
+	}
+
+	if !server.Exists(getTombstoneKeyById(expiredId)) || server.TTL(getTombstoneKeyById(expiredId)) != testTTLPolicy.Tombstone {
+		t.Fatalf("expected a tombstone for %v", expiredId)
+	}
+	if tracked := server.HGet(ROOMS_STATUS_KEY, expiredId); tracked != "" {
+		t.Fatalf("expected %v to leave the status index, got %q", expiredId, tracked)
+	}
+	if score, err := server.ZScore(ROOMS_EXPIRY_KEY, aliveId); err != nil || score <= past {
+		t.Fatalf("expected %v to be rescheduled, got %v, %v", aliveId, score, err)
+	}
+
+	for _, tt := range []struct {
+		roomId string
+		want   bool
+	}{
+		{expiredId, true},
+		{aliveId, false},
+		{"missing", false},
+	} {
+		expired, err := repository.IsExpired(ctx, tt.roomId)
+		if err != nil || expired != tt.want {
+			t.Fatalf("IsExpired(%v) = %v, %v, want %v", tt.roomId, expired, err, tt.want)
+		}
+	}
+
+	rooms, err = repository.SweepExpiredRooms(ctx)
+	if err != nil || len(rooms) != 0 {
+		t.Fatalf("rooms are only swept once, got %+v, %v", rooms, err)
+	}
+}
+
+func TestExpiryFollowsTheClock(t *testing.T) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	clock := &domain.FixedClock{At: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	repository := newMatchesRepository(rdb, testTTLPolicy, clock)
+	ctx := context.Background()
+	roomId := createFullRoom(t, repository)
+
+	ttl := testTTLPolicy.For(domain.MatchStateFullRoom)
+	if score, err := server.ZScore(ROOMS_EXPIRY_KEY, roomId); err != nil || int64(score) != clock.At.Add(ttl).Unix() {
+		t.Fatalf("expected %v to expire at %v, got %v, %v", roomId, clock.At.Add(ttl).Unix(), score, err)
+	}
+
+	server.Del(getKeyById(roomId))
+
+	rooms, err := repository.SweepExpiredRooms(ctx)
+	if err != nil || len(rooms) != 0 {
+		t.Fatalf("a room is not swept before its expiry, got %+v, %v", rooms, err)
+	}
+
+	clock.Advance(ttl)
+
+	rooms, err = repository.SweepExpiredRooms(ctx)
+	if err != nil || len(rooms) != 1 || rooms[0].RoomId != roomId || !rooms[0].ExpiredAt.Equal(clock.At) {
+		t.Fatalf("expected %v to be swept at %v, got %+v, %v", roomId, clock.At, rooms, err)
+	}
+}
@@ -2,20 +2,121 @@ package store
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedisClient(addr, pw string, db int) (*redis.Client, error) {
-	r := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: pw,
-		DB:       db,
-	})
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+type RedisTLSOptions struct {
+	Enabled            bool
+	ServerName         string
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+}
+
+type RedisOptions struct {
+	Mode             string
+	Addrs            []string
+	MasterName       string
+	Username         string
+	Password         string
+	SentinelUsername string
+	SentinelPassword string
+	DB               int
+	TLS              RedisTLSOptions
+	PoolSize         int
+	MinIdleConns     int
+	DialTimeout      time.Duration
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+}
+
+// NewRedisClient builds a standalone, sentinel or cluster client depending on the
+// mode, so repositories only ever see a redis.UniversalClient.
+func NewRedisClient(options RedisOptions) (redis.UniversalClient, error) {
+	universalOptions := &redis.UniversalOptions{
+		Addrs:            options.Addrs,
+		Username:         options.Username,
+		Password:         options.Password,
+		SentinelUsername: options.SentinelUsername,
+		SentinelPassword: options.SentinelPassword,
+		DB:               options.DB,
+		PoolSize:         options.PoolSize,
+		MinIdleConns:     options.MinIdleConns,
+		DialTimeout:      options.DialTimeout,
+		ReadTimeout:      options.ReadTimeout,
+		WriteTimeout:     options.WriteTimeout,
+	}
+
+	switch options.Mode {
+	case RedisModeSentinel:
+		universalOptions.MasterName = options.MasterName
+	case RedisModeCluster:
+		universalOptions.IsClusterMode = true
+	case RedisModeStandalone, "":
+		if len(options.Addrs) != 1 {
+			return nil, fmt.Errorf("standalone redis expects exactly one address, got %v", len(options.Addrs))
+		}
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", options.Mode)
+	}
+
+	if options.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(options.TLS)
+		if err != nil {
+			return nil, err
+		}
+		universalOptions.TLSConfig = tlsConfig
+	}
+
+	r := redis.NewUniversalClient(universalOptions)
 
 	if err := r.Ping(context.Background()).Err(); err != nil {
+		r.Close()
 		return nil, err
 	}
 
 	return r, nil
 }
+
+func newTLSConfig(options RedisTLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         options.ServerName,
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+
+	if options.CAFile != "" {
+		ca, err := os.ReadFile(options.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis tls ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("redis tls ca: no certificates found in %v", options.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if options.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
@@ -1,15 +1,53 @@
 package store
 
 import (
+	"time"
+
 	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedisStorage(rdb *redis.Client) contracts.Storage {
+type RoomTTLPolicy struct {
+	Waiting   time.Duration
+	Playing   time.Duration
+	Finished  time.Duration
+	Tombstone time.Duration
+}
+
+// For returns how long a room may stay idle in the given status. Rooms in the lobby
+// get the waiting TTL until the match starts.
+func (p RoomTTLPolicy) For(status domain.MatchStatus) time.Duration {
+	switch status {
+	case domain.MatchStatePlaying:
+		return p.Playing
+	case domain.MatchStateFinished:
+		return p.Finished
+	}
+	return p.Waiting
+}
+
+type Config struct {
+	RoomTTL RoomTTLPolicy
+	// Clock dates the expiry index and the sweeps, it defaults to the system clock.
+	Clock domain.Clock
+}
+
+func NewRedisStorage(rdb redis.UniversalClient, config Config) contracts.Storage {
+	clock := config.Clock
+	if clock == nil {
+		clock = domain.SystemClock{}
+	}
 
-	matchesRepository := newMatchesRepository(rdb)
+	matchesRepository := newMatchesRepository(rdb, config.RoomTTL, clock)
+	tournamentsRepository := newTournamentsRepository(rdb)
+	dailyRepository := newDailyRepository(rdb)
+	adminRepository := newAdminRepository(rdb, matchesRepository)
 
 	return contracts.Storage{
-		MatchesRepository: matchesRepository,
+		MatchesRepository:     matchesRepository,
+		TournamentsRepository: tournamentsRepository,
+		DailyRepository:       dailyRepository,
+		AdminRepository:       adminRepository,
 	}
 }
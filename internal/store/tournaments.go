@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	CREATE_OR_UPDATE_TOURNAMENT_EXP = time.Hour * 24 * 7
+)
+
+type TournamentsRepository struct {
+	rdb redis.UniversalClient
+}
+
+func newTournamentsRepository(rdb redis.UniversalClient) *TournamentsRepository {
+	return &TournamentsRepository{
+		rdb: rdb,
+	}
+}
+
+func (r *TournamentsRepository) CreateTournament(ctx context.Context, tournament *domain.Tournament) error {
+	key := getTournamentKeyById(tournament.Id)
+
+	plainTournament, err := json.Marshal(tournament)
+	if err != nil {
+		return err
+	}
+
+	return r.rdb.Set(ctx, key, plainTournament, CREATE_OR_UPDATE_TOURNAMENT_EXP).Err()
+}
+
+func (r *TournamentsRepository) GetTournament(ctx context.Context, tournamentId string) (*domain.Tournament, error) {
+	key := getTournamentKeyById(tournamentId)
+
+	result, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrEmptyResult
+		}
+		return nil, err
+	}
+
+	tournament := &domain.Tournament{}
+	if err := json.Unmarshal([]byte(result), tournament); err != nil {
+		return nil, err
+	}
+
+	return tournament, nil
+}
+
+func (r *TournamentsRepository) UpdateTournament(ctx context.Context, tournamentId string, update func(tournament *domain.Tournament) error) error {
+	key := getTournamentKeyById(tournamentId)
+
+	txf := func(tx *redis.Tx) error {
+		result, err := tx.Get(ctx, key).Result()
+		if err != nil {
+			if err == redis.Nil {
+				return domain.ErrEmptyResult
+			}
+			return err
+		}
+
+		tournament := &domain.Tournament{}
+		if err := json.Unmarshal([]byte(result), tournament); err != nil {
+			return err
+		}
+
+		if err := update(tournament); err != nil {
+			return err
+		}
+
+		plainTournament, err := json.Marshal(tournament)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.Set(ctx, key, plainTournament, CREATE_OR_UPDATE_TOURNAMENT_EXP).Err()
+		})
+		return err
+	}
+
+	for i := 0; i < MAX_TRANSACTION_RETRIES; i++ {
+		err := r.rdb.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+
+	return redis.TxFailedErr
+}
+
+func getTournamentKeyById(tournamentId string) string {
+	return fmt.Sprintf("tournament:{%v}", tournamentId)
+}
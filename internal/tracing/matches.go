@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/contracts"
+	"github.com/alejandro-cardenas-g/bullAndCowsApp/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type matchesService struct {
+	next contracts.IMatchesService
+}
+
+// NewMatchesService wraps every matches service call in its own span. Player ids are
+// credentials, spans name players by their handle.
+func NewMatchesService(next contracts.IMatchesService) contracts.IMatchesService {
+	return &matchesService{
+		next: next,
+	}
+}
+
+func (s *matchesService) CreateRoom(ctx context.Context, command contracts.CreateRoomCommand) (res *contracts.CreateRoomResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.CreateRoom")
+	defer func() {
+		if res != nil {
+			span.SetAttributes(attribute.String("room_id", res.RoomId), attribute.String("player_handle", res.Player.Handle))
+		}
+		end(span, err)
+	}()
+	return s.next.CreateRoom(ctx, command)
+}
+
+func (s *matchesService) JoinRoom(ctx context.Context, command contracts.JoinRoomCommand) (res *contracts.JoinRoomResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.JoinRoom", attribute.String("room_id", command.RoomId))
+	defer func() {
+		if res != nil {
+			span.SetAttributes(attribute.String("player_handle", res.Player.Handle))
+		}
+		end(span, err)
+	}()
+	return s.next.JoinRoom(ctx, command)
+}
+
+func (s *matchesService) SetCombination(ctx context.Context, command contracts.SetCombinationCommand) (res *contracts.SuccessResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.SetCombination", attribute.String("room_id", command.RoomId), attribute.String("player_handle", domain.PlayerHandle(command.PlayerId)))
+	defer func() { end(span, err) }()
+	return s.next.SetCombination(ctx, command)
+}
+
+func (s *matchesService) StartGame(ctx context.Context, roomId string) (res *contracts.StartMatchResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.StartGame", attribute.String("room_id", roomId))
+	defer func() { end(span, err) }()
+	return s.next.StartGame(ctx, roomId)
+}
+
+func (s *matchesService) MakeGuess(ctx context.Context, command contracts.MakeGuessCommand) (res *contracts.MakeGuessResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.MakeGuess", attribute.String("room_id", command.RoomId), attribute.String("player_handle", domain.PlayerHandle(command.PlayerId)))
+	defer func() {
+		if res != nil {
+			span.SetAttributes(attribute.Bool("is_winner", res.IsWinner))
+		}
+		end(span, err)
+	}()
+	return s.next.MakeGuess(ctx, command)
+}
+
+func (s *matchesService) OfferRematch(ctx context.Context, command contracts.RematchCommand) (res *contracts.SuccessResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.OfferRematch", attribute.String("room_id", command.RoomId), attribute.String("player_handle", domain.PlayerHandle(command.PlayerId)))
+	defer func() { end(span, err) }()
+	return s.next.OfferRematch(ctx, command)
+}
+
+func (s *matchesService) AcceptRematch(ctx context.Context, command contracts.RematchCommand) (res *contracts.SuccessResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.AcceptRematch", attribute.String("room_id", command.RoomId), attribute.String("player_handle", domain.PlayerHandle(command.PlayerId)))
+	defer func() { end(span, err) }()
+	return s.next.AcceptRematch(ctx, command)
+}
+
+func (s *matchesService) DeclineRematch(ctx context.Context, command contracts.RematchCommand) (res *contracts.SuccessResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.DeclineRematch", attribute.String("room_id", command.RoomId), attribute.String("player_handle", domain.PlayerHandle(command.PlayerId)))
+	defer func() { end(span, err) }()
+	return s.next.DeclineRematch(ctx, command)
+}
+
+func (s *matchesService) GetMatch(ctx context.Context, roomId string) (res *contracts.MatchStateResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.GetMatch", attribute.String("room_id", roomId))
+	defer func() { end(span, err) }()
+	return s.next.GetMatch(ctx, roomId)
+}
+
+// WatchMatch only traces the subscription, the stream itself can last for hours.
+func (s *matchesService) WatchMatch(ctx context.Context, roomId string) (res <-chan contracts.MatchEvent, err error) {
+	ctx, span := start(ctx, "MatchesService.WatchMatch", attribute.String("room_id", roomId))
+	defer func() { end(span, err) }()
+	return s.next.WatchMatch(ctx, roomId)
+}
+
+func (s *matchesService) SendChatMessage(ctx context.Context, command contracts.ChatMessageCommand) (res *contracts.ChatMessageResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.SendChatMessage", attribute.String("room_id", command.RoomId), attribute.String("player_handle", domain.PlayerHandle(command.PlayerId)))
+	defer func() { end(span, err) }()
+	return s.next.SendChatMessage(ctx, command)
+}
+
+func (s *matchesService) GetChat(ctx context.Context, roomId string) (res *contracts.ChatResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.GetChat", attribute.String("room_id", roomId))
+	defer func() { end(span, err) }()
+	return s.next.GetChat(ctx, roomId)
+}
+
+func (s *matchesService) VerifyCommitment(ctx context.Context, command contracts.VerifyCommitmentCommand) (res *contracts.VerifyCommitmentResponse, err error) {
+	ctx, span := start(ctx, "MatchesService.VerifyCommitment")
+	defer func() { end(span, err) }()
+	return s.next.VerifyCommitment(ctx, command)
+}
+
+func start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,34 @@
+package utils
+
+import "net/http"
+
+type ResponseRecorder struct {
+	http.ResponseWriter
+	Status      int
+	Bytes       int
+	WroteHeader bool
+}
+
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{
+		ResponseWriter: w,
+		Status:         http.StatusOK,
+	}
+}
+
+func (r *ResponseRecorder) WriteHeader(status int) {
+	r.Status = status
+	r.WroteHeader = true
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *ResponseRecorder) Write(b []byte) (int, error) {
+	r.WroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.Bytes += n
+	return n, err
+}
+
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,4 @@
+// Package bullandcowsv1 holds the generated protobuf and gRPC code for the game API.
+package bullandcowsv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative bullandcows/v1/matches.proto
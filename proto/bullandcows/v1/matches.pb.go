@@ -0,0 +1,2007 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: bullandcows/v1/matches.proto
+
+package bullandcowsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MatchMode int32
+
+const (
+	MatchMode_MATCH_MODE_UNSPECIFIED MatchMode = 0
+	MatchMode_MATCH_MODE_TURNS       MatchMode = 1
+	MatchMode_MATCH_MODE_RACE        MatchMode = 2
+)
+
+// Enum value maps for MatchMode.
+var (
+	MatchMode_name = map[int32]string{
+		0: "MATCH_MODE_UNSPECIFIED",
+		1: "MATCH_MODE_TURNS",
+		2: "MATCH_MODE_RACE",
+	}
+	MatchMode_value = map[string]int32{
+		"MATCH_MODE_UNSPECIFIED": 0,
+		"MATCH_MODE_TURNS":       1,
+		"MATCH_MODE_RACE":        2,
+	}
+)
+
+func (x MatchMode) Enum() *MatchMode {
+	p := new(MatchMode)
+	*p = x
+	return p
+}
+
+func (x MatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bullandcows_v1_matches_proto_enumTypes[0].Descriptor()
+}
+
+func (MatchMode) Type() protoreflect.EnumType {
+	return &file_bullandcows_v1_matches_proto_enumTypes[0]
+}
+
+func (x MatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchMode.Descriptor instead.
+func (MatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{0}
+}
+
+type MatchStatus int32
+
+const (
+	MatchStatus_MATCH_STATUS_UNSPECIFIED MatchStatus = 0
+	MatchStatus_MATCH_STATUS_WAITING     MatchStatus = 1
+	MatchStatus_MATCH_STATUS_FULL_ROOM   MatchStatus = 2
+	MatchStatus_MATCH_STATUS_PLAYING     MatchStatus = 3
+	MatchStatus_MATCH_STATUS_FINISHED    MatchStatus = 4
+)
+
+// Enum value maps for MatchStatus.
+var (
+	MatchStatus_name = map[int32]string{
+		0: "MATCH_STATUS_UNSPECIFIED",
+		1: "MATCH_STATUS_WAITING",
+		2: "MATCH_STATUS_FULL_ROOM",
+		3: "MATCH_STATUS_PLAYING",
+		4: "MATCH_STATUS_FINISHED",
+	}
+	MatchStatus_value = map[string]int32{
+		"MATCH_STATUS_UNSPECIFIED": 0,
+		"MATCH_STATUS_WAITING":     1,
+		"MATCH_STATUS_FULL_ROOM":   2,
+		"MATCH_STATUS_PLAYING":     3,
+		"MATCH_STATUS_FINISHED":    4,
+	}
+)
+
+func (x MatchStatus) Enum() *MatchStatus {
+	p := new(MatchStatus)
+	*p = x
+	return p
+}
+
+func (x MatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bullandcows_v1_matches_proto_enumTypes[1].Descriptor()
+}
+
+func (MatchStatus) Type() protoreflect.EnumType {
+	return &file_bullandcows_v1_matches_proto_enumTypes[1]
+}
+
+func (x MatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchStatus.Descriptor instead.
+func (MatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{1}
+}
+
+type DigitResult int32
+
+const (
+	DigitResult_DIGIT_RESULT_UNSPECIFIED DigitResult = 0
+	DigitResult_DIGIT_RESULT_BULL        DigitResult = 1
+	DigitResult_DIGIT_RESULT_COW         DigitResult = 2
+	DigitResult_DIGIT_RESULT_NONE        DigitResult = 3
+)
+
+// Enum value maps for DigitResult.
+var (
+	DigitResult_name = map[int32]string{
+		0: "DIGIT_RESULT_UNSPECIFIED",
+		1: "DIGIT_RESULT_BULL",
+		2: "DIGIT_RESULT_COW",
+		3: "DIGIT_RESULT_NONE",
+	}
+	DigitResult_value = map[string]int32{
+		"DIGIT_RESULT_UNSPECIFIED": 0,
+		"DIGIT_RESULT_BULL":        1,
+		"DIGIT_RESULT_COW":         2,
+		"DIGIT_RESULT_NONE":        3,
+	}
+)
+
+func (x DigitResult) Enum() *DigitResult {
+	p := new(DigitResult)
+	*p = x
+	return p
+}
+
+func (x DigitResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DigitResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_bullandcows_v1_matches_proto_enumTypes[2].Descriptor()
+}
+
+func (DigitResult) Type() protoreflect.EnumType {
+	return &file_bullandcows_v1_matches_proto_enumTypes[2]
+}
+
+func (x DigitResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DigitResult.Descriptor instead.
+func (DigitResult) EnumDescriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{2}
+}
+
+type MatchEventType int32
+
+const (
+	MatchEventType_MATCH_EVENT_TYPE_UNSPECIFIED       MatchEventType = 0
+	MatchEventType_MATCH_EVENT_TYPE_SNAPSHOT          MatchEventType = 1
+	MatchEventType_MATCH_EVENT_TYPE_PLAYER_JOINED     MatchEventType = 2
+	MatchEventType_MATCH_EVENT_TYPE_PLAYER_LEFT       MatchEventType = 3
+	MatchEventType_MATCH_EVENT_TYPE_MATCH_STARTED     MatchEventType = 4
+	MatchEventType_MATCH_EVENT_TYPE_GUESS_MADE        MatchEventType = 5
+	MatchEventType_MATCH_EVENT_TYPE_MATCH_FINISHED    MatchEventType = 6
+	MatchEventType_MATCH_EVENT_TYPE_REMATCH_OFFERED   MatchEventType = 7
+	MatchEventType_MATCH_EVENT_TYPE_REMATCH_CANCELLED MatchEventType = 8
+	MatchEventType_MATCH_EVENT_TYPE_REMATCH_ACCEPTED  MatchEventType = 9
+	MatchEventType_MATCH_EVENT_TYPE_UPDATED           MatchEventType = 10
+	MatchEventType_MATCH_EVENT_TYPE_MATCH_EXPIRED     MatchEventType = 11
+	MatchEventType_MATCH_EVENT_TYPE_MATCH_CLOSED      MatchEventType = 12
+)
+
+// Enum value maps for MatchEventType.
+var (
+	MatchEventType_name = map[int32]string{
+		0:  "MATCH_EVENT_TYPE_UNSPECIFIED",
+		1:  "MATCH_EVENT_TYPE_SNAPSHOT",
+		2:  "MATCH_EVENT_TYPE_PLAYER_JOINED",
+		3:  "MATCH_EVENT_TYPE_PLAYER_LEFT",
+		4:  "MATCH_EVENT_TYPE_MATCH_STARTED",
+		5:  "MATCH_EVENT_TYPE_GUESS_MADE",
+		6:  "MATCH_EVENT_TYPE_MATCH_FINISHED",
+		7:  "MATCH_EVENT_TYPE_REMATCH_OFFERED",
+		8:  "MATCH_EVENT_TYPE_REMATCH_CANCELLED",
+		9:  "MATCH_EVENT_TYPE_REMATCH_ACCEPTED",
+		10: "MATCH_EVENT_TYPE_UPDATED",
+		11: "MATCH_EVENT_TYPE_MATCH_EXPIRED",
+		12: "MATCH_EVENT_TYPE_MATCH_CLOSED",
+	}
+	MatchEventType_value = map[string]int32{
+		"MATCH_EVENT_TYPE_UNSPECIFIED":       0,
+		"MATCH_EVENT_TYPE_SNAPSHOT":          1,
+		"MATCH_EVENT_TYPE_PLAYER_JOINED":     2,
+		"MATCH_EVENT_TYPE_PLAYER_LEFT":       3,
+		"MATCH_EVENT_TYPE_MATCH_STARTED":     4,
+		"MATCH_EVENT_TYPE_GUESS_MADE":        5,
+		"MATCH_EVENT_TYPE_MATCH_FINISHED":    6,
+		"MATCH_EVENT_TYPE_REMATCH_OFFERED":   7,
+		"MATCH_EVENT_TYPE_REMATCH_CANCELLED": 8,
+		"MATCH_EVENT_TYPE_REMATCH_ACCEPTED":  9,
+		"MATCH_EVENT_TYPE_UPDATED":           10,
+		"MATCH_EVENT_TYPE_MATCH_EXPIRED":     11,
+		"MATCH_EVENT_TYPE_MATCH_CLOSED":      12,
+	}
+)
+
+func (x MatchEventType) Enum() *MatchEventType {
+	p := new(MatchEventType)
+	*p = x
+	return p
+}
+
+func (x MatchEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bullandcows_v1_matches_proto_enumTypes[3].Descriptor()
+}
+
+func (MatchEventType) Type() protoreflect.EnumType {
+	return &file_bullandcows_v1_matches_proto_enumTypes[3]
+}
+
+func (x MatchEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchEventType.Descriptor instead.
+func (MatchEventType) EnumDescriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{3}
+}
+
+// Players are named by handle everywhere. The id authenticates every call of the
+// player, so it is only set in the CreateRoom and JoinRoom responses.
+type Player struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Handle        string                 `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Player) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type CreateRoomRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Unspecified uses the server default.
+	Mode MatchMode `protobuf:"varint,2,opt,name=mode,proto3,enum=bullandcows.v1.MatchMode" json:"mode,omitempty"`
+	// 1, 3, 5 or 7. Zero uses the server default.
+	BestOf        int32 `protobuf:"varint,3,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRoomRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetMode() MatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MatchMode_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *CreateRoomRequest) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+type CreateRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Mode          MatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=bullandcows.v1.MatchMode" json:"mode,omitempty"`
+	BestOf        int32                  `protobuf:"varint,3,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	Player        *Player                `protobuf:"bytes,4,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomResponse) Reset() {
+	*x = CreateRoomResponse{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomResponse) ProtoMessage() {}
+
+func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoomResponse) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRoomResponse) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *CreateRoomResponse) GetMode() MatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MatchMode_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *CreateRoomResponse) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *CreateRoomResponse) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type JoinRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomRequest) Reset() {
+	*x = JoinRoomRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomRequest) ProtoMessage() {}
+
+func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
+func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRoomRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type JoinRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Player        *Player                `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomResponse) Reset() {
+	*x = JoinRoomResponse{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomResponse) ProtoMessage() {}
+
+func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomResponse.ProtoReflect.Descriptor instead.
+func (*JoinRoomResponse) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JoinRoomResponse) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRoomResponse) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type SetCombinationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RoomId   string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	PlayerId string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// Four different digits, e.g. 1234.
+	Combination   int32 `protobuf:"varint,3,opt,name=combination,proto3" json:"combination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCombinationRequest) Reset() {
+	*x = SetCombinationRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCombinationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCombinationRequest) ProtoMessage() {}
+
+func (x *SetCombinationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCombinationRequest.ProtoReflect.Descriptor instead.
+func (*SetCombinationRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetCombinationRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *SetCombinationRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SetCombinationRequest) GetCombination() int32 {
+	if x != nil {
+		return x.Combination
+	}
+	return 0
+}
+
+type SetCombinationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCombinationResponse) Reset() {
+	*x = SetCombinationResponse{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCombinationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCombinationResponse) ProtoMessage() {}
+
+func (x *SetCombinationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCombinationResponse.ProtoReflect.Descriptor instead.
+func (*SetCombinationResponse) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetCombinationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type StartGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartGameRequest) Reset() {
+	*x = StartGameRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameRequest) ProtoMessage() {}
+
+func (x *StartGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameRequest.ProtoReflect.Descriptor instead.
+func (*StartGameRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StartGameRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type StartGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          MatchMode              `protobuf:"varint,1,opt,name=mode,proto3,enum=bullandcows.v1.MatchMode" json:"mode,omitempty"`
+	IsTurnOf      string                 `protobuf:"bytes,2,opt,name=is_turn_of,json=isTurnOf,proto3" json:"is_turn_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartGameResponse) Reset() {
+	*x = StartGameResponse{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameResponse) ProtoMessage() {}
+
+func (x *StartGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameResponse.ProtoReflect.Descriptor instead.
+func (*StartGameResponse) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StartGameResponse) GetMode() MatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MatchMode_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *StartGameResponse) GetIsTurnOf() string {
+	if x != nil {
+		return x.IsTurnOf
+	}
+	return ""
+}
+
+type MakeGuessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Guess         int32                  `protobuf:"varint,3,opt,name=guess,proto3" json:"guess,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeGuessRequest) Reset() {
+	*x = MakeGuessRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeGuessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeGuessRequest) ProtoMessage() {}
+
+func (x *MakeGuessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeGuessRequest.ProtoReflect.Descriptor instead.
+func (*MakeGuessRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MakeGuessRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *MakeGuessRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MakeGuessRequest) GetGuess() int32 {
+	if x != nil {
+		return x.Guess
+	}
+	return 0
+}
+
+type Digit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Result        DigitResult            `protobuf:"varint,2,opt,name=result,proto3,enum=bullandcows.v1.DigitResult" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Digit) Reset() {
+	*x = Digit{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Digit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Digit) ProtoMessage() {}
+
+func (x *Digit) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Digit.ProtoReflect.Descriptor instead.
+func (*Digit) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Digit) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Digit) GetResult() DigitResult {
+	if x != nil {
+		return x.Result
+	}
+	return DigitResult_DIGIT_RESULT_UNSPECIFIED
+}
+
+type Guess struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Digits              []*Digit               `protobuf:"bytes,1,rep,name=digits,proto3" json:"digits,omitempty"`
+	IsWinnerCombination bool                   `protobuf:"varint,2,opt,name=is_winner_combination,json=isWinnerCombination,proto3" json:"is_winner_combination,omitempty"`
+	Round               int32                  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	PlayedAt            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=played_at,json=playedAt,proto3" json:"played_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Guess) Reset() {
+	*x = Guess{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Guess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Guess) ProtoMessage() {}
+
+func (x *Guess) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Guess.ProtoReflect.Descriptor instead.
+func (*Guess) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Guess) GetDigits() []*Digit {
+	if x != nil {
+		return x.Digits
+	}
+	return nil
+}
+
+func (x *Guess) GetIsWinnerCombination() bool {
+	if x != nil {
+		return x.IsWinnerCombination
+	}
+	return false
+}
+
+func (x *Guess) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Guess) GetPlayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PlayedAt
+	}
+	return nil
+}
+
+type PlayerGuesses struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Guesses       []*Guess               `protobuf:"bytes,1,rep,name=guesses,proto3" json:"guesses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerGuesses) Reset() {
+	*x = PlayerGuesses{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerGuesses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerGuesses) ProtoMessage() {}
+
+func (x *PlayerGuesses) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerGuesses.ProtoReflect.Descriptor instead.
+func (*PlayerGuesses) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PlayerGuesses) GetGuesses() []*Guess {
+	if x != nil {
+		return x.Guesses
+	}
+	return nil
+}
+
+type MakeGuessResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	IsWinner bool                   `protobuf:"varint,1,opt,name=is_winner,json=isWinner,proto3" json:"is_winner,omitempty"`
+	Winner   string                 `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+	// Keyed by player handle.
+	Guesses       map[string]*PlayerGuesses `protobuf:"bytes,3,rep,name=guesses,proto3" json:"guesses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeGuessResponse) Reset() {
+	*x = MakeGuessResponse{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeGuessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeGuessResponse) ProtoMessage() {}
+
+func (x *MakeGuessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeGuessResponse.ProtoReflect.Descriptor instead.
+func (*MakeGuessResponse) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MakeGuessResponse) GetIsWinner() bool {
+	if x != nil {
+		return x.IsWinner
+	}
+	return false
+}
+
+func (x *MakeGuessResponse) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *MakeGuessResponse) GetGuesses() map[string]*PlayerGuesses {
+	if x != nil {
+		return x.Guesses
+	}
+	return nil
+}
+
+type RematchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchRequest) Reset() {
+	*x = RematchRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchRequest) ProtoMessage() {}
+
+func (x *RematchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchRequest.ProtoReflect.Descriptor instead.
+func (*RematchRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RematchRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RematchRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type RematchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchResponse) Reset() {
+	*x = RematchResponse{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchResponse) ProtoMessage() {}
+
+func (x *RematchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchResponse.ProtoReflect.Descriptor instead.
+func (*RematchResponse) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RematchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchRequest) Reset() {
+	*x = GetMatchRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchRequest) ProtoMessage() {}
+
+func (x *GetMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchRequest.ProtoReflect.Descriptor instead.
+func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetMatchRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type RoundResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Winner        string                 `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+	Guesses       int32                  `protobuf:"varint,3,opt,name=guesses,proto3" json:"guesses,omitempty"`
+	StartedBy     string                 `protobuf:"bytes,4,opt,name=started_by,json=startedBy,proto3" json:"started_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundResult) Reset() {
+	*x = RoundResult{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundResult) ProtoMessage() {}
+
+func (x *RoundResult) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundResult.ProtoReflect.Descriptor instead.
+func (*RoundResult) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RoundResult) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RoundResult) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *RoundResult) GetGuesses() int32 {
+	if x != nil {
+		return x.Guesses
+	}
+	return 0
+}
+
+func (x *RoundResult) GetStartedBy() string {
+	if x != nil {
+		return x.StartedBy
+	}
+	return ""
+}
+
+type Series struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	BestOf int32                  `protobuf:"varint,1,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	// Rounds won keyed by player handle.
+	Score         map[string]int32 `protobuf:"bytes,2,rep,name=score,proto3" json:"score,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Rounds        []*RoundResult   `protobuf:"bytes,3,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	Winner        string           `protobuf:"bytes,4,opt,name=winner,proto3" json:"winner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Series) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *Series) GetScore() map[string]int32 {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *Series) GetRounds() []*RoundResult {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *Series) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+type RematchOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferedBy     string                 `protobuf:"bytes,1,opt,name=offered_by,json=offeredBy,proto3" json:"offered_by,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchOffer) Reset() {
+	*x = RematchOffer{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchOffer) ProtoMessage() {}
+
+func (x *RematchOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchOffer.ProtoReflect.Descriptor instead.
+func (*RematchOffer) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RematchOffer) GetOfferedBy() string {
+	if x != nil {
+		return x.OfferedBy
+	}
+	return ""
+}
+
+func (x *RematchOffer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type MatchState struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RoomId   string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Mode     MatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=bullandcows.v1.MatchMode" json:"mode,omitempty"`
+	Status   MatchStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=bullandcows.v1.MatchStatus" json:"status,omitempty"`
+	IsTurnOf string                 `protobuf:"bytes,4,opt,name=is_turn_of,json=isTurnOf,proto3" json:"is_turn_of,omitempty"`
+	Winner   string                 `protobuf:"bytes,5,opt,name=winner,proto3" json:"winner,omitempty"`
+	Players  []*Player              `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`
+	// Keyed by player handle.
+	Guesses map[string]*PlayerGuesses `protobuf:"bytes,7,rep,name=guesses,proto3" json:"guesses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Series  *Series                   `protobuf:"bytes,8,opt,name=series,proto3" json:"series,omitempty"`
+	// Only set while an offer is pending.
+	RematchOffer  *RematchOffer `protobuf:"bytes,9,opt,name=rematch_offer,json=rematchOffer,proto3" json:"rematch_offer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchState) Reset() {
+	*x = MatchState{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchState) ProtoMessage() {}
+
+func (x *MatchState) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchState.ProtoReflect.Descriptor instead.
+func (*MatchState) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MatchState) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *MatchState) GetMode() MatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MatchMode_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *MatchState) GetStatus() MatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MatchStatus_MATCH_STATUS_UNSPECIFIED
+}
+
+func (x *MatchState) GetIsTurnOf() string {
+	if x != nil {
+		return x.IsTurnOf
+	}
+	return ""
+}
+
+func (x *MatchState) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *MatchState) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *MatchState) GetGuesses() map[string]*PlayerGuesses {
+	if x != nil {
+		return x.Guesses
+	}
+	return nil
+}
+
+func (x *MatchState) GetSeries() *Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *MatchState) GetRematchOffer() *RematchOffer {
+	if x != nil {
+		return x.RematchOffer
+	}
+	return nil
+}
+
+type WatchMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMatchRequest) Reset() {
+	*x = WatchMatchRequest{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMatchRequest) ProtoMessage() {}
+
+func (x *WatchMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchMatchRequest) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchMatchRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type MatchEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  MatchEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=bullandcows.v1.MatchEventType" json:"type,omitempty"`
+	// Missing on MATCH_EXPIRED and MATCH_CLOSED.
+	Match         *MatchState            `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchEvent) Reset() {
+	*x = MatchEvent{}
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchEvent) ProtoMessage() {}
+
+func (x *MatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_bullandcows_v1_matches_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchEvent.ProtoReflect.Descriptor instead.
+func (*MatchEvent) Descriptor() ([]byte, []int) {
+	return file_bullandcows_v1_matches_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MatchEvent) GetType() MatchEventType {
+	if x != nil {
+		return x.Type
+	}
+	return MatchEventType_MATCH_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *MatchEvent) GetMatch() *MatchState {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *MatchEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+var File_bullandcows_v1_matches_proto protoreflect.FileDescriptor
+
+var file_bullandcows_v1_matches_proto_rawDesc = string([]byte{
+	0x0a, 0x1c, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2f, 0x76, 0x31,
+	0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e,
+	0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x4c, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x77, 0x0a,
+	0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62,
+	0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x62, 0x65, 0x73, 0x74, 0x4f, 0x66, 0x22, 0xa5, 0x01, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f,
+	0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65, 0x73, 0x74, 0x4f, 0x66, 0x12, 0x2e,
+	0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x22, 0x46,
+	0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x10, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f,
+	0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f,
+	0x6d, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x22, 0x6f, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x32, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x62, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x2b, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x60, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61,
+	0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x0a, 0x69, 0x73, 0x5f,
+	0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69,
+	0x73, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x66, 0x22, 0x5e, 0x0a, 0x10, 0x4d, 0x61, 0x6b, 0x65, 0x47,
+	0x75, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72,
+	0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x75, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x67, 0x75, 0x65, 0x73, 0x73, 0x22, 0x52, 0x0a, 0x05, 0x44, 0x69, 0x67, 0x69, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64,
+	0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x67, 0x69, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xb9, 0x01, 0x0a, 0x05,
+	0x47, 0x75, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x69, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63,
+	0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x67, 0x69, 0x74, 0x52, 0x06, 0x64, 0x69,
+	0x67, 0x69, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x73, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x13, 0x69, 0x73, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6d,
+	0x62, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x37,
+	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x64, 0x41, 0x74, 0x22, 0x40, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x47, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x67, 0x75, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x75, 0x6c, 0x6c,
+	0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x73,
+	0x52, 0x07, 0x67, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0xed, 0x01, 0x0a, 0x11, 0x4d, 0x61,
+	0x6b, 0x65, 0x47, 0x75, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69,
+	0x6e, 0x6e, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x07, 0x67, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63,
+	0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6b, 0x65, 0x47, 0x75, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x67, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x1a, 0x59,
+	0x0a, 0x0c, 0x47, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x47, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x46, 0x0a, 0x0e, 0x52, 0x65, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72,
+	0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x2b, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x2a,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x74, 0x0a, 0x0b, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x75, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x75, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42, 0x79,
+	0x22, 0xe1, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x62,
+	0x65, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65,
+	0x73, 0x74, 0x4f, 0x66, 0x12, 0x37, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x33, 0x0a,
+	0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x1a, 0x38, 0x0a, 0x0a, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x68, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x5f,
+	0x62, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x65,
+	0x64, 0x42, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x82,
+	0x04, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f,
+	0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63,
+	0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x69, 0x73,
+	0x5f, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x73, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x12, 0x30, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x73, 0x12, 0x41, 0x0a, 0x07, 0x67, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x47, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x67, 0x75,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63,
+	0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x06, 0x73,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62,
+	0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x1a, 0x59, 0x0a, 0x0c, 0x47, 0x75, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x75, 0x6c, 0x6c,
+	0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x47, 0x75, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x2c, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49,
+	0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e,
+	0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02,
+	0x61, 0x74, 0x2a, 0x52, 0x0a, 0x09, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x1a, 0x0a, 0x16, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x54, 0x55, 0x52, 0x4e, 0x53, 0x10,
+	0x01, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x52, 0x41, 0x43, 0x45, 0x10, 0x02, 0x2a, 0x96, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x57, 0x41, 0x49, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1a,
+	0x0a, 0x16, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46,
+	0x55, 0x4c, 0x4c, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x49,
+	0x4e, 0x47, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x04, 0x2a,
+	0x6f, 0x0a, 0x0b, 0x44, 0x69, 0x67, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c,
+	0x0a, 0x18, 0x44, 0x49, 0x47, 0x49, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11,
+	0x44, 0x49, 0x47, 0x49, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x42, 0x55, 0x4c,
+	0x4c, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x49, 0x47, 0x49, 0x54, 0x5f, 0x52, 0x45, 0x53,
+	0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x57, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x49, 0x47,
+	0x49, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x03,
+	0x2a, 0xdb, 0x03, 0x0a, 0x0e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48,
+	0x4f, 0x54, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f,
+	0x4a, 0x4f, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x41, 0x54, 0x43,
+	0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x4c, 0x41,
+	0x59, 0x45, 0x52, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1f,
+	0x0a, 0x1b, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x47, 0x55, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x41, 0x44, 0x45, 0x10, 0x05, 0x12,
+	0x23, 0x0a, 0x1f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48,
+	0x45, 0x44, 0x10, 0x06, 0x12, 0x24, 0x0a, 0x20, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x45, 0x44, 0x10, 0x07, 0x12, 0x26, 0x0a, 0x22, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52,
+	0x45, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44,
+	0x10, 0x08, 0x12, 0x25, 0x0a, 0x21, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x41,
+	0x43, 0x43, 0x45, 0x50, 0x54, 0x45, 0x44, 0x10, 0x09, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x41, 0x54,
+	0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x0a, 0x12, 0x22, 0x0a, 0x1e, 0x4d, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x54, 0x43,
+	0x48, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x21, 0x0a, 0x1d, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x0c, 0x32, 0xc7,
+	0x06, 0x0a, 0x0e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x53, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12,
+	0x21, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x12, 0x1f, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x62,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e,
+	0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x62,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
+	0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63,
+	0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x09, 0x4d, 0x61, 0x6b, 0x65,
+	0x47, 0x75, 0x65, 0x73, 0x73, 0x12, 0x20, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63,
+	0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6b, 0x65, 0x47, 0x75, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e,
+	0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6b, 0x65, 0x47, 0x75, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x2e, 0x62, 0x75, 0x6c,
+	0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x75, 0x6c,
+	0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x41,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x2e, 0x62,
+	0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62,
+	0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a,
+	0x0e, 0x44, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x1e, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x47, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1f, 0x2e, 0x62,
+	0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x2e, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e,
+	0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x75, 0x6c,
+	0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x53, 0x5a, 0x51, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x65, 0x6a, 0x61, 0x6e, 0x64, 0x72, 0x6f,
+	0x2d, 0x63, 0x61, 0x72, 0x64, 0x65, 0x6e, 0x61, 0x73, 0x2d, 0x67, 0x2f, 0x62, 0x75, 0x6c, 0x6c,
+	0x41, 0x6e, 0x64, 0x43, 0x6f, 0x77, 0x73, 0x41, 0x70, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x2f, 0x76, 0x31, 0x3b,
+	0x62, 0x75, 0x6c, 0x6c, 0x61, 0x6e, 0x64, 0x63, 0x6f, 0x77, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_bullandcows_v1_matches_proto_rawDescOnce sync.Once
+	file_bullandcows_v1_matches_proto_rawDescData []byte
+)
+
+func file_bullandcows_v1_matches_proto_rawDescGZIP() []byte {
+	file_bullandcows_v1_matches_proto_rawDescOnce.Do(func() {
+		file_bullandcows_v1_matches_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bullandcows_v1_matches_proto_rawDesc), len(file_bullandcows_v1_matches_proto_rawDesc)))
+	})
+	return file_bullandcows_v1_matches_proto_rawDescData
+}
+
+var file_bullandcows_v1_matches_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_bullandcows_v1_matches_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_bullandcows_v1_matches_proto_goTypes = []any{
+	(MatchMode)(0),                 // 0: bullandcows.v1.MatchMode
+	(MatchStatus)(0),               // 1: bullandcows.v1.MatchStatus
+	(DigitResult)(0),               // 2: bullandcows.v1.DigitResult
+	(MatchEventType)(0),            // 3: bullandcows.v1.MatchEventType
+	(*Player)(nil),                 // 4: bullandcows.v1.Player
+	(*CreateRoomRequest)(nil),      // 5: bullandcows.v1.CreateRoomRequest
+	(*CreateRoomResponse)(nil),     // 6: bullandcows.v1.CreateRoomResponse
+	(*JoinRoomRequest)(nil),        // 7: bullandcows.v1.JoinRoomRequest
+	(*JoinRoomResponse)(nil),       // 8: bullandcows.v1.JoinRoomResponse
+	(*SetCombinationRequest)(nil),  // 9: bullandcows.v1.SetCombinationRequest
+	(*SetCombinationResponse)(nil), // 10: bullandcows.v1.SetCombinationResponse
+	(*StartGameRequest)(nil),       // 11: bullandcows.v1.StartGameRequest
+	(*StartGameResponse)(nil),      // 12: bullandcows.v1.StartGameResponse
+	(*MakeGuessRequest)(nil),       // 13: bullandcows.v1.MakeGuessRequest
+	(*Digit)(nil),                  // 14: bullandcows.v1.Digit
+	(*Guess)(nil),                  // 15: bullandcows.v1.Guess
+	(*PlayerGuesses)(nil),          // 16: bullandcows.v1.PlayerGuesses
+	(*MakeGuessResponse)(nil),      // 17: bullandcows.v1.MakeGuessResponse
+	(*RematchRequest)(nil),         // 18: bullandcows.v1.RematchRequest
+	(*RematchResponse)(nil),        // 19: bullandcows.v1.RematchResponse
+	(*GetMatchRequest)(nil),        // 20: bullandcows.v1.GetMatchRequest
+	(*RoundResult)(nil),            // 21: bullandcows.v1.RoundResult
+	(*Series)(nil),                 // 22: bullandcows.v1.Series
+	(*RematchOffer)(nil),           // 23: bullandcows.v1.RematchOffer
+	(*MatchState)(nil),             // 24: bullandcows.v1.MatchState
+	(*WatchMatchRequest)(nil),      // 25: bullandcows.v1.WatchMatchRequest
+	(*MatchEvent)(nil),             // 26: bullandcows.v1.MatchEvent
+	nil,                            // 27: bullandcows.v1.MakeGuessResponse.GuessesEntry
+	nil,                            // 28: bullandcows.v1.Series.ScoreEntry
+	nil,                            // 29: bullandcows.v1.MatchState.GuessesEntry
+	(*timestamppb.Timestamp)(nil),  // 30: google.protobuf.Timestamp
+}
+var file_bullandcows_v1_matches_proto_depIdxs = []int32{
+	0,  // 0: bullandcows.v1.CreateRoomRequest.mode:type_name -> bullandcows.v1.MatchMode
+	0,  // 1: bullandcows.v1.CreateRoomResponse.mode:type_name -> bullandcows.v1.MatchMode
+	4,  // 2: bullandcows.v1.CreateRoomResponse.player:type_name -> bullandcows.v1.Player
+	4,  // 3: bullandcows.v1.JoinRoomResponse.player:type_name -> bullandcows.v1.Player
+	0,  // 4: bullandcows.v1.StartGameResponse.mode:type_name -> bullandcows.v1.MatchMode
+	2,  // 5: bullandcows.v1.Digit.result:type_name -> bullandcows.v1.DigitResult
+	14, // 6: bullandcows.v1.Guess.digits:type_name -> bullandcows.v1.Digit
+	30, // 7: bullandcows.v1.Guess.played_at:type_name -> google.protobuf.Timestamp
+	15, // 8: bullandcows.v1.PlayerGuesses.guesses:type_name -> bullandcows.v1.Guess
+	27, // 9: bullandcows.v1.MakeGuessResponse.guesses:type_name -> bullandcows.v1.MakeGuessResponse.GuessesEntry
+	28, // 10: bullandcows.v1.Series.score:type_name -> bullandcows.v1.Series.ScoreEntry
+	21, // 11: bullandcows.v1.Series.rounds:type_name -> bullandcows.v1.RoundResult
+	30, // 12: bullandcows.v1.RematchOffer.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 13: bullandcows.v1.MatchState.mode:type_name -> bullandcows.v1.MatchMode
+	1,  // 14: bullandcows.v1.MatchState.status:type_name -> bullandcows.v1.MatchStatus
+	4,  // 15: bullandcows.v1.MatchState.players:type_name -> bullandcows.v1.Player
+	29, // 16: bullandcows.v1.MatchState.guesses:type_name -> bullandcows.v1.MatchState.GuessesEntry
+	22, // 17: bullandcows.v1.MatchState.series:type_name -> bullandcows.v1.Series
+	23, // 18: bullandcows.v1.MatchState.rematch_offer:type_name -> bullandcows.v1.RematchOffer
+	3,  // 19: bullandcows.v1.MatchEvent.type:type_name -> bullandcows.v1.MatchEventType
+	24, // 20: bullandcows.v1.MatchEvent.match:type_name -> bullandcows.v1.MatchState
+	30, // 21: bullandcows.v1.MatchEvent.at:type_name -> google.protobuf.Timestamp
+	16, // 22: bullandcows.v1.MakeGuessResponse.GuessesEntry.value:type_name -> bullandcows.v1.PlayerGuesses
+	16, // 23: bullandcows.v1.MatchState.GuessesEntry.value:type_name -> bullandcows.v1.PlayerGuesses
+	5,  // 24: bullandcows.v1.MatchesService.CreateRoom:input_type -> bullandcows.v1.CreateRoomRequest
+	7,  // 25: bullandcows.v1.MatchesService.JoinRoom:input_type -> bullandcows.v1.JoinRoomRequest
+	9,  // 26: bullandcows.v1.MatchesService.SetCombination:input_type -> bullandcows.v1.SetCombinationRequest
+	11, // 27: bullandcows.v1.MatchesService.StartGame:input_type -> bullandcows.v1.StartGameRequest
+	13, // 28: bullandcows.v1.MatchesService.MakeGuess:input_type -> bullandcows.v1.MakeGuessRequest
+	18, // 29: bullandcows.v1.MatchesService.OfferRematch:input_type -> bullandcows.v1.RematchRequest
+	18, // 30: bullandcows.v1.MatchesService.AcceptRematch:input_type -> bullandcows.v1.RematchRequest
+	18, // 31: bullandcows.v1.MatchesService.DeclineRematch:input_type -> bullandcows.v1.RematchRequest
+	20, // 32: bullandcows.v1.MatchesService.GetMatch:input_type -> bullandcows.v1.GetMatchRequest
+	25, // 33: bullandcows.v1.MatchesService.WatchMatch:input_type -> bullandcows.v1.WatchMatchRequest
+	6,  // 34: bullandcows.v1.MatchesService.CreateRoom:output_type -> bullandcows.v1.CreateRoomResponse
+	8,  // 35: bullandcows.v1.MatchesService.JoinRoom:output_type -> bullandcows.v1.JoinRoomResponse
+	10, // 36: bullandcows.v1.MatchesService.SetCombination:output_type -> bullandcows.v1.SetCombinationResponse
+	12, // 37: bullandcows.v1.MatchesService.StartGame:output_type -> bullandcows.v1.StartGameResponse
+	17, // 38: bullandcows.v1.MatchesService.MakeGuess:output_type -> bullandcows.v1.MakeGuessResponse
+	19, // 39: bullandcows.v1.MatchesService.OfferRematch:output_type -> bullandcows.v1.RematchResponse
+	19, // 40: bullandcows.v1.MatchesService.AcceptRematch:output_type -> bullandcows.v1.RematchResponse
+	19, // 41: bullandcows.v1.MatchesService.DeclineRematch:output_type -> bullandcows.v1.RematchResponse
+	24, // 42: bullandcows.v1.MatchesService.GetMatch:output_type -> bullandcows.v1.MatchState
+	26, // 43: bullandcows.v1.MatchesService.WatchMatch:output_type -> bullandcows.v1.MatchEvent
+	34, // [34:44] is the sub-list for method output_type
+	24, // [24:34] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_bullandcows_v1_matches_proto_init() }
+func file_bullandcows_v1_matches_proto_init() {
+	if File_bullandcows_v1_matches_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bullandcows_v1_matches_proto_rawDesc), len(file_bullandcows_v1_matches_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bullandcows_v1_matches_proto_goTypes,
+		DependencyIndexes: file_bullandcows_v1_matches_proto_depIdxs,
+		EnumInfos:         file_bullandcows_v1_matches_proto_enumTypes,
+		MessageInfos:      file_bullandcows_v1_matches_proto_msgTypes,
+	}.Build()
+	File_bullandcows_v1_matches_proto = out.File
+	file_bullandcows_v1_matches_proto_goTypes = nil
+	file_bullandcows_v1_matches_proto_depIdxs = nil
+}
@@ -0,0 +1,479 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bullandcows/v1/matches.proto
+
+package bullandcowsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MatchesService_CreateRoom_FullMethodName     = "/bullandcows.v1.MatchesService/CreateRoom"
+	MatchesService_JoinRoom_FullMethodName       = "/bullandcows.v1.MatchesService/JoinRoom"
+	MatchesService_SetCombination_FullMethodName = "/bullandcows.v1.MatchesService/SetCombination"
+	MatchesService_StartGame_FullMethodName      = "/bullandcows.v1.MatchesService/StartGame"
+	MatchesService_MakeGuess_FullMethodName      = "/bullandcows.v1.MatchesService/MakeGuess"
+	MatchesService_OfferRematch_FullMethodName   = "/bullandcows.v1.MatchesService/OfferRematch"
+	MatchesService_AcceptRematch_FullMethodName  = "/bullandcows.v1.MatchesService/AcceptRematch"
+	MatchesService_DeclineRematch_FullMethodName = "/bullandcows.v1.MatchesService/DeclineRematch"
+	MatchesService_GetMatch_FullMethodName       = "/bullandcows.v1.MatchesService/GetMatch"
+	MatchesService_WatchMatch_FullMethodName     = "/bullandcows.v1.MatchesService/WatchMatch"
+)
+
+// MatchesServiceClient is the client API for MatchesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MatchesService mirrors the /api/v1/matches REST routes. Errors use the standard
+// status codes and carry a google.rpc.ErrorInfo whose reason is the same stable code
+// returned by the REST API.
+type MatchesServiceClient interface {
+	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*CreateRoomResponse, error)
+	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error)
+	SetCombination(ctx context.Context, in *SetCombinationRequest, opts ...grpc.CallOption) (*SetCombinationResponse, error)
+	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error)
+	MakeGuess(ctx context.Context, in *MakeGuessRequest, opts ...grpc.CallOption) (*MakeGuessResponse, error)
+	OfferRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error)
+	AcceptRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error)
+	DeclineRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error)
+	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*MatchState, error)
+	// WatchMatch sends a snapshot and then the state of the match after every change.
+	// The stream ends after a MATCH_EXPIRED or MATCH_CLOSED event.
+	WatchMatch(ctx context.Context, in *WatchMatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error)
+}
+
+type matchesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchesServiceClient(cc grpc.ClientConnInterface) MatchesServiceClient {
+	return &matchesServiceClient{cc}
+}
+
+func (c *matchesServiceClient) CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*CreateRoomResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRoomResponse)
+	err := c.cc.Invoke(ctx, MatchesService_CreateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinRoomResponse)
+	err := c.cc.Invoke(ctx, MatchesService_JoinRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) SetCombination(ctx context.Context, in *SetCombinationRequest, opts ...grpc.CallOption) (*SetCombinationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCombinationResponse)
+	err := c.cc.Invoke(ctx, MatchesService_SetCombination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartGameResponse)
+	err := c.cc.Invoke(ctx, MatchesService_StartGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) MakeGuess(ctx context.Context, in *MakeGuessRequest, opts ...grpc.CallOption) (*MakeGuessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MakeGuessResponse)
+	err := c.cc.Invoke(ctx, MatchesService_MakeGuess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) OfferRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RematchResponse)
+	err := c.cc.Invoke(ctx, MatchesService_OfferRematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) AcceptRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RematchResponse)
+	err := c.cc.Invoke(ctx, MatchesService_AcceptRematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) DeclineRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RematchResponse)
+	err := c.cc.Invoke(ctx, MatchesService_DeclineRematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*MatchState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchState)
+	err := c.cc.Invoke(ctx, MatchesService_GetMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchesServiceClient) WatchMatch(ctx context.Context, in *WatchMatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchesService_ServiceDesc.Streams[0], MatchesService_WatchMatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMatchRequest, MatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchesService_WatchMatchClient = grpc.ServerStreamingClient[MatchEvent]
+
+// MatchesServiceServer is the server API for MatchesService service.
+// All implementations must embed UnimplementedMatchesServiceServer
+// for forward compatibility.
+//
+// MatchesService mirrors the /api/v1/matches REST routes. Errors use the standard
+// status codes and carry a google.rpc.ErrorInfo whose reason is the same stable code
+// returned by the REST API.
+type MatchesServiceServer interface {
+	CreateRoom(context.Context, *CreateRoomRequest) (*CreateRoomResponse, error)
+	JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error)
+	SetCombination(context.Context, *SetCombinationRequest) (*SetCombinationResponse, error)
+	StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error)
+	MakeGuess(context.Context, *MakeGuessRequest) (*MakeGuessResponse, error)
+	OfferRematch(context.Context, *RematchRequest) (*RematchResponse, error)
+	AcceptRematch(context.Context, *RematchRequest) (*RematchResponse, error)
+	DeclineRematch(context.Context, *RematchRequest) (*RematchResponse, error)
+	GetMatch(context.Context, *GetMatchRequest) (*MatchState, error)
+	// WatchMatch sends a snapshot and then the state of the match after every change.
+	// The stream ends after a MATCH_EXPIRED or MATCH_CLOSED event.
+	WatchMatch(*WatchMatchRequest, grpc.ServerStreamingServer[MatchEvent]) error
+	mustEmbedUnimplementedMatchesServiceServer()
+}
+
+// UnimplementedMatchesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatchesServiceServer struct{}
+
+func (UnimplementedMatchesServiceServer) CreateRoom(context.Context, *CreateRoomRequest) (*CreateRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedMatchesServiceServer) JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
+}
+func (UnimplementedMatchesServiceServer) SetCombination(context.Context, *SetCombinationRequest) (*SetCombinationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCombination not implemented")
+}
+func (UnimplementedMatchesServiceServer) StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGame not implemented")
+}
+func (UnimplementedMatchesServiceServer) MakeGuess(context.Context, *MakeGuessRequest) (*MakeGuessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MakeGuess not implemented")
+}
+func (UnimplementedMatchesServiceServer) OfferRematch(context.Context, *RematchRequest) (*RematchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferRematch not implemented")
+}
+func (UnimplementedMatchesServiceServer) AcceptRematch(context.Context, *RematchRequest) (*RematchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptRematch not implemented")
+}
+func (UnimplementedMatchesServiceServer) DeclineRematch(context.Context, *RematchRequest) (*RematchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineRematch not implemented")
+}
+func (UnimplementedMatchesServiceServer) GetMatch(context.Context, *GetMatchRequest) (*MatchState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (UnimplementedMatchesServiceServer) WatchMatch(*WatchMatchRequest, grpc.ServerStreamingServer[MatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMatch not implemented")
+}
+func (UnimplementedMatchesServiceServer) mustEmbedUnimplementedMatchesServiceServer() {}
+func (UnimplementedMatchesServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeMatchesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchesServiceServer will
+// result in compilation errors.
+type UnsafeMatchesServiceServer interface {
+	mustEmbedUnimplementedMatchesServiceServer()
+}
+
+func RegisterMatchesServiceServer(s grpc.ServiceRegistrar, srv MatchesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMatchesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MatchesService_ServiceDesc, srv)
+}
+
+func _MatchesService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_CreateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).JoinRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_JoinRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).JoinRoom(ctx, req.(*JoinRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_SetCombination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCombinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).SetCombination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_SetCombination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).SetCombination(ctx, req.(*SetCombinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_StartGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).StartGame(ctx, req.(*StartGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_MakeGuess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeGuessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).MakeGuess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_MakeGuess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).MakeGuess(ctx, req.(*MakeGuessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_OfferRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).OfferRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_OfferRematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).OfferRematch(ctx, req.(*RematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_AcceptRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).AcceptRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_AcceptRematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).AcceptRematch(ctx, req.(*RematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_DeclineRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).DeclineRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_DeclineRematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).DeclineRematch(ctx, req.(*RematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchesServiceServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchesService_GetMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchesServiceServer).GetMatch(ctx, req.(*GetMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchesService_WatchMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchesServiceServer).WatchMatch(m, &grpc.GenericServerStream[WatchMatchRequest, MatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchesService_WatchMatchServer = grpc.ServerStreamingServer[MatchEvent]
+
+// MatchesService_ServiceDesc is the grpc.ServiceDesc for MatchesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MatchesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bullandcows.v1.MatchesService",
+	HandlerType: (*MatchesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRoom",
+			Handler:    _MatchesService_CreateRoom_Handler,
+		},
+		{
+			MethodName: "JoinRoom",
+			Handler:    _MatchesService_JoinRoom_Handler,
+		},
+		{
+			MethodName: "SetCombination",
+			Handler:    _MatchesService_SetCombination_Handler,
+		},
+		{
+			MethodName: "StartGame",
+			Handler:    _MatchesService_StartGame_Handler,
+		},
+		{
+			MethodName: "MakeGuess",
+			Handler:    _MatchesService_MakeGuess_Handler,
+		},
+		{
+			MethodName: "OfferRematch",
+			Handler:    _MatchesService_OfferRematch_Handler,
+		},
+		{
+			MethodName: "AcceptRematch",
+			Handler:    _MatchesService_AcceptRematch_Handler,
+		},
+		{
+			MethodName: "DeclineRematch",
+			Handler:    _MatchesService_DeclineRematch_Handler,
+		},
+		{
+			MethodName: "GetMatch",
+			Handler:    _MatchesService_GetMatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMatch",
+			Handler:       _MatchesService_WatchMatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bullandcows/v1/matches.proto",
+}